@@ -30,6 +30,185 @@ type APIServerSpec struct {
 	// used in conjunction with ControlPlaneNodeSelector or ControlPlaneTolerations, then these overrides
 	// take precedence.
 	APIServerDeployment *APIServerDeployment `json:"apiServerDeployment,omitempty"`
+
+	// WatchProgressNotifyInterval is the interval at which the API server requests progress notifications
+	// for its watches. Tuning this may help clusters with a large number of Tigera resources.
+	// If omitted, the API server uses its default interval.
+	// +optional
+	WatchProgressNotifyInterval *metav1.Duration `json:"watchProgressNotifyInterval,omitempty"`
+
+	// ShutdownDelayDuration is the time the API server keeps serving requests after it has been asked to
+	// shut down, giving load balancers and clients time to stop sending it new connections.
+	// If omitted, the API server uses its default shutdown delay.
+	// +optional
+	ShutdownDelayDuration *metav1.Duration `json:"shutdownDelayDuration,omitempty"`
+
+	// EgressSelectorConfigMap references the key of a ConfigMap in the tigera-operator namespace that holds an
+	// EgressSelectorConfiguration for the API server. When specified, the configuration is mounted into the API server
+	// and passed with --egress-selector-config-file, so that the API server can reach its backends on clusters
+	// that use konnectivity.
+	// +optional
+	EgressSelectorConfigMap *v1.ConfigMapKeySelector `json:"egressSelectorConfigMap,omitempty"`
+
+	// TracingConfigMap references the key of a ConfigMap in the tigera-operator namespace that holds a
+	// TracingConfiguration for the API server. When specified, the configuration is mounted into the API server
+	// and passed with --tracing-config-file, so that the API server emits OpenTelemetry traces.
+	// +optional
+	TracingConfigMap *v1.ConfigMapKeySelector `json:"tracingConfigMap,omitempty"`
+
+	// ClientCAConfigMap references the key of a ConfigMap in the tigera-operator namespace that holds one or more
+	// PEM encoded CA certificates. Clients presenting a certificate signed by one of these CAs can authenticate to the
+	// API server with mutual TLS. The CAs are merged with the cluster client CA and passed with --client-ca-file.
+	// +optional
+	ClientCAConfigMap *v1.ConfigMapKeySelector `json:"clientCAConfigMap,omitempty"`
+
+	// PodDisruptionBudget configures the PodDisruptionBudget for the API server. Configuring it requires the API
+	// server to run more than one replica. If omitted, the API server allows a single pod to be unavailable.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+
+	// RevisionHistoryLimit is the number of old ReplicaSets of the API server Deployment that are kept to allow a
+	// rollback. If omitted, the Kubernetes default of 10 is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// LivezExcludedChecks is a list of health check names, for example "etcd" or "poststarthook/start-informers",
+	// that the API server excludes from its /livez endpoint. Each name is passed with --livez-exclude.
+	// +optional
+	LivezExcludedChecks []string `json:"livezExcludedChecks,omitempty"`
+
+	// ReadyzExcludedChecks is a list of health check names that the API server excludes from its /readyz endpoint,
+	// which backs its readiness probe. Each name is passed with --readyz-exclude.
+	// +optional
+	ReadyzExcludedChecks []string `json:"readyzExcludedChecks,omitempty"`
+
+	// ContentionProfiling enables lock contention profiling on the API server, in addition to the regular profiling
+	// endpoints. It is intended for debugging and adds overhead while enabled.
+	// Default: false
+	// +optional
+	ContentionProfiling *bool `json:"contentionProfiling,omitempty"`
+
+	// MinRequestTimeout is the minimum time the API server keeps a long-running request, such as a watch, open
+	// before timing it out. The actual timeout is randomized between this value and twice this value. It must be a
+	// positive whole number of seconds.
+	// If omitted, the API server uses its default of 30 minutes.
+	// +optional
+	MinRequestTimeout *metav1.Duration `json:"minRequestTimeout,omitempty"`
+
+	// EventTTL is how long the API server retains the events it stores, for example 24h. It is passed with
+	// --event-ttl and must be a positive duration.
+	// If omitted, the API server uses its default of 1 hour.
+	// +optional
+	EventTTL *metav1.Duration `json:"eventTTL,omitempty"`
+
+	// CORSAllowedOrigins is a list of regular expressions matching the origins that browsers may call the API
+	// server from, for example "//dashboard\.example\.com$". The expressions are passed with --cors-allowed-origins
+	// and must not contain commas. If omitted, CORS is not enabled.
+	// +optional
+	CORSAllowedOrigins []string `json:"corsAllowedOrigins,omitempty"`
+
+	// APIAudiences is a list of audiences, for example "https://kubernetes.default.svc" or "vault", that the API
+	// server accepts in the service account tokens it validates, such as projected tokens requested with a custom
+	// audience. The audiences are passed with --api-audiences and must not be empty, contain commas or whitespace, or
+	// be repeated. If omitted, the API server uses its default audiences.
+	// +optional
+	APIAudiences []string `json:"apiAudiences,omitempty"`
+
+	// ServiceAccountLookup controls whether the API server verifies that the service account tokens it authenticates
+	// still exist, so that tokens are rejected once they are deleted. It is passed with --service-account-lookup.
+	// Default: true
+	// +optional
+	ServiceAccountLookup *bool `json:"serviceAccountLookup,omitempty"`
+
+	// EnableAggregatorRouting controls whether requests to aggregated API servers are routed to the endpoint IPs of
+	// their services rather than to the service cluster IPs, for clusters where the service IPs are not routable from
+	// the API server. It is passed with --enable-aggregator-routing.
+	// Default: false
+	// +optional
+	EnableAggregatorRouting *bool `json:"enableAggregatorRouting,omitempty"`
+
+	// HTTP2MaxStreamsPerConnection is the maximum number of concurrent HTTP/2 streams the API server allows on a
+	// single client connection. Raising it avoids client errors under heavy concurrent watches. It is passed with
+	// --http2-max-streams-per-connection. If omitted, the API server uses its default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	HTTP2MaxStreamsPerConnection *int32 `json:"http2MaxStreamsPerConnection,omitempty"`
+
+	// GoAwayChance is the probability, between 0 and 0.02, for example "0.001", that the API server sends a GOAWAY to
+	// an HTTP/2 client so that it reconnects, and is likely rebalanced onto another API server replica. It is passed
+	// with --goaway-chance. If omitted, the operator uses 0.001 when the API server runs more than one replica, and
+	// the API server default of 0 otherwise. Set it to "0" to never send a GOAWAY.
+	// +optional
+	GoAwayChance string `json:"goAwayChance,omitempty"`
+
+	// AuditLogRotation configures how the API server rotates its audit log. It only applies to Calico Enterprise,
+	// where the API server writes an audit log.
+	// +optional
+	AuditLogRotation *APIServerAuditLogRotation `json:"auditLogRotation,omitempty"`
+
+	// PacketCapture controls whether the PacketCapture API is deployed alongside the API server. It only applies to
+	// Calico Enterprise. When disabled, the PacketCapture API and its TLS secret are removed from the cluster.
+	// Default: Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PacketCapture *PacketCaptureOption `json:"packetCapture,omitempty"`
+
+	// TLS configures the TLS versions and cipher suites that the API server accepts. If omitted, the API server uses
+	// its defaults.
+	// +optional
+	TLS *APIServerTLS `json:"tls,omitempty"`
+
+	// AdditionalDNSNames is a list of DNS names, for example the name of an internal gateway in front of the API
+	// server, that are added to the subject alternative names of the API server certificate issued by the operator.
+	// The certificate is reissued when the list changes. User supplied certificates are not modified.
+	// +optional
+	AdditionalDNSNames []string `json:"additionalDNSNames,omitempty"`
+}
+
+// APIServerTLS configures the TLS versions and cipher suites that the API server accepts.
+type APIServerTLS struct {
+	// MinVersion is the minimum TLS version that the API server accepts. It is passed with --tls-min-version.
+	// If omitted, the API server uses its default.
+	// +kubebuilder:validation:Enum=VersionTLS10;VersionTLS11;VersionTLS12;VersionTLS13
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// CipherSuites is the list of cipher suites, for example TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, that the API server
+	// accepts. They only apply to TLS 1.2 and earlier, since TLS 1.3 cipher suites are not configurable. They are
+	// passed with --tls-cipher-suites. If omitted, the default cipher suites of Go are used.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// PacketCaptureOption enables or disables the PacketCapture API.
+//
+// One of: Enabled, Disabled
+type PacketCaptureOption string
+
+const (
+	PacketCaptureEnabled  PacketCaptureOption = "Enabled"
+	PacketCaptureDisabled PacketCaptureOption = "Disabled"
+)
+
+// APIServerAuditLogRotation configures the rotation of the API server audit log. Fields that are omitted use the
+// API server defaults.
+type APIServerAuditLogRotation struct {
+	// MaxAge is the maximum number of days to retain old audit log files. It is passed with --audit-log-maxage.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAge *int32 `json:"maxAge,omitempty"`
+
+	// MaxBackup is the maximum number of old audit log files to retain. It is passed with --audit-log-maxbackup.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBackup *int32 `json:"maxBackup,omitempty"`
+
+	// MaxSize is the maximum size in megabytes of the audit log file before it is rotated. It is passed with
+	// --audit-log-maxsize.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSize *int32 `json:"maxSize,omitempty"`
 }
 
 // APIServerStatus defines the observed state of Tigera API server.
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1
 
+import "k8s.io/apimachinery/pkg/util/intstr"
+
 // Metadata contains the standard Kubernetes labels and annotations fields.
 type Metadata struct {
 	// Labels is a map of string keys and values that may match replicaset and
@@ -41,3 +43,15 @@ const (
 	LogLevelFatal LogLevel = "Fatal"
 	LogLevelError LogLevel = "Error"
 )
+
+// PodDisruptionBudgetConfig configures the PodDisruptionBudget rendered for a component.
+// At most one of MinAvailable and MaxUnavailable may be set.
+type PodDisruptionBudgetConfig struct {
+	// MinAvailable is the number or percentage of pods that must remain available during a voluntary disruption.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that may be unavailable during a voluntary disruption.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -42,8 +44,210 @@ type ComplianceSpec struct {
 	// ComplianceReporterPodTemplate configures the Compliance Reporter PodTemplate.
 	// +optional
 	ComplianceReporterPodTemplate *ComplianceReporterPodTemplate `json:"complianceReporterPodTemplate,omitempty"`
+
+	// BenchmarkerRunMode determines how the compliance benchmarker is run. When set to DaemonSet, the benchmarker
+	// runs continuously on every node. When set to CronJob, the benchmarker runs periodically according to
+	// BenchmarkerSchedule. The ComplianceBenchmarkerDaemonSet overrides are applied to the benchmarker pods in either mode.
+	// Default: DaemonSet
+	// +optional
+	BenchmarkerRunMode *BenchmarkerRunMode `json:"benchmarkerRunMode,omitempty"`
+
+	// BenchmarkerSchedule is the cron schedule on which the compliance benchmarker is run when BenchmarkerRunMode
+	// is CronJob. It is ignored otherwise.
+	// Default: "0 * * * *"
+	// +optional
+	BenchmarkerSchedule string `json:"benchmarkerSchedule,omitempty"`
+
+	// JobBackoffLimit is the number of times a failed compliance job is retried before it is marked as failed. It
+	// applies to the report jobs that the compliance controller creates, and to the benchmarker jobs when
+	// BenchmarkerRunMode is CronJob. Lowering it keeps a failing job from being retried so often that its root cause
+	// is hard to find.
+	// Default: 6
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	JobBackoffLimit *int32 `json:"jobBackoffLimit,omitempty"`
+
+	// ServerWorkerPoolSize is the number of workers the compliance server uses to process requests, such as report
+	// generation, concurrently. If omitted, the compliance server uses its default pool size.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ServerWorkerPoolSize *int32 `json:"serverWorkerPoolSize,omitempty"`
+
+	// ServerMaxRequestBodySize is the largest request body the compliance server accepts, for example "10Mi".
+	// Larger requests are rejected. It must be a positive whole number of bytes, no greater than 1Gi.
+	// If omitted, the compliance server uses its default limit.
+	// +optional
+	ServerMaxRequestBodySize *resource.Quantity `json:"serverMaxRequestBodySize,omitempty"`
+
+	// ServerIdleTimeout is how long the compliance server keeps an idle keep-alive connection open before closing it.
+	// Setting it below the idle timeout of a load balancer in front of the compliance server prevents the load
+	// balancer from closing connections the server still considers open. It must be positive.
+	// If omitted, the compliance server uses its default idle timeout.
+	// +optional
+	ServerIdleTimeout *metav1.Duration `json:"serverIdleTimeout,omitempty"`
+
+	// ComplianceServerPodDisruptionBudget configures a PodDisruptionBudget for the compliance server. It is only
+	// rendered when the compliance server runs more than one replica.
+	// +optional
+	ComplianceServerPodDisruptionBudget *PodDisruptionBudgetConfig `json:"complianceServerPodDisruptionBudget,omitempty"`
+
+	// Timezone is the IANA time zone database name, for example "Europe/London", that the compliance components use
+	// for report schedules and timestamps. It is set as the TZ environment variable on the compliance containers.
+	// If omitted, the compliance components use UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// ReportOutputFormats is the list of formats the compliance reporter writes each report in. Listing more than
+	// one format produces a copy of the report in each of them.
+	// Default: [JSON]
+	// +optional
+	ReportOutputFormats []ComplianceReportOutputFormat `json:"reportOutputFormats,omitempty"`
+
+	// ReportRetention configures how long the compliance controller keeps the compliance reports it generates. Older
+	// reports are deleted from Elasticsearch. The LogStorage retention of compliance reports still applies.
+	// If omitted, reports are kept until their indices are removed by the LogStorage retention.
+	// +optional
+	ReportRetention *ComplianceReportRetention `json:"reportRetention,omitempty"`
+
+	// BenchmarkerProfileConfigMap references the key of a ConfigMap in the tigera-operator namespace that holds a
+	// custom CIS benchmark profile, for example a hardened variant of the default profile. When specified, the
+	// profile is mounted into the compliance benchmarker, which runs it instead of its built-in profile.
+	// +optional
+	BenchmarkerProfileConfigMap *corev1.ConfigMapKeySelector `json:"benchmarkerProfileConfigMap,omitempty"`
+
+	// BenchmarkerResultOutput configures a volume that is mounted into the compliance benchmarker, which writes its
+	// benchmark results to it in addition to Elasticsearch.
+	// +optional
+	BenchmarkerResultOutput *ComplianceBenchmarkerResultOutput `json:"benchmarkerResultOutput,omitempty"`
+
+	// SnapshotSchedule is the cron schedule, in the standard five field format or one of the @hourly, @daily,
+	// @weekly, @monthly and @yearly descriptors, on which the compliance snapshotter takes configuration snapshots,
+	// for example "0 * * * *" for hourly snapshots.
+	// If omitted, the snapshotter takes a snapshot once a day.
+	// +optional
+	SnapshotSchedule string `json:"snapshotSchedule,omitempty"`
+
+	// SnapshotterBatchSize is the maximum number of resource snapshots the compliance snapshotter writes in a single
+	// request. Smaller batches reduce the write load on Elasticsearch in large clusters.
+	// If omitted, the snapshotter uses its default batch size.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SnapshotterBatchSize *int32 `json:"snapshotterBatchSize,omitempty"`
+
+	// SnapshotterFlushInterval is the longest time the compliance snapshotter buffers snapshots before writing them,
+	// even if the batch is not full. It must be positive.
+	// If omitted, the snapshotter uses its default flush interval.
+	// +optional
+	SnapshotterFlushInterval *metav1.Duration `json:"snapshotterFlushInterval,omitempty"`
+
+	// PrometheusMetrics controls whether the compliance controller, snapshotter and benchmarker serve Prometheus
+	// metrics, such as the number of succeeded and failed report jobs. When enabled and the Monitor is installed,
+	// ServiceMonitors are created so that the Tigera Prometheus instance scrapes them.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PrometheusMetrics *ComplianceMetricsOption `json:"prometheusMetrics,omitempty"`
+
+	// NoProxy is a list of additional hosts, domains and CIDRs, for example "es.example.com" or "10.0.0.0/8", that the
+	// compliance components connect to directly rather than through the HTTP proxy set in their environment. The
+	// service domains of the cluster, its service CIDRs and its IP pool CIDRs are always included. The entries are set
+	// in the NO_PROXY environment variable of the compliance containers.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// ServerClientCertificateVerification controls whether the compliance server requires its clients to present a
+	// TLS certificate signed by a CA in the trusted bundle of the operator. When enabled, clients without such a
+	// certificate are rejected, and the liveness and readiness probes of the compliance server only check that it
+	// accepts TCP connections.
+	// Default: Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	ServerClientCertificateVerification *ComplianceTLSVerificationOption `json:"serverClientCertificateVerification,omitempty"`
+
+	// SoftMemoryLimitPercent is the percentage of its memory limit that each compliance container uses as the soft
+	// memory limit of the Go runtime, set with the GOMEMLIMIT environment variable. The runtime collects garbage more
+	// aggressively as it approaches the soft limit, which keeps it from being OOM killed. It only applies to the
+	// containers that have a memory limit, which can be set through the component overrides.
+	// Default: 90
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SoftMemoryLimitPercent *int32 `json:"softMemoryLimitPercent,omitempty"`
+}
+
+// ComplianceMetricsOption enables or disables the Prometheus metrics of the compliance components.
+//
+// One of: Enabled, Disabled
+type ComplianceMetricsOption string
+
+const (
+	ComplianceMetricsEnabled  ComplianceMetricsOption = "Enabled"
+	ComplianceMetricsDisabled ComplianceMetricsOption = "Disabled"
+)
+
+// ComplianceBenchmarkerResultOutput is a volume that the compliance benchmarker writes its results to. Exactly one of
+// HostPath and PersistentVolumeClaimName must be specified.
+type ComplianceBenchmarkerResultOutput struct {
+	// Path is the absolute path in the benchmarker container, for example /var/log/calico/benchmarks, at which the
+	// volume is mounted and under which the benchmarker writes its results. It must not be the root directory.
+	Path string `json:"path"`
+
+	// HostPath is the absolute path of a directory on each node to write the results of the benchmarker running on
+	// that node to.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+
+	// PersistentVolumeClaimName is the name of a PersistentVolumeClaim in the tigera-compliance namespace to write
+	// the results to. When the benchmarker runs on more than one node, the claim must support the ReadWriteMany
+	// access mode.
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
 }
 
+// ComplianceTLSVerificationOption enables or disables the verification of a TLS certificate by the compliance
+// components.
+//
+// One of: Enabled, Disabled
+type ComplianceTLSVerificationOption string
+
+const (
+	ComplianceTLSVerificationEnabled  ComplianceTLSVerificationOption = "Enabled"
+	ComplianceTLSVerificationDisabled ComplianceTLSVerificationOption = "Disabled"
+)
+
+// ComplianceReportRetention limits the compliance reports that are kept by age, by count or by both. At least one of
+// MaxAge and MaxReports must be specified.
+type ComplianceReportRetention struct {
+	// MaxAge is the age, for example 720h, after which a compliance report is deleted. It must be positive.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MaxReports is the number of the most recent reports of each GlobalReport that are kept. Older reports are
+	// deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReports *int32 `json:"maxReports,omitempty"`
+}
+
+// ComplianceReportOutputFormat is a format in which the compliance reporter writes reports.
+// +kubebuilder:validation:Enum=JSON;CSV;YAML
+type ComplianceReportOutputFormat string
+
+const (
+	ComplianceReportOutputFormatJSON ComplianceReportOutputFormat = "JSON"
+	ComplianceReportOutputFormatCSV  ComplianceReportOutputFormat = "CSV"
+	ComplianceReportOutputFormatYAML ComplianceReportOutputFormat = "YAML"
+)
+
+// BenchmarkerRunMode is the mode used to run the compliance benchmarker.
+// +kubebuilder:validation:Enum=DaemonSet;CronJob
+type BenchmarkerRunMode string
+
+const (
+	BenchmarkerRunModeDaemonSet BenchmarkerRunMode = "DaemonSet"
+	BenchmarkerRunModeCronJob   BenchmarkerRunMode = "CronJob"
+)
+
 // ComplianceStatus defines the observed state of Tigera compliance reporting capabilities.
 type ComplianceStatus struct {
 
@@ -73,6 +73,19 @@ type InstallationSpec struct {
 	// +optional
 	ImagePrefix string `json:"imagePrefix,omitempty"`
 
+	// RegistryOverrides allows for the registry of individual component images to be specified, keyed by the name
+	// of the image, for example calico/node or tigera/cnx-apiserver. If specified then the given registry takes
+	// precedence over Registry for that image. Each value must end with a slash character (`/`).
+	// A special case value, UseDefault, is supported to explicitly specify the default registry of the image
+	// will be used. ImagePath, ImagePrefix and ImageSet digests still apply to the overridden images.
+	//
+	// Image format:
+	//    `<registry><imagePath>/<imagePrefix><imageName>:<image-tag>`
+	//
+	// This option allows configuring the `<registry>` portion of the above format for individual images.
+	// +optional
+	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+
 	// ImagePullSecrets is an array of references to container registry pull secrets to use. These are
 	// applied to all images to be pulled.
 	// +optional
@@ -196,6 +209,18 @@ type InstallationSpec struct {
 	// Kubernetes Service CIDRs. Specifying this is required when using Calico for Windows.
 	// +optional
 	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// AdditionalLabels are labels added to the resources that the operator creates for the apiserver, monitor and
+	// compliance components, for example to identify them to GitOps tooling. Labels that the operator sets itself
+	// take precedence. Labels removed from this list are not removed from existing resources.
+	// +optional
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+
+	// AdditionalAnnotations are annotations added to the resources that the operator creates for the apiserver,
+	// monitor and compliance components. Annotations that the operator sets itself take precedence. Annotations
+	// removed from this list are not removed from existing resources.
+	// +optional
+	AdditionalAnnotations map[string]string `json:"additionalAnnotations,omitempty"`
 }
 
 type Logging struct {
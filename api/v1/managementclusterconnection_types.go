@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,12 +28,33 @@ type ManagementClusterConnectionSpec struct {
 	// +optional
 	ManagementClusterAddr string `json:"managementClusterAddr,omitempty"`
 
+	// ManagementClusterAddrs is an ordered list of additional addresses, in the same form as ManagementClusterAddr,
+	// where the managed cluster can reach the management cluster, for example the ingress addresses of a highly
+	// available management cluster. Guardian tries the addresses in order, starting with ManagementClusterAddr if it
+	// is specified, and fails over to the next address when it cannot reach the management cluster. This field is used
+	// by managed clusters only.
+	// +optional
+	ManagementClusterAddrs []string `json:"managementClusterAddrs,omitempty"`
+
 	// TLS provides options for configuring how Managed Clusters can establish an mTLS connection with the Management Cluster.
 	// +optional
 	TLS *ManagementClusterTLS `json:"tls,omitempty"`
 
 	// GuardianDeployment configures the guardian Deployment.
 	GuardianDeployment *GuardianDeployment `json:"guardianDeployment,omitempty"`
+
+	// ReconnectTimeout is how long Guardian waits for an attempt to establish the tunnel to the management cluster
+	// to complete before it gives up and retries, for example 30s. It must be a positive duration.
+	// If omitted, Guardian uses its built-in timeout, which is the behavior of existing installations.
+	// +optional
+	ReconnectTimeout *metav1.Duration `json:"reconnectTimeout,omitempty"`
+
+	// KeepAliveInterval is the interval at which Guardian sends keepalive probes over the tunnel to the management
+	// cluster, for example 10s, so that broken connections are detected and re-established sooner. It must be a
+	// positive duration.
+	// If omitted, Guardian uses its built-in interval, which is the behavior of existing installations.
+	// +optional
+	KeepAliveInterval *metav1.Duration `json:"keepAliveInterval,omitempty"`
 }
 
 type ManagementClusterTLS struct {
@@ -47,6 +69,17 @@ type ManagementClusterTLS struct {
 	//
 	// +kubebuilder:validation:Enum=Tigera;Public
 	CA CAType `json:"ca,omitempty"`
+
+	// CABundleSecret references the key of a Secret in the tigera-operator namespace that holds a PEM-encoded
+	// certificate bundle. When specified, the tunnel client also trusts the certificates in the bundle to verify the
+	// tunnel server's identity. This allows pinning the expected tunnel server certificate or trusting a custom CA.
+	// +optional
+	CABundleSecret *corev1.SecretKeySelector `json:"caBundleSecret,omitempty"`
+
+	// ServerName is the name that the tunnel client expects the tunnel server's certificate to be issued for.
+	// If omitted, the name implied by CA is expected.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
 }
 
 // CAType specifies which verification method the tunnel client should use to verify the tunnel server's identity.
@@ -62,6 +95,9 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether the tunnel to the management cluster is established."
+// +kubebuilder:printcolumn:name="Progressing",type="string",JSONPath=".status.conditions[?(@.type=='Progressing')].status",description="Whether the connection is processing changes."
+// +kubebuilder:printcolumn:name="Degraded",type="string",JSONPath=".status.conditions[?(@.type=='Degraded')].status",description="Whether the connection is degraded."
 
 // ManagementClusterConnection represents a link between a managed cluster and a management cluster. At most one
 // instance of this resource is supported. It must be named "tigera-secure".
@@ -19,6 +19,7 @@ package v1
 import (
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,6 +37,369 @@ type MonitorSpec struct {
 	// AlertManager is the configuration for the AlertManager.
 	// +optional
 	AlertManager *AlertManager `json:"alertManager,omitempty"`
+
+	// MetricRelabelConfigs are applied to the samples scraped by the ServiceMonitors that the operator creates in the
+	// tigera-prometheus namespace, before ingestion. They can be used, for example, to drop high-cardinality metrics
+	// to reduce Prometheus memory usage.
+	// More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs
+	// +optional
+	MetricRelabelConfigs []*v1.RelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// AlertmanagerMode controls whether the operator deploys Alertmanager. When disabled, Prometheus still evaluates
+	// its rules but does not send the resulting alerts anywhere, and the Alertmanager configuration secret is neither
+	// read nor copied. It cannot be disabled while ExternalAlertmanager or AlertmanagerExternalAccess is set.
+	// Default: Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	AlertmanagerMode *AlertmanagerModeOption `json:"alertmanagerMode,omitempty"`
+
+	// AlertmanagerExternalAccess optionally exposes Alertmanager through an authenticating proxy, in the same way the
+	// Prometheus API is exposed. Requests are authenticated with Kubernetes tokens and, when an Authentication
+	// resource is configured, with tokens issued by its identity provider.
+	// +optional
+	AlertmanagerExternalAccess *AlertmanagerExternalAccess `json:"alertmanagerExternalAccess,omitempty"`
+
+	// AlertmanagerMeshPolicy controls whether the operator renders the network policy that allows the Alertmanager
+	// replicas to gossip with each other on port 9094 to form a cluster. It can only be disabled while Alertmanager
+	// runs a single replica, which has no peers to reach.
+	// Default: Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	AlertmanagerMeshPolicy *AlertmanagerMeshPolicyOption `json:"alertmanagerMeshPolicy,omitempty"`
+
+	// AlertmanagerLogLevel is the level at which Alertmanager logs. Alertmanager supports the Error, Warn, Info and
+	// Debug levels.
+	// Default: Info
+	// +kubebuilder:validation:Enum=Error;Warn;Info;Debug
+	// +optional
+	AlertmanagerLogLevel *LogLevel `json:"alertmanagerLogLevel,omitempty"`
+
+	// AlertmanagerReplicas is the number of Alertmanager replicas to run, for example 3 for highly available alerting.
+	// If omitted, the control plane replica count of the Installation is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AlertmanagerReplicas *int32 `json:"alertmanagerReplicas,omitempty"`
+
+	// PrometheusReplicas is the number of Prometheus replicas to run, for example 2 for highly available monitoring.
+	// Each replica scrapes all targets independently. When more than one replica runs, the replicas are spread
+	// across nodes and zones using pod anti-affinity.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PrometheusReplicas *int32 `json:"prometheusReplicas,omitempty"`
+
+	// ExternalAlertmanager is a list of URLs of existing Alertmanagers, for example
+	// https://alertmanager.example.com:9093, that Prometheus sends its alerts to. When set, the operator does not
+	// deploy its own Alertmanager.
+	// +optional
+	ExternalAlertmanager []string `json:"externalAlertmanager,omitempty"`
+
+	// ScrapeConfig configures the scrape interval and timeout of the ServiceMonitors that the operator creates in the
+	// tigera-prometheus namespace.
+	// +optional
+	ScrapeConfig *ScrapeConfig `json:"scrapeConfig,omitempty"`
+
+	// Federation configures the operator's Prometheus to federate metrics from other Prometheus servers, for example
+	// to collect the metrics of several clusters in a hub cluster. Each source is scraped by an additional scrape job.
+	// +optional
+	Federation []PrometheusFederationSource `json:"federation,omitempty"`
+
+	// TSDB configures the block durations of the Prometheus time series database, which control how often data is
+	// compacted, and the ingestion of out-of-order samples.
+	// +optional
+	TSDB *PrometheusTSDB `json:"tsdb,omitempty"`
+
+	// RoutePrefix is the path prefix, for example /prometheus, under which Prometheus serves its API and web UI, so
+	// that it can be served from a sub-path behind a shared ingress. It is passed to Prometheus with
+	// --web.route-prefix, and the operator managed clients of Prometheus use it when they query Prometheus.
+	// If omitted, Prometheus is served from the root path.
+	// +optional
+	RoutePrefix string `json:"routePrefix,omitempty"`
+
+	// PrometheusFeatures is the list of experimental Prometheus features to enable, for example exemplar-storage.
+	// They are passed to Prometheus with --enable-feature.
+	// +optional
+	PrometheusFeatures []PrometheusFeature `json:"prometheusFeatures,omitempty"`
+
+	// Retention is how long Prometheus keeps the metrics it collects, for example 30d. It is passed to Prometheus with
+	// --storage.tsdb.retention.time.
+	// Default: 24h
+	// +optional
+	Retention v1.Duration `json:"retention,omitempty"`
+
+	// StorageSize is the size of the PersistentVolumeClaim that each Prometheus replica stores its data in, for
+	// example 50Gi. The claims are provisioned with StorageClassName, or the default StorageClass if it is omitted,
+	// and their size cannot be reduced once they exist. If omitted, Prometheus stores its data in an emptyDir volume,
+	// which is lost when the pod is rescheduled.
+	// +optional
+	StorageSize *resource.Quantity `json:"storageSize,omitempty"`
+
+	// StorageClassName is the name of the StorageClass that the Prometheus PersistentVolumeClaims are provisioned
+	// with, for example one backed by SSDs. It is ignored unless StorageSize is specified. The StorageClass of an
+	// existing claim cannot be changed, so changing it requires deleting the claims, and the data they hold, by hand.
+	// If omitted, the default StorageClass is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// RemoteWrite configures Prometheus to forward the metrics it collects to remote storage backends, for example
+	// a central Thanos or Cortex deployment.
+	// +optional
+	RemoteWrite []PrometheusRemoteWrite `json:"remoteWrite,omitempty"`
+
+	// RemoteRead configures Prometheus to also read metrics from remote storage backends when it answers queries, for
+	// example to query long-term data kept in a Thanos or Cortex deployment.
+	// +optional
+	RemoteRead []PrometheusRemoteRead `json:"remoteRead,omitempty"`
+
+	// ScrapeInterval is the default interval, for example 15s, at which Prometheus scrapes the targets that do not
+	// set their own interval, such as the federation sources. The operator managed ServiceMonitors are configured
+	// with ScrapeConfig instead. It must not be less than 5s.
+	// If omitted, the Prometheus operator default of 30s is used.
+	// +optional
+	ScrapeInterval v1.Duration `json:"scrapeInterval,omitempty"`
+
+	// EvaluationInterval is the interval, for example 15s, at which Prometheus evaluates its alerting and recording
+	// rules. It must not be less than 5s.
+	// If omitted, the Prometheus operator default of 30s is used.
+	// +optional
+	EvaluationInterval v1.Duration `json:"evaluationInterval,omitempty"`
+
+	// QueryTimeout is the maximum time a Prometheus query may take before it is aborted, for example 2m. It is passed
+	// to Prometheus with --query.timeout.
+	// If omitted, the Prometheus default of 2m is used.
+	// +optional
+	QueryTimeout v1.Duration `json:"queryTimeout,omitempty"`
+
+	// PrometheusMaxConnections is the maximum number of simultaneous connections that Prometheus accepts on its web
+	// endpoint, which serves queries and the web UI. It is passed to Prometheus with --web.max-connections.
+	// If omitted, the Prometheus default of 512 is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PrometheusMaxConnections *int32 `json:"prometheusMaxConnections,omitempty"`
+
+	// AdditionalServiceMonitors configures ServiceMonitors that the operator creates in the tigera-prometheus
+	// namespace next to its own, so that Prometheus also scrapes user defined targets, such as the metrics of a
+	// sidecar. The operator only removes the ServiceMonitors that it created for this list; ServiceMonitors created by
+	// users are left alone.
+	// +optional
+	AdditionalServiceMonitors []AdditionalServiceMonitor `json:"additionalServiceMonitors,omitempty"`
+}
+
+// AdditionalServiceMonitor configures a ServiceMonitor that scrapes the Services selected by its namespaces and label
+// selector.
+type AdditionalServiceMonitor struct {
+	// Name is the name of the ServiceMonitor. It must not be the name of a ServiceMonitor that the operator manages
+	// itself.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespaces are the namespaces of the Services to scrape.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// Selector selects the Services to scrape by their labels.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Endpoints are the endpoints of the selected Services to scrape.
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []AdditionalServiceMonitorEndpoint `json:"endpoints"`
+}
+
+// AdditionalServiceMonitorEndpoint configures an endpoint of an additional ServiceMonitor. Endpoints are scraped over
+// HTTP.
+type AdditionalServiceMonitorEndpoint struct {
+	// Port is the number of the target port to scrape. Prometheus is allowed to reach it by the network policy of the
+	// tigera-prometheus namespace.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path is the HTTP path to scrape.
+	// Default: /metrics
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Interval at which metrics are scraped.
+	// If omitted, the Prometheus scrape interval is used.
+	// +optional
+	Interval v1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout after which the scrape is ended. It must not be greater than Interval.
+	// If omitted, the Prometheus scrape timeout is used.
+	// +optional
+	ScrapeTimeout v1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// HonorLabels chooses the metric's labels on collisions with target labels.
+	// +optional
+	HonorLabels bool `json:"honorLabels,omitempty"`
+
+	// MetricRelabelConfigs to apply to samples before ingestion.
+	// +optional
+	MetricRelabelConfigs []*v1.RelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// RelabelConfigs to apply to samples before scraping.
+	// +optional
+	RelabelConfigs []*v1.RelabelConfig `json:"relabelings,omitempty"`
+}
+
+// PrometheusFeature is an experimental Prometheus feature that can be enabled with --enable-feature.
+// +kubebuilder:validation:Enum=exemplar-storage;expand-external-labels;extra-scrape-metrics;memory-snapshot-on-shutdown;native-histograms;new-service-discovery-manager;no-default-scrape-port;auto-gomaxprocs
+type PrometheusFeature string
+
+const (
+	PrometheusFeatureExemplarStorage            PrometheusFeature = "exemplar-storage"
+	PrometheusFeatureExpandExternalLabels       PrometheusFeature = "expand-external-labels"
+	PrometheusFeatureExtraScrapeMetrics         PrometheusFeature = "extra-scrape-metrics"
+	PrometheusFeatureMemorySnapshotOnShutdown   PrometheusFeature = "memory-snapshot-on-shutdown"
+	PrometheusFeatureNativeHistograms           PrometheusFeature = "native-histograms"
+	PrometheusFeatureNewServiceDiscoveryManager PrometheusFeature = "new-service-discovery-manager"
+	PrometheusFeatureNoDefaultScrapePort        PrometheusFeature = "no-default-scrape-port"
+	PrometheusFeatureAutoGOMAXPROCS             PrometheusFeature = "auto-gomaxprocs"
+)
+
+// PrometheusTSDB configures the time series database of the operator's Prometheus.
+type PrometheusTSDB struct {
+	// MinBlockDuration is the minimum duration of a data block before it is persisted. It is passed to Prometheus
+	// with --storage.tsdb.min-block-duration and must not be greater than MaxBlockDuration.
+	// If omitted, the Prometheus default of 2h is used.
+	// +optional
+	MinBlockDuration v1.Duration `json:"minBlockDuration,omitempty"`
+
+	// MaxBlockDuration is the maximum duration that compacted blocks may span. It is passed to Prometheus with
+	// --storage.tsdb.max-block-duration.
+	// If omitted, the Prometheus default of 10% of the retention period is used.
+	// +optional
+	MaxBlockDuration v1.Duration `json:"maxBlockDuration,omitempty"`
+
+	// OutOfOrderTimeWindow is how old an out-of-order sample may be, relative to the newest sample, and still be
+	// ingested, for example 30m for samples that arrive late from remote clusters. It is passed to Prometheus with
+	// --storage.tsdb.out-of-order-time-window.
+	// If omitted, out-of-order samples are rejected.
+	// +optional
+	OutOfOrderTimeWindow v1.Duration `json:"outOfOrderTimeWindow,omitempty"`
+}
+
+// PrometheusFederationSource is a Prometheus server that metrics are federated from.
+type PrometheusFederationSource struct {
+	// Name identifies the source. The scrape job for the source is named federate-<name>.
+	// +required
+	Name string `json:"name"`
+
+	// Endpoint is the base URL of the Prometheus server, for example https://prometheus.example.com:9090. Metrics
+	// are read from its /federate path.
+	// +required
+	Endpoint string `json:"endpoint"`
+
+	// Match is the list of series selectors, for example {job="calico-node-metrics"}, that select the series to
+	// federate from the source.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Match []string `json:"match"`
+}
+
+// PrometheusRemoteWrite is a remote storage backend that Prometheus forwards its metrics to.
+type PrometheusRemoteWrite struct {
+	// URL of the remote write endpoint, for example https://thanos-receive.example.com/api/v1/receive.
+	// +required
+	URL string `json:"url"`
+
+	// BasicAuthSecret references a Secret in the tigera-operator namespace that holds the username and password, under
+	// the username and password keys, used to authenticate with the endpoint.
+	// +optional
+	BasicAuthSecret *corev1.LocalObjectReference `json:"basicAuthSecret,omitempty"`
+
+	// TLSSecret references a Secret in the tigera-operator namespace that holds the client certificate and key, under
+	// the tls.crt and tls.key keys, used to authenticate with the endpoint. If the Secret also holds a ca.crt key, it
+	// is used to verify the endpoint's certificate.
+	// +optional
+	TLSSecret *corev1.LocalObjectReference `json:"tlsSecret,omitempty"`
+}
+
+// PrometheusRemoteRead is a remote storage backend that Prometheus reads metrics from.
+type PrometheusRemoteRead struct {
+	// URL of the remote read endpoint, for example https://thanos-query.example.com/api/v1/read.
+	// +required
+	URL string `json:"url"`
+
+	// ReadRecent makes Prometheus also read from the endpoint for time ranges that its local storage holds complete
+	// data for. By default, only older time ranges are read from the endpoint.
+	// +optional
+	ReadRecent bool `json:"readRecent,omitempty"`
+
+	// BasicAuthSecret references a Secret in the tigera-operator namespace that holds the username and password, under
+	// the username and password keys, used to authenticate with the endpoint.
+	// +optional
+	BasicAuthSecret *corev1.LocalObjectReference `json:"basicAuthSecret,omitempty"`
+
+	// TLSSecret references a Secret in the tigera-operator namespace that holds the client certificate and key, under
+	// the tls.crt and tls.key keys, used to authenticate with the endpoint. If the Secret also holds a ca.crt key, it
+	// is used to verify the endpoint's certificate.
+	// +optional
+	TLSSecret *corev1.LocalObjectReference `json:"tlsSecret,omitempty"`
+}
+
+// ScrapeConfig configures how Prometheus scrapes the operator managed ServiceMonitors.
+type ScrapeConfig struct {
+	// Interval at which the targets of the operator managed ServiceMonitors are scraped.
+	// Default: 5s
+	// +optional
+	Interval v1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout after which a scrape of the targets of the operator managed ServiceMonitors is ended. It must not
+	// be greater than the interval.
+	// Default: 5s
+	// +optional
+	ScrapeTimeout v1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// ServiceMonitors overrides the interval and timeout of individual operator managed ServiceMonitors, such as
+	// calico-node-monitor or elasticsearch-metrics. Values that are not set are taken from the global configuration.
+	// +optional
+	ServiceMonitors []ServiceMonitorScrapeConfig `json:"serviceMonitors,omitempty"`
+}
+
+// ServiceMonitorScrapeConfig configures how Prometheus scrapes a single operator managed ServiceMonitor.
+type ServiceMonitorScrapeConfig struct {
+	// Name is the name of the ServiceMonitor in the tigera-prometheus namespace.
+	// +required
+	Name string `json:"name"`
+
+	// Interval at which the targets of the ServiceMonitor are scraped.
+	// +optional
+	Interval v1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout after which a scrape of the targets of the ServiceMonitor is ended. It must not be greater than
+	// the interval.
+	// +optional
+	ScrapeTimeout v1.Duration `json:"scrapeTimeout,omitempty"`
+}
+
+// AlertmanagerModeOption enables or disables the Alertmanager deployed by the operator.
+//
+// One of: Enabled, Disabled
+type AlertmanagerModeOption string
+
+const (
+	AlertmanagerModeEnabled  AlertmanagerModeOption = "Enabled"
+	AlertmanagerModeDisabled AlertmanagerModeOption = "Disabled"
+)
+
+// AlertmanagerMeshPolicyOption enables or disables the Alertmanager mesh network policy.
+//
+// One of: Enabled, Disabled
+type AlertmanagerMeshPolicyOption string
+
+const (
+	AlertmanagerMeshPolicyEnabled  AlertmanagerMeshPolicyOption = "Enabled"
+	AlertmanagerMeshPolicyDisabled AlertmanagerMeshPolicyOption = "Disabled"
+)
+
+// AlertmanagerExternalAccess configures the Service that exposes the Alertmanager authenticating proxy.
+type AlertmanagerExternalAccess struct {
+	// ServiceType is the type of the Service that exposes the Alertmanager authenticating proxy.
+	// Default: ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 }
 
 type ExternalPrometheus struct {
@@ -141,6 +505,11 @@ type Prometheus struct {
 type PrometheusSpec struct {
 	// CommonPrometheusFields are the options available to both the Prometheus server and agent.
 	CommonPrometheusFields *CommonPrometheusFields `json:"commonPrometheusFields,omitempty"`
+
+	// PodDisruptionBudget configures a PodDisruptionBudget for Prometheus. It is only rendered when Prometheus
+	// runs more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
 }
 type CommonPrometheusFields struct {
 
@@ -176,6 +545,11 @@ type AlertManager struct {
 type AlertManagerSpec struct {
 	// Define resources requests and limits for single Pods.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodDisruptionBudget configures a PodDisruptionBudget for Alertmanager. It is only rendered when Alertmanager
+	// runs more than one replica.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
 }
 
 func (c *Prometheus) GetContainers() []corev1.Container {
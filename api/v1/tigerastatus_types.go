@@ -137,6 +137,8 @@ const (
 	UpgradeError              TigeraStatusReason = "UpgradeError"
 	Unknown                   TigeraStatusReason = "Unknown"
 	ImageSetError             TigeraStatusReason = "ImageSetError"
+	MissingCRD                TigeraStatusReason = "MissingCRD"
+	DependencyTimeout         TigeraStatusReason = "DependencyTimeout"
 )
 
 func init() {
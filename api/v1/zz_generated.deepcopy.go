@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -56,6 +57,36 @@ func (in *APIServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerAuditLogRotation) DeepCopyInto(out *APIServerAuditLogRotation) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackup != nil {
+		in, out := &in.MaxBackup, &out.MaxBackup
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerAuditLogRotation.
+func (in *APIServerAuditLogRotation) DeepCopy() *APIServerAuditLogRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerAuditLogRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIServerDeployment) DeepCopyInto(out *APIServerDeployment) {
 	*out = *in
@@ -266,6 +297,111 @@ func (in *APIServerSpec) DeepCopyInto(out *APIServerSpec) {
 		*out = new(APIServerDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WatchProgressNotifyInterval != nil {
+		in, out := &in.WatchProgressNotifyInterval, &out.WatchProgressNotifyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ShutdownDelayDuration != nil {
+		in, out := &in.ShutdownDelayDuration, &out.ShutdownDelayDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EgressSelectorConfigMap != nil {
+		in, out := &in.EgressSelectorConfigMap, &out.EgressSelectorConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TracingConfigMap != nil {
+		in, out := &in.TracingConfigMap, &out.TracingConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCAConfigMap != nil {
+		in, out := &in.ClientCAConfigMap, &out.ClientCAConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LivezExcludedChecks != nil {
+		in, out := &in.LivezExcludedChecks, &out.LivezExcludedChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadyzExcludedChecks != nil {
+		in, out := &in.ReadyzExcludedChecks, &out.ReadyzExcludedChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentionProfiling != nil {
+		in, out := &in.ContentionProfiling, &out.ContentionProfiling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinRequestTimeout != nil {
+		in, out := &in.MinRequestTimeout, &out.MinRequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EventTTL != nil {
+		in, out := &in.EventTTL, &out.EventTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CORSAllowedOrigins != nil {
+		in, out := &in.CORSAllowedOrigins, &out.CORSAllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIAudiences != nil {
+		in, out := &in.APIAudiences, &out.APIAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountLookup != nil {
+		in, out := &in.ServiceAccountLookup, &out.ServiceAccountLookup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableAggregatorRouting != nil {
+		in, out := &in.EnableAggregatorRouting, &out.EnableAggregatorRouting
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTP2MaxStreamsPerConnection != nil {
+		in, out := &in.HTTP2MaxStreamsPerConnection, &out.HTTP2MaxStreamsPerConnection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AuditLogRotation != nil {
+		in, out := &in.AuditLogRotation, &out.AuditLogRotation
+		*out = new(APIServerAuditLogRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PacketCapture != nil {
+		in, out := &in.PacketCapture, &out.PacketCapture
+		*out = new(PacketCaptureOption)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(APIServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalDNSNames != nil {
+		in, out := &in.AdditionalDNSNames, &out.AdditionalDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSpec.
@@ -300,6 +436,26 @@ func (in *APIServerStatus) DeepCopy() *APIServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerTLS) DeepCopyInto(out *APIServerTLS) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerTLS.
+func (in *APIServerTLS) DeepCopy() *APIServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSEgressGateway) DeepCopyInto(out *AWSEgressGateway) {
 	*out = *in
@@ -375,6 +531,71 @@ func (in *AdditionalLogStoreSpec) DeepCopy() *AdditionalLogStoreSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalServiceMonitor) DeepCopyInto(out *AdditionalServiceMonitor) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]AdditionalServiceMonitorEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalServiceMonitor.
+func (in *AdditionalServiceMonitor) DeepCopy() *AdditionalServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalServiceMonitorEndpoint) DeepCopyInto(out *AdditionalServiceMonitorEndpoint) {
+	*out = *in
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalServiceMonitorEndpoint.
+func (in *AdditionalServiceMonitorEndpoint) DeepCopy() *AdditionalServiceMonitorEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalServiceMonitorEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertManager) DeepCopyInto(out *AlertManager) {
 	*out = *in
@@ -399,6 +620,11 @@ func (in *AlertManager) DeepCopy() *AlertManager {
 func (in *AlertManagerSpec) DeepCopyInto(out *AlertManagerSpec) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertManagerSpec.
@@ -411,6 +637,21 @@ func (in *AlertManagerSpec) DeepCopy() *AlertManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerExternalAccess) DeepCopyInto(out *AlertmanagerExternalAccess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerExternalAccess.
+func (in *AlertmanagerExternalAccess) DeepCopy() *AlertmanagerExternalAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerExternalAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AnomalyDetectionSpec) DeepCopyInto(out *AnomalyDetectionSpec) {
 	*out = *in
@@ -1803,6 +2044,21 @@ func (in *ComplianceBenchmarkerDaemonSetSpec) DeepCopy() *ComplianceBenchmarkerD
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceBenchmarkerResultOutput) DeepCopyInto(out *ComplianceBenchmarkerResultOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBenchmarkerResultOutput.
+func (in *ComplianceBenchmarkerResultOutput) DeepCopy() *ComplianceBenchmarkerResultOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceBenchmarkerResultOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceControllerDeployment) DeepCopyInto(out *ComplianceControllerDeployment) {
 	*out = *in
@@ -1964,6 +2220,31 @@ func (in *ComplianceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceReportRetention) DeepCopyInto(out *ComplianceReportRetention) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxReports != nil {
+		in, out := &in.MaxReports, &out.MaxReports
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReportRetention.
+func (in *ComplianceReportRetention) DeepCopy() *ComplianceReportRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceReportRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceReporterPodSpec) DeepCopyInto(out *ComplianceReporterPodSpec) {
 	*out = *in
@@ -2359,6 +2640,86 @@ func (in *ComplianceSpec) DeepCopyInto(out *ComplianceSpec) {
 		*out = new(ComplianceReporterPodTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BenchmarkerRunMode != nil {
+		in, out := &in.BenchmarkerRunMode, &out.BenchmarkerRunMode
+		*out = new(BenchmarkerRunMode)
+		**out = **in
+	}
+	if in.JobBackoffLimit != nil {
+		in, out := &in.JobBackoffLimit, &out.JobBackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServerWorkerPoolSize != nil {
+		in, out := &in.ServerWorkerPoolSize, &out.ServerWorkerPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServerMaxRequestBodySize != nil {
+		in, out := &in.ServerMaxRequestBodySize, &out.ServerMaxRequestBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ServerIdleTimeout != nil {
+		in, out := &in.ServerIdleTimeout, &out.ServerIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ComplianceServerPodDisruptionBudget != nil {
+		in, out := &in.ComplianceServerPodDisruptionBudget, &out.ComplianceServerPodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportOutputFormats != nil {
+		in, out := &in.ReportOutputFormats, &out.ReportOutputFormats
+		*out = make([]ComplianceReportOutputFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReportRetention != nil {
+		in, out := &in.ReportRetention, &out.ReportRetention
+		*out = new(ComplianceReportRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BenchmarkerProfileConfigMap != nil {
+		in, out := &in.BenchmarkerProfileConfigMap, &out.BenchmarkerProfileConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BenchmarkerResultOutput != nil {
+		in, out := &in.BenchmarkerResultOutput, &out.BenchmarkerResultOutput
+		*out = new(ComplianceBenchmarkerResultOutput)
+		**out = **in
+	}
+	if in.SnapshotterBatchSize != nil {
+		in, out := &in.SnapshotterBatchSize, &out.SnapshotterBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SnapshotterFlushInterval != nil {
+		in, out := &in.SnapshotterFlushInterval, &out.SnapshotterFlushInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PrometheusMetrics != nil {
+		in, out := &in.PrometheusMetrics, &out.PrometheusMetrics
+		*out = new(ComplianceMetricsOption)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerClientCertificateVerification != nil {
+		in, out := &in.ServerClientCertificateVerification, &out.ServerClientCertificateVerification
+		*out = new(ComplianceTLSVerificationOption)
+		**out = **in
+	}
+	if in.SoftMemoryLimitPercent != nil {
+		in, out := &in.SoftMemoryLimitPercent, &out.SoftMemoryLimitPercent
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSpec.
@@ -4042,6 +4403,13 @@ func (in *InstallationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 	*out = *in
+	if in.RegistryOverrides != nil {
+		in, out := &in.RegistryOverrides, &out.RegistryOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]corev1.LocalObjectReference, len(*in))
@@ -4159,6 +4527,20 @@ func (in *InstallationSpec) DeepCopyInto(out *InstallationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdditionalAnnotations != nil {
+		in, out := &in.AdditionalAnnotations, &out.AdditionalAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationSpec.
@@ -5267,16 +5649,31 @@ func (in *ManagementClusterConnectionList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementClusterConnectionSpec) DeepCopyInto(out *ManagementClusterConnectionSpec) {
 	*out = *in
+	if in.ManagementClusterAddrs != nil {
+		in, out := &in.ManagementClusterAddrs, &out.ManagementClusterAddrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(ManagementClusterTLS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GuardianDeployment != nil {
 		in, out := &in.GuardianDeployment, &out.GuardianDeployment
 		*out = new(GuardianDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReconnectTimeout != nil {
+		in, out := &in.ReconnectTimeout, &out.ReconnectTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeepAliveInterval != nil {
+		in, out := &in.KeepAliveInterval, &out.KeepAliveInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterConnectionSpec.
@@ -5366,6 +5763,11 @@ func (in *ManagementClusterSpec) DeepCopy() *ManagementClusterSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementClusterTLS) DeepCopyInto(out *ManagementClusterTLS) {
 	*out = *in
+	if in.CABundleSecret != nil {
+		in, out := &in.CABundleSecret, &out.CABundleSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementClusterTLS.
@@ -5714,6 +6116,105 @@ func (in *MonitorSpec) DeepCopyInto(out *MonitorSpec) {
 		*out = new(AlertManager)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.AlertmanagerMode != nil {
+		in, out := &in.AlertmanagerMode, &out.AlertmanagerMode
+		*out = new(AlertmanagerModeOption)
+		**out = **in
+	}
+	if in.AlertmanagerExternalAccess != nil {
+		in, out := &in.AlertmanagerExternalAccess, &out.AlertmanagerExternalAccess
+		*out = new(AlertmanagerExternalAccess)
+		**out = **in
+	}
+	if in.AlertmanagerMeshPolicy != nil {
+		in, out := &in.AlertmanagerMeshPolicy, &out.AlertmanagerMeshPolicy
+		*out = new(AlertmanagerMeshPolicyOption)
+		**out = **in
+	}
+	if in.AlertmanagerLogLevel != nil {
+		in, out := &in.AlertmanagerLogLevel, &out.AlertmanagerLogLevel
+		*out = new(LogLevel)
+		**out = **in
+	}
+	if in.AlertmanagerReplicas != nil {
+		in, out := &in.AlertmanagerReplicas, &out.AlertmanagerReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PrometheusReplicas != nil {
+		in, out := &in.PrometheusReplicas, &out.PrometheusReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExternalAlertmanager != nil {
+		in, out := &in.ExternalAlertmanager, &out.ExternalAlertmanager
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScrapeConfig != nil {
+		in, out := &in.ScrapeConfig, &out.ScrapeConfig
+		*out = new(ScrapeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Federation != nil {
+		in, out := &in.Federation, &out.Federation
+		*out = make([]PrometheusFederationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TSDB != nil {
+		in, out := &in.TSDB, &out.TSDB
+		*out = new(PrometheusTSDB)
+		**out = **in
+	}
+	if in.PrometheusFeatures != nil {
+		in, out := &in.PrometheusFeatures, &out.PrometheusFeatures
+		*out = make([]PrometheusFeature, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageSize != nil {
+		in, out := &in.StorageSize, &out.StorageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]PrometheusRemoteWrite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoteRead != nil {
+		in, out := &in.RemoteRead, &out.RemoteRead
+		*out = make([]PrometheusRemoteRead, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrometheusMaxConnections != nil {
+		in, out := &in.PrometheusMaxConnections, &out.PrometheusMaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AdditionalServiceMonitors != nil {
+		in, out := &in.AdditionalServiceMonitors, &out.AdditionalServiceMonitors
+		*out = make([]AdditionalServiceMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorSpec.
@@ -5892,6 +6393,31 @@ func (in *PathMatch) DeepCopy() *PathMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyRecommendation) DeepCopyInto(out *PolicyRecommendation) {
 	*out = *in
@@ -6155,6 +6681,76 @@ func (in *PrometheusContainer) DeepCopy() *PrometheusContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusFederationSource) DeepCopyInto(out *PrometheusFederationSource) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusFederationSource.
+func (in *PrometheusFederationSource) DeepCopy() *PrometheusFederationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusFederationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteRead) DeepCopyInto(out *PrometheusRemoteRead) {
+	*out = *in
+	if in.BasicAuthSecret != nil {
+		in, out := &in.BasicAuthSecret, &out.BasicAuthSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TLSSecret != nil {
+		in, out := &in.TLSSecret, &out.TLSSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteRead.
+func (in *PrometheusRemoteRead) DeepCopy() *PrometheusRemoteRead {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteRead)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWrite) DeepCopyInto(out *PrometheusRemoteWrite) {
+	*out = *in
+	if in.BasicAuthSecret != nil {
+		in, out := &in.BasicAuthSecret, &out.BasicAuthSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TLSSecret != nil {
+		in, out := &in.TLSSecret, &out.TLSSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWrite.
+func (in *PrometheusRemoteWrite) DeepCopy() *PrometheusRemoteWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 	*out = *in
@@ -6163,6 +6759,11 @@ func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 		*out = new(CommonPrometheusFields)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSpec.
@@ -6175,6 +6776,21 @@ func (in *PrometheusSpec) DeepCopy() *PrometheusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusTSDB) DeepCopyInto(out *PrometheusTSDB) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusTSDB.
+func (in *PrometheusTSDB) DeepCopy() *PrometheusTSDB {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusTSDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Retention) DeepCopyInto(out *Retention) {
 	*out = *in
@@ -6250,6 +6866,26 @@ func (in *SNIMatch) DeepCopy() *SNIMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeConfig) DeepCopyInto(out *ScrapeConfig) {
+	*out = *in
+	if in.ServiceMonitors != nil {
+		in, out := &in.ServiceMonitors, &out.ServiceMonitors
+		*out = make([]ServiceMonitorScrapeConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeConfig.
+func (in *ScrapeConfig) DeepCopy() *ScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceMonitor) DeepCopyInto(out *ServiceMonitor) {
 	*out = *in
@@ -6279,6 +6915,21 @@ func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorScrapeConfig) DeepCopyInto(out *ServiceMonitorScrapeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorScrapeConfig.
+func (in *ServiceMonitorScrapeConfig) DeepCopy() *ServiceMonitorScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SplunkStoreSpec) DeepCopyInto(out *SplunkStoreSpec) {
 	*out = *in
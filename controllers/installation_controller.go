@@ -37,5 +37,10 @@ type InstallationReconciler struct {
 // +kubebuilder:rbac:groups=operator.tigera.io,resources=installations/status,verbs=get;update;patch
 
 func (r *InstallationReconciler) SetupWithManager(mgr ctrl.Manager, opts options.AddOptions) error {
-	return installation.Add(mgr, opts)
+	if err := installation.Add(mgr, opts); err != nil {
+		return err
+	}
+
+	// The status events controller records the condition transitions of every TigeraStatus as Events on the Installation.
+	return installation.AddStatusEventsController(mgr, opts)
 }
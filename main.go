@@ -18,6 +18,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	goruntime "runtime"
@@ -45,9 +46,11 @@ import (
 	"github.com/tigera/operator/pkg/render/logstorage"
 	"github.com/tigera/operator/version"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -93,6 +96,8 @@ func printVersion() {
 
 func main() {
 	var enableLeaderElection bool
+	var leaderElectionID string
+	var leaderElectionNamespace string
 	// urlOnlyKubeconfig is a slight hack; we need to get the apiserver from the
 	// kubeconfig but should use the in-cluster service account
 	var urlOnlyKubeconfig string
@@ -103,10 +108,27 @@ func main() {
 	var sgSetup bool
 	var manageCRDs bool
 	var preDelete bool
+	var useServerSideApply bool
+	var unmanagedResourceSelector string
+	var enableOperatorNetworkPolicy bool
+	var manageWebhookServerCert bool
+	var resyncPeriod time.Duration
+	var verifyMultiArchImages bool
+	var certificateDuration time.Duration
+	var certificateRenewalWindow time.Duration
+	var auditSpecChanges bool
+	var dependencyWaitTimeout time.Duration
+	var requeueInterval time.Duration
+	var dryRun bool
+	var clusterDomainOverride string
 
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionID, "leader-election-id", options.DefaultLeaderElectionID,
+		"Name of the lease used for leader election. Operators that must not compete for leadership, e.g. one per tenant, need distinct leases.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
+		"Namespace of the lease used for leader election. Defaults to the namespace the operator runs in.")
 	flag.StringVar(&urlOnlyKubeconfig, "url-only-kubeconfig", "",
 		"Path to a kubeconfig, but only for the apiserver url.")
 	flag.BoolVar(&showVersion, "version", false,
@@ -123,6 +145,32 @@ func main() {
 		"Operator should manage the projectcalico.org and operator.tigera.io CRDs.")
 	flag.BoolVar(&preDelete, "pre-delete", false,
 		"Run helm pre-deletion hook logic, then exit.")
+	flag.BoolVar(&useServerSideApply, "use-server-side-apply", false,
+		"Make the apiserver, monitor and compliance controllers apply the resources they manage using server-side apply instead of client-side create and update.")
+	flag.StringVar(&unmanagedResourceSelector, "unmanaged-resource-selector", "",
+		"Label selector of existing resources the apiserver, monitor and compliance controllers should not update, e.g. 'example.com/managed=manual'. By default all resources are managed.")
+	flag.BoolVar(&enableOperatorNetworkPolicy, "enable-operator-network-policy", false,
+		"Render a NetworkPolicy restricting the operator pod's egress to the API server and the namespaces it manages.")
+	flag.BoolVar(&manageWebhookServerCert, "manage-webhook-server-cert", false,
+		"Issue the webhook server certificate in the tigera-operator-webhook-tls secret and inject its CA into the tigera-operator webhook configurations.")
+	flag.DurationVar(&resyncPeriod, "resync-period", 0,
+		"Interval at which the apiserver, monitor and compliance controllers reconcile even without changes, e.g. '10m'. Disabled by default.")
+	flag.BoolVar(&verifyMultiArchImages, "verify-multi-arch-images", false,
+		"Warn when the digests of the ImageSet in use are not multi-arch manifests. Manifests are read anonymously from the image registries.")
+	flag.DurationVar(&certificateDuration, "certificate-duration", 0,
+		"Validity of the certificates the apiserver, monitor and compliance controllers issue, e.g. '2160h'. Existing operator issued certificates that are valid for longer are replaced. Defaults to 825 days.")
+	flag.DurationVar(&certificateRenewalWindow, "certificate-renewal-window", 0,
+		"How long before expiry the apiserver, monitor and compliance controllers renew the certificates they issue, e.g. '168h'. Defaults to 30 days.")
+	flag.BoolVar(&auditSpecChanges, "audit-spec-changes", false,
+		"Log the before and after of the spec fields that change each time the apiserver, monitor and compliance controllers reconcile a new generation of their CR.")
+	flag.DurationVar(&dependencyWaitTimeout, "dependency-wait-timeout", 0,
+		"How long the monitor controller waits for the Prometheus operator CRDs to be installed before reporting the dependency as missing, e.g. '30m'. Waits indefinitely by default.")
+	flag.DurationVar(&requeueInterval, "requeue-interval", 0,
+		"How long the apiserver, monitor and compliance controllers wait before reconciling again when the resources they depend on are not yet available, e.g. '1m'. Defaults to 30s.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Log the objects the apiserver, monitor and compliance controllers would create, update or delete, and the changes they would make, without writing them.")
+	flag.StringVar(&clusterDomainOverride, "cluster-domain", "",
+		"The cluster domain used in the DNS names of services. By default it is detected from /etc/resolv.conf or the resolver, falling back to cluster.local.")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -139,10 +187,7 @@ func main() {
 	}
 	if printImages != "" {
 		if strings.ToLower(printImages) == "list" {
-			cmpnts := components.CalicoImages
-			cmpnts = append(cmpnts, components.EnterpriseImages...)
-
-			for _, x := range cmpnts {
+			for _, x := range components.AllComponents() {
 				ref, _ := components.GetReference(x, "", "", "", nil)
 				fmt.Println(ref)
 			}
@@ -233,12 +278,16 @@ func main() {
 	active.WaitUntilActive(cs, c, sigHandler, setupLog)
 	log.Info("Active operator: proceeding")
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	options := options.AddOptions{
+		LeaderElectionID:        leaderElectionID,
+		LeaderElectionNamespace: leaderElectionNamespace,
+	}
+
+	mgrOptions := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr(),
-		Port:               9443,
+		Port:               render.OperatorWebhookPort,
 		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "operator-lock",
 		// We should test this again in the future to see if the problem with LicenseKey updates
 		// being missed is resolved. Prior to controller-runtime 0.7 we observed Test failures
 		// where LicenseKey updates would be missed and the client cache did not have the LicenseKey.
@@ -270,9 +319,15 @@ func main() {
 			ByObject: map[client.Object]cache.ByObject{
 				&v3.NetworkPolicy{}:       {Label: policySelector},
 				&v3.GlobalNetworkPolicy{}: {Label: policySelector},
+
+				// The operator only reads the endpoints of the kubernetes service, to restrict its own egress to
+				// the API server.
+				&corev1.Endpoints{}: {Field: fields.SelectorFromSet(fields.Set{"metadata.name": "kubernetes", "metadata.namespace": metav1.NamespaceDefault})},
 			},
 		},
-	})
+	}
+	options.ConfigureLeaderElection(&mgrOptions)
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -385,11 +440,16 @@ func main() {
 	}
 	setupLog.WithValues("required", enterpriseCRDExists).Info("Checking if TSEE controllers are required")
 
-	clusterDomain, err := dns.GetClusterDomain(dns.DefaultResolveConfPath)
+	clusterDomain, err := dns.DetectClusterDomain(clusterDomainOverride, dns.DefaultResolveConfPath, net.DefaultResolver)
 	if err != nil {
+		if clusterDomainOverride != "" {
+			setupLog.Error(err, "Invalid --cluster-domain")
+			os.Exit(1)
+		}
 		clusterDomain = dns.DefaultClusterDomain
-		log.Error(err, fmt.Sprintf("Couldn't find the cluster domain from the resolv.conf, defaulting to %s", clusterDomain))
+		log.Error(err, fmt.Sprintf("Couldn't detect the cluster domain, defaulting to %s", clusterDomain))
 	}
+	setupLog.WithValues("clusterDomain", clusterDomain).Info("Using cluster domain")
 
 	kubernetesVersion, err := common.GetKubernetesVersion(clientset)
 	if err != nil {
@@ -434,16 +494,33 @@ func main() {
 		os.Exit(1)
 	}
 
-	options := options.AddOptions{
-		DetectedProvider:    provider,
-		EnterpriseCRDExists: enterpriseCRDExists,
-		UsePSP:              usePSP,
-		ClusterDomain:       clusterDomain,
-		KubernetesVersion:   kubernetesVersion,
-		ManageCRDs:          manageCRDs,
-		ShutdownContext:     ctx,
-		MultiTenant:         multiTenant,
-		ElasticExternal:     utils.UseExternalElastic(bootConfig),
+	options.DetectedProvider = provider
+	options.EnterpriseCRDExists = enterpriseCRDExists
+	options.UsePSP = usePSP
+	options.ClusterDomain = clusterDomain
+	options.KubernetesVersion = kubernetesVersion
+	options.ManageCRDs = manageCRDs
+	options.ShutdownContext = ctx
+	options.MultiTenant = multiTenant
+	options.ElasticExternal = utils.UseExternalElastic(bootConfig)
+	options.OperatorNetworkPolicy = enableOperatorNetworkPolicy
+	options.ManageWebhookServerCert = manageWebhookServerCert
+	options.ResyncPeriod = resyncPeriod
+	options.VerifyMultiArchImages = verifyMultiArchImages
+	options.CertificateDuration = certificateDuration
+	options.CertificateRenewalWindow = certificateRenewalWindow
+	options.AuditSpecChanges = auditSpecChanges
+	options.DependencyWaitTimeout = dependencyWaitTimeout
+	options.RequeueInterval = requeueInterval
+	options.DryRun = dryRun
+	options.UseServerSideApply = useServerSideApply
+	if unmanagedResourceSelector != "" {
+		selector, err := labels.Parse(unmanagedResourceSelector)
+		if err != nil {
+			setupLog.Error(err, "Invalid unmanaged resource selector", "selector", unmanagedResourceSelector)
+			os.Exit(1)
+		}
+		options.UnmanagedResourceSelector = selector
 	}
 
 	// Before we start any controllers, make sure our options are valid.
@@ -0,0 +1,38 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+// Component is an image the operator deploys, along with the version of the image that this release of the
+// operator uses. The components are generated from the release versions into calico.go and enterprise.go.
+type Component = component
+
+// AllComponents returns the components of both variants, the Calico components first. Components that only
+// provide a version, and not an image, are not included.
+func AllComponents() []Component {
+	all := make([]Component, 0, len(CalicoImages)+len(EnterpriseImages))
+	all = append(all, CalicoImages...)
+	return append(all, EnterpriseImages...)
+}
+
+// GetComponentByImage returns the component with the given image name, for example calico/node. Components that
+// share an image, such as the FIPS variants of a component, resolve to the first of them in AllComponents.
+func GetComponentByImage(image string) (Component, bool) {
+	for _, c := range AllComponents() {
+		if c.Image == image {
+			return c, true
+		}
+	}
+	return Component{}, false
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("component lookup", func() {
+	It("should return the components of both variants", func() {
+		all := AllComponents()
+		Expect(all).To(HaveLen(len(CalicoImages) + len(EnterpriseImages)))
+		Expect(all).To(ContainElements(ComponentCalicoNode, ComponentOperatorInit, ComponentTigeraNode, ComponentManager))
+	})
+
+	It("should not let callers modify the generated image lists", func() {
+		all := AllComponents()
+		all[0] = Component{Image: "example/other"}
+		Expect(CalicoImages[0].Image).NotTo(Equal("example/other"))
+	})
+
+	DescribeTable("should find known images",
+		func(image string, expected Component) {
+			c, ok := GetComponentByImage(image)
+			Expect(ok).To(BeTrue())
+			Expect(c).To(Equal(expected))
+		},
+		Entry("a Calico image", "calico/node", ComponentCalicoNode),
+		Entry("an Enterprise image", "tigera/cnx-apiserver", ComponentAPIServer),
+		Entry("the operator init image", "tigera/operator", ComponentOperatorInit),
+		Entry("an image shared with a FIPS component", "tigera/elasticsearch", ComponentElasticsearch),
+	)
+
+	DescribeTable("should not find unknown images",
+		func(image string) {
+			_, ok := GetComponentByImage(image)
+			Expect(ok).To(BeFalse())
+		},
+		Entry("an unknown image", "example/unknown"),
+		Entry("an image with a registry", "docker.io/calico/node"),
+		Entry("an empty image", ""),
+	)
+})
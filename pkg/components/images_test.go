@@ -132,3 +132,42 @@ var _ = Describe("test GetReference", func() {
 		)
 	})
 })
+
+var _ = Describe("test GetInstallationReference", func() {
+	installation := &op.InstallationSpec{
+		Registry:  "quay.io/",
+		ImagePath: "userpath",
+		RegistryOverrides: map[string]string{
+			"calico/node":                 "mirror.example.com/",
+			"tigera/cnx-node":             "mirror.example.com/",
+			"tigera/key-cert-provisioner": UseDefault,
+		},
+	}
+
+	DescribeTable("should render a mix of overridden and default components",
+		func(c component, expected string) {
+			Expect(GetInstallationReference(c, installation, nil)).To(Equal(fmt.Sprintf("%s:%s", expected, c.Version)))
+		},
+		Entry("an overridden calico image", ComponentCalicoNode, "mirror.example.com/userpath/node"),
+		Entry("an overridden tigera image", ComponentTigeraNode, "mirror.example.com/userpath/cnx-node"),
+		Entry("an image overridden to its default registry", ComponentTigeraCSRInitContainer, TigeraRegistry+"userpath/key-cert-provisioner"),
+		Entry("a calico image without an override", ComponentCalicoTypha, "quay.io/userpath/typha"),
+		Entry("a tigera image without an override", ComponentElasticsearchOperator, "quay.io/userpath/eck-operator"),
+	)
+
+	DescribeTable("should render digests from an ImageSet",
+		func(c component, expected string) {
+			is := &op.ImageSet{
+				Spec: op.ImageSetSpec{
+					Images: []op.Image{
+						{Image: "calico/node", Digest: "sha256:caliconodehash"},
+						{Image: "calico/typha", Digest: "sha256:calicotyphahash"},
+					},
+				},
+			}
+			Expect(GetInstallationReference(c, installation, is)).To(Equal(expected))
+		},
+		Entry("for an overridden image", ComponentCalicoNode, "mirror.example.com/userpath/node@sha256:caliconodehash"),
+		Entry("for an image without an override", ComponentCalicoTypha, "quay.io/userpath/typha@sha256:calicotyphahash"),
+	)
+})
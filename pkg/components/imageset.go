@@ -0,0 +1,106 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	operator "github.com/tigera/operator/api/v1"
+)
+
+// ImageSetName returns the name of the ImageSet the operator uses for the given variant.
+func ImageSetName(v operator.ProductVariant) string {
+	if v == operator.TigeraSecureEnterprise {
+		return fmt.Sprintf("enterprise-%s", EnterpriseRelease)
+	}
+	return fmt.Sprintf("calico-%s", CalicoRelease)
+}
+
+// ImageSetImages returns the sorted, de-duplicated names of the images an ImageSet must list for the given variant.
+// FIPS components share the image name of their non-FIPS counterpart so they do not need an entry of their own.
+func ImageSetImages(v operator.ProductVariant) []string {
+	comps := CalicoImages
+	if v == operator.TigeraSecureEnterprise {
+		// The operator init image is used by both variants but is only part of the Calico image list.
+		comps = append([]component{ComponentOperatorInit}, EnterpriseImages...)
+	}
+
+	seen := map[string]bool{}
+	images := []string{}
+	for _, c := range comps {
+		if !seen[c.Image] {
+			seen[c.Image] = true
+			images = append(images, c.Image)
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+// ValidateImageSet cross-checks the ImageSet against the components of this operator release for the given variant.
+// The ImageSet must be named for the release and must list every image of the variant exactly once, and no others.
+func ValidateImageSet(is *operator.ImageSet, v operator.ProductVariant) error {
+	// None is valid
+	if is == nil {
+		return nil
+	}
+
+	errMsgs := []string{}
+	if name := ImageSetName(v); is.Name != name {
+		errMsgs = append(errMsgs, fmt.Sprintf("expected the name %s for variant %s", name, v))
+	}
+
+	expected := map[string]bool{}
+	for _, img := range ImageSetImages(v) {
+		expected[img] = true
+	}
+
+	listed := map[string]bool{}
+	unexpected := []string{}
+	duplicated := []string{}
+	for _, img := range is.Spec.Images {
+		switch {
+		case !expected[img.Image]:
+			unexpected = append(unexpected, img.Image)
+		case listed[img.Image]:
+			duplicated = append(duplicated, img.Image)
+		}
+		listed[img.Image] = true
+	}
+
+	missing := []string{}
+	for _, img := range ImageSetImages(v) {
+		if !listed[img] {
+			missing = append(missing, img)
+		}
+	}
+
+	if len(missing) != 0 {
+		errMsgs = append(errMsgs, fmt.Sprintf("missing images: %s", strings.Join(missing, ", ")))
+	}
+	if len(unexpected) != 0 {
+		errMsgs = append(errMsgs, fmt.Sprintf("unexpected images for variant %s: %s", v, strings.Join(unexpected, ", ")))
+	}
+	if len(duplicated) != 0 {
+		errMsgs = append(errMsgs, fmt.Sprintf("duplicated images: %s", strings.Join(duplicated, ", ")))
+	}
+
+	if len(errMsgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("ImageSet %s: %s", is.Name, strings.Join(errMsgs, "; "))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	op "github.com/tigera/operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func completeImageSet(v op.ProductVariant) *op.ImageSet {
+	is := &op.ImageSet{ObjectMeta: metav1.ObjectMeta{Name: ImageSetName(v)}}
+	for _, img := range ImageSetImages(v) {
+		is.Spec.Images = append(is.Spec.Images, op.Image{Image: img, Digest: "sha256:deadbeef"})
+	}
+	return is
+}
+
+var _ = Describe("test ValidateImageSet", func() {
+	It("should accept no ImageSet", func() {
+		Expect(ValidateImageSet(nil, op.Calico)).NotTo(HaveOccurred())
+	})
+
+	It("should accept a complete Calico ImageSet", func() {
+		Expect(ValidateImageSet(completeImageSet(op.Calico), op.Calico)).NotTo(HaveOccurred())
+	})
+
+	It("should accept a complete Enterprise ImageSet", func() {
+		is := completeImageSet(op.TigeraSecureEnterprise)
+		Expect(is.Spec.Images).To(ContainElement(op.Image{Image: ComponentOperatorInit.Image, Digest: "sha256:deadbeef"}))
+		Expect(ValidateImageSet(is, op.TigeraSecureEnterprise)).NotTo(HaveOccurred())
+	})
+
+	It("should list an image shared by FIPS and non-FIPS components only once", func() {
+		images := ImageSetImages(op.Calico)
+		seen := map[string]bool{}
+		for _, img := range images {
+			Expect(seen).NotTo(HaveKey(img))
+			seen[img] = true
+		}
+		Expect(seen).To(HaveKey(ComponentCalicoNodeFIPS.Image))
+	})
+
+	It("should report the missing images of an incomplete ImageSet", func() {
+		is := completeImageSet(op.TigeraSecureEnterprise)
+		is.Spec.Images = is.Spec.Images[1:]
+		err := ValidateImageSet(is, op.TigeraSecureEnterprise)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("ImageSet enterprise-" + EnterpriseRelease + ": missing images: " + ImageSetImages(op.TigeraSecureEnterprise)[0]))
+	})
+
+	It("should report the images of the other variant as unexpected", func() {
+		is := completeImageSet(op.Calico)
+		is.Spec.Images = append(is.Spec.Images, op.Image{Image: ComponentTigeraNode.Image, Digest: "sha256:deadbeef"})
+		err := ValidateImageSet(is, op.Calico)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unexpected images for variant Calico: tigera/cnx-node"))
+	})
+
+	It("should report duplicated images", func() {
+		is := completeImageSet(op.Calico)
+		is.Spec.Images = append(is.Spec.Images, is.Spec.Images[0])
+		err := ValidateImageSet(is, op.Calico)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("duplicated images: " + is.Spec.Images[0].Image))
+	})
+
+	It("should report an ImageSet for another release", func() {
+		is := completeImageSet(op.Calico)
+		is.Name = "calico-v0.0.0"
+		err := ValidateImageSet(is, op.Calico)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("expected the name calico-" + CalicoRelease))
+	})
+})
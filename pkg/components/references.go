@@ -93,6 +93,16 @@ func GetReference(c component, registry, imagePath, imagePrefix string, is *oper
 	return "", fmt.Errorf("ImageSet did not contain image %s", c.Image)
 }
 
+// GetInstallationReference returns the fully qualified image to use for the component in the given installation. The
+// registry override of the installation for the component's image, if any, takes precedence over its registry.
+func GetInstallationReference(c component, installation *operator.InstallationSpec, is *operator.ImageSet) (string, error) {
+	registry := installation.Registry
+	if override, ok := installation.RegistryOverrides[c.Image]; ok {
+		registry = override
+	}
+	return GetReference(c, registry, installation.ImagePath, installation.ImagePrefix, is)
+}
+
 func ReplaceImagePath(image, imagePath string) string {
 	subs := strings.SplitAfterN(image, "/", 2)
 	if len(subs) == 2 {
@@ -16,14 +16,26 @@ package apiserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +50,7 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/common/validation"
 	apiserver "github.com/tigera/operator/pkg/common/validation/apiserver"
+	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	"github.com/tigera/operator/pkg/controller/options"
@@ -50,12 +63,20 @@ import (
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 )
 
 const ResourceName string = "apiserver"
 
+const (
+	// clusterClientCAConfigMapName is the ConfigMap in kube-system through which the Kubernetes API server publishes
+	// the CA that signs client certificates, under the clusterClientCAKey key.
+	clusterClientCAConfigMapName = "extension-apiserver-authentication"
+	clusterClientCAKey           = "client-ca-file"
+)
+
 var log = logf.Log.WithName("controller_apiserver")
 
 // Add creates a new APIServer Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -68,6 +89,12 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("failed to create apiserver-controller: %w", err)
 	}
 
+	if opts.ResyncPeriod > 0 {
+		if err = utils.AddPeriodicReconcile(c, opts.ResyncPeriod, &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("apiserver-controller failed to create periodic reconcile watch: %w", err)
+		}
+	}
+
 	// Established deferred watches against the v3 API that should succeed after the Enterprise API Server becomes available.
 	if opts.EnterpriseCRDExists {
 		k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
@@ -90,16 +117,30 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, opts options.AddOptions) *ReconcileAPIServer {
+	// Degraded events are recorded on the default APIServer CR.
+	events := status.WithEventRecorder(mgr.GetEventRecorderFor("apiserver-controller"), &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: utils.DefaultInstanceKey.Name}})
 	r := &ReconcileAPIServer{
-		client:              mgr.GetClient(),
-		scheme:              mgr.GetScheme(),
-		provider:            opts.DetectedProvider,
-		enterpriseCRDsExist: opts.EnterpriseCRDExists,
-		status:              status.New(mgr.GetClient(), "apiserver", opts.KubernetesVersion),
-		clusterDomain:       opts.ClusterDomain,
-		usePSP:              opts.UsePSP,
-		tierWatchReady:      &utils.ReadyFlag{},
-		multiTenant:         opts.MultiTenant,
+		client:                   mgr.GetClient(),
+		scheme:                   mgr.GetScheme(),
+		provider:                 opts.DetectedProvider,
+		enterpriseCRDsExist:      opts.EnterpriseCRDExists,
+		status:                   status.New(mgr.GetClient(), "apiserver", opts.KubernetesVersion, events),
+		clusterDomain:            opts.ClusterDomain,
+		usePSP:                   opts.UsePSP,
+		tierWatchReady:           &utils.ReadyFlag{},
+		multiTenant:              opts.MultiTenant,
+		certificateDuration:      opts.CertificateDuration,
+		certificateRenewalWindow: opts.CertificateRenewalWindow,
+		requeueInterval:          opts.RequeueInterval,
+		dryRun:                   opts.DryRun,
+		serverSideApply:          opts.UseServerSideApply,
+		unmanagedSelector:        opts.UnmanagedResourceSelector,
+	}
+	if opts.AuditSpecChanges {
+		r.specAuditor = utils.NewSpecAuditor(log.WithName("audit"))
+	}
+	if opts.VerifyMultiArchImages {
+		r.manifestInspector = imageset.NewRegistryManifestInspector()
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -168,6 +209,17 @@ func add(c ctrlruntime.Controller, r *ReconcileAPIServer) error {
 		}
 	}
 
+	// The egress selector, tracing and client CA ConfigMaps are referenced by name from the APIServer, so watch all
+	// ConfigMaps in the operator namespace.
+	if err = utils.AddConfigMapWatch(c, "", common.OperatorNamespace(), &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("apiserver-controller failed to watch ConfigMaps: %w", err)
+	}
+
+	// The cluster client CA is merged into the client CA bundle of the API server.
+	if err = utils.AddConfigMapWatch(c, clusterClientCAConfigMapName, metav1.NamespaceSystem, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("apiserver-controller failed to watch ConfigMap %s: %w", clusterClientCAConfigMapName, err)
+	}
+
 	if err = imageset.AddImageSetWatch(c); err != nil {
 		return fmt.Errorf("apiserver-controller failed to watch ImageSet: %w", err)
 	}
@@ -195,6 +247,35 @@ type ReconcileAPIServer struct {
 	usePSP              bool
 	tierWatchReady      *utils.ReadyFlag
 	multiTenant         bool
+
+	// The validity of the key pairs issued by this controller. The default is used when zero.
+	certificateDuration time.Duration
+
+	// How long before expiry the key pairs issued by this controller are renewed. The default is used when zero.
+	certificateRenewalWindow time.Duration
+
+	// manifestInspector, if set, is used to warn about ImageSet images that are not multi-arch.
+	manifestInspector imageset.ManifestInspector
+
+	// multiArchChecked identifies the ImageSet generation and registry settings whose images were last checked by
+	// manifestInspector. The check is not repeated until they change, whether or not it succeeded.
+	multiArchChecked string
+
+	// specAuditor, if set, keeps an audit trail of the spec changes this controller acts on.
+	specAuditor *utils.SpecAuditor
+
+	// How long to wait before reconciling again when resources are not yet available. utils.StandardRetry is
+	// used when zero.
+	requeueInterval time.Duration
+
+	// dryRun, if set, makes the component handler log the writes it would make instead of making them.
+	dryRun bool
+
+	// serverSideApply, if set, makes the component handler write objects using server-side apply.
+	serverSideApply bool
+
+	// unmanagedSelector selects existing objects that the component handler should not update.
+	unmanagedSelector labels.Selector
 }
 
 // Reconcile reads that state of the cluster for a APIServer object and makes changes based on the state read
@@ -224,6 +305,9 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "APIServer is invalid", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if r.specAuditor != nil {
+		r.specAuditor.Record(instance, instance.Spec)
+	}
 
 	// SetMetaData in the TigeraStatus such as observedGenerations.
 	defer r.status.SetMetaData(&instance.ObjectMeta)
@@ -258,7 +342,12 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 	ns := rmeta.APIServerNamespace(variant)
 
-	certificateManager, err := certificatemanager.Create(r.client, installationSpec, r.clusterDomain, common.OperatorNamespace())
+	if err := validateAPIServerPodDisruptionBudget(instance, installationSpec); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "APIServer is invalid", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	certificateManager, err := certificatemanager.Create(r.client, installationSpec, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(r.certificateDuration), certificatemanager.WithCertificateRenewalWindow(r.certificateRenewalWindow), certificatemanager.WithExactDNSNames())
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -266,7 +355,8 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 
 	// We need separate certificates for OSS vs Enterprise.
 	secretName := render.ProjectCalicoAPIServerTLSSecretName(installationSpec.Variant)
-	tlsSecret, err := certificateManager.GetOrCreateKeyPair(r.client, secretName, common.OperatorNamespace(), dns.GetServiceDNSNames(render.ProjectCalicoAPIServerServiceName(installationSpec.Variant), rmeta.APIServerNamespace(installationSpec.Variant), r.clusterDomain))
+	dnsNames := append(dns.GetServiceDNSNames(render.ProjectCalicoAPIServerServiceName(installationSpec.Variant), rmeta.APIServerNamespace(installationSpec.Variant), r.clusterDomain), instance.Spec.AdditionalDNSNames...)
+	tlsSecret, err := certificateManager.GetOrCreateKeyPair(r.client, secretName, common.OperatorNamespace(), dnsNames)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to get or create tls key pair", err, reqLogger)
 		return reconcile.Result{}, err
@@ -280,6 +370,70 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 		return reconcile.Result{}, err
 	}
 
+	var egressSelectorConfig *corev1.ConfigMap
+	if ref := instance.Spec.EgressSelectorConfigMap; ref != nil {
+		egressSelectorConfig = &corev1.ConfigMap{}
+		if err = r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: common.OperatorNamespace()}, egressSelectorConfig); err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Egress selector ConfigMap %s not found", ref.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying egress selector ConfigMap", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if _, ok := egressSelectorConfig.Data[ref.Key]; !ok {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Egress selector ConfigMap %s has no key %s", ref.Name, ref.Key), nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	var tracingConfig *corev1.ConfigMap
+	if ref := instance.Spec.TracingConfigMap; ref != nil {
+		tracingConfig = &corev1.ConfigMap{}
+		if err = r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: common.OperatorNamespace()}, tracingConfig); err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Tracing ConfigMap %s not found", ref.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying tracing ConfigMap", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if _, ok := tracingConfig.Data[ref.Key]; !ok {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Tracing ConfigMap %s has no key %s", ref.Name, ref.Key), nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	var clientCABundle string
+	if ref := instance.Spec.ClientCAConfigMap; ref != nil {
+		clientCAConfig := &corev1.ConfigMap{}
+		if err = r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: common.OperatorNamespace()}, clientCAConfig); err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Client CA ConfigMap %s not found", ref.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying client CA ConfigMap", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		clientCAs, ok := clientCAConfig.Data[ref.Key]
+		if !ok {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Client CA ConfigMap %s has no key %s", ref.Name, ref.Key), nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		if err = validateClientCABundle(clientCAs); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Client CA ConfigMap %s key %s is not valid", ref.Name, ref.Key), err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+
+		// Passing --client-ca-file stops the API server from reading the cluster client CA, so it is merged in here.
+		clusterClientCA, err := r.clusterClientCA(ctx)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying the cluster client CA", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		clientCABundle = mergeClientCABundles(clusterClientCA, clientCAs)
+	}
+
 	// Query enterprise-only data.
 	var tunnelCAKeyPair certificatemanagement.KeyPairInterface
 	var trustedBundle certificatemanagement.TrustedBundle
@@ -287,6 +441,15 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	var managementClusterConnection *operatorv1.ManagementClusterConnection
 	includeV3NetworkPolicy := false
 	if variant == operatorv1.TigeraSecureEnterprise {
+		if err = utils.ValidateClusterRole(ctx, r.client); err != nil {
+			if utils.IsClusterRoleConflictError(err) {
+				r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
+			} else {
+				r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the cluster role", err, reqLogger)
+			}
+			return reconcile.Result{}, err
+		}
+
 		managementCluster, err = utils.GetManagementCluster(ctx, r.client)
 		if err != nil {
 			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementCluster", err, reqLogger)
@@ -299,12 +462,6 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 			return reconcile.Result{}, err
 		}
 
-		if managementClusterConnection != nil && managementCluster != nil {
-			err = fmt.Errorf("having both a ManagementCluster and a ManagementClusterConnection is not supported")
-			r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-
 		// This block depends on the Manager controller having defaulted the ManagementCluster CR and having created the tunnel CA secret.
 		// If these conditions are not met, this controller does not degrade as the Manager controller needs API server to be ready to accomplish the above.
 		if managementCluster != nil && managementCluster.Spec.TLS != nil && !r.multiTenant {
@@ -364,7 +521,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	// Create a component handler to manage the rendered component.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance, utils.WithAdditionalMetadata(installationSpec.AdditionalLabels, installationSpec.AdditionalAnnotations), utils.WithDryRun(r.dryRun), utils.WithServerSideApply(r.serverSideApply), utils.WithUnmanagedSelector(r.unmanagedSelector))
 
 	// Render the desired objects from the CRD and create or update them.
 	reqLogger.V(3).Info("rendering components")
@@ -382,7 +539,15 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 		TrustedBundle:               trustedBundle,
 		UsePSP:                      r.usePSP,
 		MultiTenant:                 r.multiTenant,
+		EgressSelectorConfig:        egressSelectorConfig,
+		TracingConfig:               tracingConfig,
+		ClientCABundle:              clientCABundle,
+	}
+	if tlsCfg := instance.Spec.TLS; tlsCfg != nil {
+		apiServerCfg.TLSMinVersion = tlsCfg.MinVersion
+		apiServerCfg.TLSCipherSuites = tlsCfg.CipherSuites
 	}
+	apiServerCfg.GoAwayChance = apiServerGoAwayChance(instance, installationSpec)
 
 	component, err := render.APIServer(&apiServerCfg)
 	if err != nil {
@@ -403,7 +568,16 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	var pcPolicy render.Component
-	if variant == operatorv1.TigeraSecureEnterprise && (!r.multiTenant || managementCluster == nil) {
+	packetCaptureSupported := variant == operatorv1.TigeraSecureEnterprise && (!r.multiTenant || managementCluster == nil)
+	if packetCaptureSupported && !packetCaptureEnabled(instance) {
+		// The PacketCapture API is disabled, remove anything that was rendered while it was enabled.
+		toDelete, err := r.packetCaptureObjectsToDelete(ctx)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying packet capture TLS certificate", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		components = append(components, render.NewDeletionPassthrough(toDelete...))
+	} else if packetCaptureSupported {
 		packetCaptureCertSecret, err := certificateManager.GetOrCreateKeyPair(
 			r.client,
 			render.PacketCaptureServerCert,
@@ -476,10 +650,14 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ImageSetError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	if r.manifestInspector != nil {
+		r.warnSingleArchImages(ctx, variant, installationSpec, reqLogger)
+	}
+
 	for _, component := range components {
 		if err := handler.CreateOrUpdateOrDelete(context.Background(), component, r.status); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
@@ -492,7 +670,7 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 
 	if !r.status.IsAvailable() {
 		// Schedule a kick to check again in the near future. Hopefully by then things will be available.
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
 	}
 
 	// Everything is available - update the CRD status.
@@ -503,6 +681,152 @@ func (r *ReconcileAPIServer) Reconcile(ctx context.Context, request reconcile.Re
 	return reconcile.Result{}, nil
 }
 
+// packetCaptureEnabled returns whether the PacketCapture API should be rendered for the given APIServer.
+func packetCaptureEnabled(instance *operatorv1.APIServer) bool {
+	return instance.Spec.PacketCapture == nil || *instance.Spec.PacketCapture == operatorv1.PacketCaptureEnabled
+}
+
+// packetCaptureObjectsToDelete returns the objects rendered for the PacketCapture API. The TLS secret in the operator
+// namespace is only included when the operator created it; a user supplied certificate is left in place.
+func (r *ReconcileAPIServer) packetCaptureObjectsToDelete(ctx context.Context) ([]client.Object, error) {
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: render.PacketCaptureNamespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: render.PacketCaptureDeploymentName, Namespace: render.PacketCaptureNamespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: render.PacketCaptureServerCert, Namespace: render.PacketCaptureNamespace}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: render.PacketCaptureClusterRoleName}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: render.PacketCaptureClusterRoleBindingName}},
+	}
+
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: render.PacketCaptureServerCert, Namespace: common.OperatorNamespace()}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil && len(secret.GetOwnerReferences()) > 0 {
+		objs = append(objs, secret)
+	}
+	return objs, nil
+}
+
+// clusterClientCA returns the PEM encoded CA the Kubernetes API server uses to verify client certificates, or an empty
+// string if the cluster does not publish one.
+func (r *ReconcileAPIServer) clusterClientCA(ctx context.Context) (string, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: clusterClientCAConfigMapName, Namespace: metav1.NamespaceSystem}, cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return cm.Data[clusterClientCAKey], nil
+}
+
+// validateClientCABundle verifies that the bundle holds only PEM encoded CA certificates, and at least one of them.
+func validateClientCABundle(bundle string) error {
+	rest := []byte(bundle)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		count++
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("PEM block %d has type %q, expected CERTIFICATE", count, block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("PEM block %d is not a valid certificate: %w", count, err)
+		}
+		if !cert.IsCA {
+			return fmt.Errorf("certificate %q is not a CA", cert.Subject.CommonName)
+		}
+	}
+	if len(strings.TrimSpace(string(rest))) != 0 {
+		return fmt.Errorf("bundle contains data that is not PEM encoded")
+	}
+	if count == 0 {
+		return fmt.Errorf("bundle contains no certificates")
+	}
+	return nil
+}
+
+// mergeClientCABundles concatenates the given PEM bundles, skipping empty ones.
+func mergeClientCABundles(bundles ...string) string {
+	var merged []string
+	for _, b := range bundles {
+		if b = strings.TrimSpace(b); b != "" {
+			merged = append(merged, b)
+		}
+	}
+	return strings.Join(merged, "\n") + "\n"
+}
+
+// apiServerImages are the images of the components rendered by this controller.
+var apiServerImages = []string{
+	components.ComponentAPIServer.Image,
+	components.ComponentQueryServer.Image,
+	components.ComponentPacketCapture.Image,
+	components.ComponentCalicoAPIServer.Image,
+	components.ComponentCalicoAPIServerFIPS.Image,
+}
+
+// warnSingleArchImages logs a warning for each image of this controller's components in the ImageSet in use that is
+// not a multi-arch manifest, since pods using it cannot run on nodes of other architectures. Failures to inspect the
+// images are logged rather than returned, so that an unreachable registry does not block the reconcile. The images
+// are checked once for each generation of the ImageSet and registry settings of the Installation.
+func (r *ReconcileAPIServer) warnSingleArchImages(ctx context.Context, variant operatorv1.ProductVariant, installation *operatorv1.InstallationSpec, reqLogger logr.Logger) {
+	is, err := imageset.GetImageSet(ctx, r.client, variant)
+	if err != nil || is == nil {
+		return
+	}
+	checked := fmt.Sprintf("%s/%d %s %s %s %v", is.Name, is.Generation, installation.Registry, installation.ImagePath, installation.ImagePrefix, installation.RegistryOverrides)
+	if checked == r.multiArchChecked {
+		return
+	}
+	r.multiArchChecked = checked
+
+	singleArch, err := imageset.SingleArchImages(ctx, r.manifestInspector, is, installation, apiServerImages...)
+	if err != nil {
+		reqLogger.Error(err, "Failed to verify that the ImageSet images are multi-arch", "ImageSet", is.Name)
+		return
+	}
+	for _, ref := range singleArch {
+		reqLogger.Info("ImageSet image is not a multi-arch manifest and may not run on nodes of all architectures", "ImageSet", is.Name, "image", ref)
+	}
+}
+
+// tlsVersions are the values accepted by the API server --tls-min-version flag.
+var tlsVersions = map[string]bool{"VersionTLS10": true, "VersionTLS11": true, "VersionTLS12": true, "VersionTLS13": true}
+
+// validateAPIServerTLS verifies that the minimum TLS version and cipher suites are ones the API server accepts.
+func validateAPIServerTLS(cfg *operatorv1.APIServerTLS) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MinVersion != "" && !tlsVersions[cfg.MinVersion] {
+		return fmt.Errorf("MinVersion %q is not one of VersionTLS10, VersionTLS11, VersionTLS12 or VersionTLS13", cfg.MinVersion)
+	}
+	if len(cfg.CipherSuites) > 0 && cfg.MinVersion == "VersionTLS13" {
+		return fmt.Errorf("CipherSuites cannot be configured with MinVersion VersionTLS13, since TLS 1.3 cipher suites are not configurable")
+	}
+
+	supported := map[string]bool{}
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		supported[suite.Name] = true
+	}
+	for _, name := range cfg.CipherSuites {
+		if !supported[name] {
+			return fmt.Errorf("CipherSuites contains the unknown cipher suite %q", name)
+		}
+	}
+	return nil
+}
+
+// healthCheckNameRegexp matches API server health check names, such as "etcd" or "poststarthook/start-informers".
+var healthCheckNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(/[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
 func validateAPIServerResource(instance *operatorv1.APIServer) error {
 	// Verify the APIServerDeployment overrides, if specified, is valid.
 	if d := instance.Spec.APIServerDeployment; d != nil {
@@ -511,6 +835,127 @@ func validateAPIServerResource(instance *operatorv1.APIServer) error {
 			return fmt.Errorf("APIServer spec.APIServerDeployment is not valid: %w", err)
 		}
 	}
+
+	if i := instance.Spec.WatchProgressNotifyInterval; i != nil && i.Duration <= 0 {
+		return fmt.Errorf("APIServer spec.WatchProgressNotifyInterval must be a positive duration, got %s", i.Duration)
+	}
+	if d := instance.Spec.ShutdownDelayDuration; d != nil && d.Duration < 0 {
+		return fmt.Errorf("APIServer spec.ShutdownDelayDuration must not be negative, got %s", d.Duration)
+	}
+	if t := instance.Spec.MinRequestTimeout; t != nil && (t.Duration <= 0 || t.Duration%time.Second != 0) {
+		return fmt.Errorf("APIServer spec.MinRequestTimeout must be a positive whole number of seconds, got %s", t.Duration)
+	}
+	if ttl := instance.Spec.EventTTL; ttl != nil && ttl.Duration <= 0 {
+		return fmt.Errorf("APIServer spec.EventTTL must be a positive duration, got %s", ttl.Duration)
+	}
+	if n := instance.Spec.HTTP2MaxStreamsPerConnection; n != nil && *n <= 0 {
+		return fmt.Errorf("APIServer spec.HTTP2MaxStreamsPerConnection must be positive, got %d", *n)
+	}
+	if n := instance.Spec.RevisionHistoryLimit; n != nil && *n < 0 {
+		return fmt.Errorf("APIServer spec.RevisionHistoryLimit must not be negative, got %d", *n)
+	}
+	if chance := instance.Spec.GoAwayChance; chance != "" {
+		if f, err := strconv.ParseFloat(chance, 64); err != nil || !(f >= 0 && f <= maxAPIServerGoAwayChance) {
+			return fmt.Errorf("APIServer spec.GoAwayChance must be a number between 0 and %g, got %q", maxAPIServerGoAwayChance, chance)
+		}
+	}
+	if rotation := instance.Spec.AuditLogRotation; rotation != nil {
+		if n := rotation.MaxAge; n != nil && *n <= 0 {
+			return fmt.Errorf("APIServer spec.AuditLogRotation.MaxAge must be positive, got %d", *n)
+		}
+		if n := rotation.MaxBackup; n != nil && *n <= 0 {
+			return fmt.Errorf("APIServer spec.AuditLogRotation.MaxBackup must be positive, got %d", *n)
+		}
+		if n := rotation.MaxSize; n != nil && *n <= 0 {
+			return fmt.Errorf("APIServer spec.AuditLogRotation.MaxSize must be positive, got %d", *n)
+		}
+	}
+	if ref := instance.Spec.EgressSelectorConfigMap; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf("APIServer spec.EgressSelectorConfigMap must specify both a name and a key")
+	}
+	if ref := instance.Spec.TracingConfigMap; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf("APIServer spec.TracingConfigMap must specify both a name and a key")
+	}
+	if ref := instance.Spec.ClientCAConfigMap; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf("APIServer spec.ClientCAConfigMap must specify both a name and a key")
+	}
+	for _, name := range instance.Spec.AdditionalDNSNames {
+		if errs := k8svalidation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*.")); len(errs) > 0 {
+			return fmt.Errorf("APIServer spec.AdditionalDNSNames contains the invalid DNS name %q: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	if err := validateAPIServerTLS(instance.Spec.TLS); err != nil {
+		return fmt.Errorf("APIServer spec.TLS is not valid: %w", err)
+	}
+	for _, origin := range instance.Spec.CORSAllowedOrigins {
+		if origin == "" {
+			return fmt.Errorf("APIServer spec.CORSAllowedOrigins must not contain an empty pattern")
+		}
+		if strings.Contains(origin, ",") {
+			return fmt.Errorf("APIServer spec.CORSAllowedOrigins pattern %q must not contain a comma", origin)
+		}
+		if _, err := regexp.Compile(origin); err != nil {
+			return fmt.Errorf("APIServer spec.CORSAllowedOrigins contains an invalid regular expression %q: %w", origin, err)
+		}
+	}
+	audiences := map[string]bool{}
+	for _, audience := range instance.Spec.APIAudiences {
+		if audience == "" {
+			return fmt.Errorf("APIServer spec.APIAudiences must not contain an empty audience")
+		}
+		if strings.ContainsAny(audience, ", \t\n") {
+			return fmt.Errorf("APIServer spec.APIAudiences audience %q must not contain commas or whitespace", audience)
+		}
+		if audiences[audience] {
+			return fmt.Errorf("APIServer spec.APIAudiences contains the audience %q more than once", audience)
+		}
+		audiences[audience] = true
+	}
+	for _, check := range instance.Spec.LivezExcludedChecks {
+		if !healthCheckNameRegexp.MatchString(check) {
+			return fmt.Errorf("APIServer spec.LivezExcludedChecks contains an invalid health check name %q", check)
+		}
+	}
+	for _, check := range instance.Spec.ReadyzExcludedChecks {
+		if !healthCheckNameRegexp.MatchString(check) {
+			return fmt.Errorf("APIServer spec.ReadyzExcludedChecks contains an invalid health check name %q", check)
+		}
+	}
+	return nil
+}
+
+const (
+	// defaultAPIServerGoAwayChance is the GOAWAY probability used to rebalance client connections when the API server
+	// runs more than one replica. It is low enough that clients rarely reconnect.
+	defaultAPIServerGoAwayChance = "0.001"
+
+	// maxAPIServerGoAwayChance is the highest GOAWAY probability accepted by the API server.
+	maxAPIServerGoAwayChance = 0.02
+)
+
+// apiServerGoAwayChance returns the GOAWAY probability of the API server: the configured value if any, otherwise a
+// modest default when the API server runs more than one replica, so that long-lived client connections are spread
+// across the replicas. An empty value leaves the API server default in place.
+func apiServerGoAwayChance(instance *operatorv1.APIServer, installation *operatorv1.InstallationSpec) string {
+	if instance.Spec.GoAwayChance != "" {
+		return instance.Spec.GoAwayChance
+	}
+	if installation.ControlPlaneReplicas != nil && *installation.ControlPlaneReplicas > 1 {
+		return defaultAPIServerGoAwayChance
+	}
+	return ""
+}
+
+// validateAPIServerPodDisruptionBudget validates the configured PodDisruptionBudget against the number of API server
+// replicas in the installation.
+func validateAPIServerPodDisruptionBudget(instance *operatorv1.APIServer, installation *operatorv1.InstallationSpec) error {
+	replicas := int32(1)
+	if installation.ControlPlaneReplicas != nil {
+		replicas = *installation.ControlPlaneReplicas
+	}
+	if err := poddisruptionbudget.Validate(instance.Spec.PodDisruptionBudget, replicas); err != nil {
+		return fmt.Errorf("APIServer spec.PodDisruptionBudget is not valid: %w", err)
+	}
 	return nil
 }
 
@@ -17,6 +17,7 @@ package apiserver
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	kerror "k8s.io/apimachinery/pkg/api/errors"
@@ -29,9 +30,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -46,10 +49,12 @@ import (
 	"github.com/tigera/operator/pkg/controller/utils"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/tls"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"github.com/tigera/operator/test"
 )
 
@@ -148,6 +153,7 @@ var _ = Describe("apiserver controller tests", func() {
 		mockStatus.On("ClearDegraded")
 		mockStatus.On("AddCertificateSigningRequests", mock.Anything)
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("SetMetaData", mock.Anything).Return()
 	})
@@ -240,21 +246,73 @@ var _ = Describe("apiserver controller tests", func() {
 			Expect(test.GetResource(cli, &pcSecret)).To(HaveOccurred()) // Since certificate management is enabled.
 			Expect(pcSecret).NotTo(BeNil())
 		})
-		It("should use images from imageset", func() {
-			installation.Spec.CertificateManagement = certificateManagement
+		It("should apply the APIServerDeployment container resource overrides", func() {
 			Expect(cli.Create(ctx, installation)).To(BeNil())
 
-			Expect(cli.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/cnx-apiserver", Digest: "sha256:apiserverhash"},
-						{Image: "tigera/cnx-queryserver", Digest: "sha256:queryserverhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
-						{Image: "tigera/packetcapture", Digest: "sha256:packetcapturehash"},
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			d := appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"},
+			}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			defaultAPIServerResources := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver").Resources
+			defaultQueryServerResources := test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-queryserver").Resources
+
+			apiServerResources := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}
+			queryServerResources := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+			}
+			Expect(apiServerResources).NotTo(Equal(defaultAPIServerResources))
+			Expect(queryServerResources).NotTo(Equal(defaultQueryServerResources))
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).To(Succeed())
+			apiServer.Spec.APIServerDeployment = &operatorv1.APIServerDeployment{
+				Spec: &operatorv1.APIServerDeploymentSpec{
+					Template: &operatorv1.APIServerDeploymentPodTemplateSpec{
+						Spec: &operatorv1.APIServerDeploymentPodSpec{
+							Containers: []operatorv1.APIServerDeploymentContainer{
+								{Name: "calico-apiserver", Resources: &apiServerResources},
+								{Name: "tigera-queryserver", Resources: &queryServerResources},
+							},
+						},
 					},
 				},
-			})).ToNot(HaveOccurred())
+			}
+			Expect(cli.Update(ctx, apiServer)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			Expect(test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver").Resources).To(Equal(apiServerResources))
+			Expect(test.GetContainer(d.Spec.Template.Spec.Containers, "tigera-queryserver").Resources).To(Equal(queryServerResources))
+		})
+
+		It("should use images from imageset", func() {
+			installation.Spec.CertificateManagement = certificateManagement
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			Expect(cli.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/cnx-apiserver", Digest: "sha256:apiserverhash"},
+				operatorv1.Image{Image: "tigera/cnx-queryserver", Digest: "sha256:queryserverhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
+				operatorv1.Image{Image: "tigera/packetcapture", Digest: "sha256:packetcapturehash"},
+			))).ToNot(HaveOccurred())
 
 			r := ReconcileAPIServer{
 				client:              cli,
@@ -327,6 +385,56 @@ var _ = Describe("apiserver controller tests", func() {
 			Expect(pcSecret).NotTo(BeNil())
 		})
 
+		It("should inspect the images of the ImageSet when multi-arch verification is enabled", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			imageSet := test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/cnx-apiserver", Digest: "sha256:apiserverhash"},
+				operatorv1.Image{Image: "tigera/cnx-queryserver", Digest: "sha256:queryserverhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
+				operatorv1.Image{Image: "tigera/packetcapture", Digest: "sha256:packetcapturehash"},
+			)
+			Expect(cli.Create(ctx, imageSet)).ToNot(HaveOccurred())
+
+			inspector := &fakeManifestInspector{multiArch: map[string]bool{
+				"some.registry.org/tigera/cnx-queryserver@sha256:queryserverhash": true,
+			}}
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+				manifestInspector:   inspector,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inspector.inspected).To(ConsistOf(
+				"some.registry.org/tigera/cnx-apiserver@sha256:apiserverhash",
+				"some.registry.org/tigera/cnx-queryserver@sha256:queryserverhash",
+				"some.registry.org/tigera/packetcapture@sha256:packetcapturehash",
+			))
+
+			// The images are not inspected again until the ImageSet or the registry settings change.
+			inspector.inspected = nil
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inspector.inspected).To(BeEmpty())
+
+			// Single-arch images and unreachable registries only produce warnings, and failures are not retried
+			// on every reconcile either.
+			inspector.err = fmt.Errorf("registry unreachable")
+			Expect(cli.Get(ctx, utils.DefaultInstanceKey, installation)).To(BeNil())
+			installation.Spec.Registry = "other.registry.org/"
+			Expect(cli.Update(ctx, installation)).To(BeNil())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inspector.inspected).To(HaveLen(1))
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inspector.inspected).To(HaveLen(1))
+		})
+
 		It("should not add OwnerReference to user-supplied apiserver and packetcapture TLS cert secrets", func() {
 			Expect(cli.Create(ctx, installation)).To(BeNil())
 
@@ -381,6 +489,191 @@ var _ = Describe("apiserver controller tests", func() {
 			Expect(secret.GetOwnerReferences()).To(HaveLen(1))
 		})
 
+		It("should include the additional DNS names in the issued API server certificate", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			setAdditionalDNSNames := func(names ...string) {
+				apiServer := &operatorv1.APIServer{}
+				Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).ShouldNot(HaveOccurred())
+				apiServer.Spec.AdditionalDNSNames = names
+				Expect(cli.Update(ctx, apiServer)).ShouldNot(HaveOccurred())
+			}
+			issuedDNSNames := func() []string {
+				secret := &corev1.Secret{}
+				Expect(cli.Get(ctx, client.ObjectKey{Namespace: common.OperatorNamespace(), Name: "tigera-apiserver-certs"}, secret)).ShouldNot(HaveOccurred())
+				cert, err := certificatemanagement.ParseCertificate(secret.Data[corev1.TLSCertKey])
+				Expect(err).ShouldNot(HaveOccurred())
+				return cert.DNSNames
+			}
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				clusterDomain:       dns.DefaultClusterDomain,
+				tierWatchReady:      ready,
+			}
+
+			setAdditionalDNSNames("apiserver.gateway.example.com")
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issuedDNSNames()).To(ContainElements("tigera-api.tigera-system.svc", "apiserver.gateway.example.com"))
+
+			// Adding a name reissues the certificate with it.
+			setAdditionalDNSNames("apiserver.gateway.example.com", "apiserver.internal.example.com")
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issuedDNSNames()).To(ContainElements("apiserver.gateway.example.com", "apiserver.internal.example.com"))
+
+			// Removing a name reissues the certificate without it.
+			setAdditionalDNSNames("apiserver.internal.example.com")
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(issuedDNSNames()).To(ContainElement("apiserver.internal.example.com"))
+			Expect(issuedDNSNames()).NotTo(ContainElement("apiserver.gateway.example.com"))
+		})
+
+		It("should not modify a user-supplied API server certificate when additional DNS names are configured", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(cli.Create(ctx, apiSecret)).ShouldNot(HaveOccurred())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).ShouldNot(HaveOccurred())
+			apiServer.Spec.AdditionalDNSNames = []string{"apiserver.gateway.example.com"}
+			Expect(cli.Update(ctx, apiServer)).ShouldNot(HaveOccurred())
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				clusterDomain:       dns.DefaultClusterDomain,
+				tierWatchReady:      ready,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: common.OperatorNamespace(), Name: "tigera-apiserver-certs"}, secret)).ShouldNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(apiSecret.Data))
+		})
+
+		It("should roll the API server Deployment when its TLS secret is rotated", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(cli.Create(ctx, apiSecret)).ShouldNot(HaveOccurred())
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				clusterDomain:       dns.DefaultClusterDomain,
+				tierWatchReady:      ready,
+			}
+			certHash := func() string {
+				d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+				Expect(test.GetResource(cli, &d)).To(BeNil())
+				Expect(d.Spec.Template.Annotations).To(HaveKey("tigera-operator.hash.operator.tigera.io/tigera-apiserver-certs"))
+				return d.Spec.Template.Annotations["tigera-operator.hash.operator.tigera.io/tigera-apiserver-certs"]
+			}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			initial := certHash()
+
+			// Reconciling again without a rotation leaves the pod template unchanged.
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(certHash()).To(Equal(initial))
+
+			cryptoCA, err := tls.MakeCA("byo-ca")
+			Expect(err).NotTo(HaveOccurred())
+			rotated, err := secret.CreateTLSSecret(cryptoCA, "tigera-apiserver-certs", common.OperatorNamespace(), "key.key", "cert.crt", time.Hour, nil, dns.GetServiceDNSNames(render.ProjectCalicoAPIServerServiceName(operatorv1.TigeraSecureEnterprise), "tigera-system", dns.DefaultClusterDomain)...)
+			Expect(err).NotTo(HaveOccurred())
+			current := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKeyFromObject(apiSecret), current)).ShouldNot(HaveOccurred())
+			current.Data = rotated.Data
+			Expect(cli.Update(ctx, current)).ShouldNot(HaveOccurred())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(certHash()).NotTo(Equal(initial))
+		})
+
+		It("should remove the PacketCapture API when it is disabled", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			pcDeployment := &appsv1.Deployment{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: render.PacketCaptureNamespace, Name: render.PacketCaptureDeploymentName}, pcDeployment)).ShouldNot(HaveOccurred())
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: common.OperatorNamespace(), Name: render.PacketCaptureServerCert}, secret)).ShouldNot(HaveOccurred())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).ShouldNot(HaveOccurred())
+			disabled := operatorv1.PacketCaptureDisabled
+			apiServer.Spec.PacketCapture = &disabled
+			Expect(cli.Update(ctx, apiServer)).ShouldNot(HaveOccurred())
+
+			mockStatus.Calls = nil
+			mockStatus.On("RemoveDeployments", mock.Anything).Return()
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(kerror.IsNotFound(cli.Get(ctx, client.ObjectKey{Namespace: render.PacketCaptureNamespace, Name: render.PacketCaptureDeploymentName}, pcDeployment))).To(BeTrue())
+			Expect(kerror.IsNotFound(cli.Get(ctx, client.ObjectKey{Namespace: common.OperatorNamespace(), Name: render.PacketCaptureServerCert}, secret))).To(BeTrue())
+			Expect(kerror.IsNotFound(cli.Get(ctx, client.ObjectKey{Namespace: render.PacketCaptureNamespace, Name: render.PacketCaptureServerCert}, secret))).To(BeTrue())
+			mockStatus.AssertCalled(GinkgoT(), "RemoveDeployments", []types.NamespacedName{{Namespace: render.PacketCaptureNamespace, Name: render.PacketCaptureDeploymentName}})
+			mockStatus.AssertNotCalled(GinkgoT(), "RemoveCertificateSigningRequests", render.PacketCaptureNamespace)
+			mockStatus.AssertNotCalled(GinkgoT(), "RemoveCertificateRenewals", render.PacketCaptureNamespace)
+		})
+
+		It("should not remove a user-supplied packetcapture TLS cert secret when the PacketCapture API is disabled", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(cli.Create(ctx, packetCaptureSecret)).ShouldNot(HaveOccurred())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).ShouldNot(HaveOccurred())
+			disabled := operatorv1.PacketCaptureDisabled
+			apiServer.Spec.PacketCapture = &disabled
+			Expect(cli.Update(ctx, apiServer)).ShouldNot(HaveOccurred())
+			mockStatus.On("RemoveDeployments", mock.Anything).Return()
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(cli.Get(ctx, client.ObjectKey{Namespace: common.OperatorNamespace(), Name: render.PacketCaptureServerCert}, secret)).ShouldNot(HaveOccurred())
+			Expect(secret.GetOwnerReferences()).To(HaveLen(0))
+
+			policies := v3.NetworkPolicyList{}
+			Expect(cli.List(ctx, &policies)).ToNot(HaveOccurred())
+			Expect(policies.Items).To(HaveLen(1))
+			Expect(policies.Items[0].Name).To(Equal("allow-tigera.cnx-apiserver-access"))
+		})
+
 		It("should render allow-tigera policy when tier and tier watch are ready", func() {
 			Expect(cli.Create(ctx, installation)).To(BeNil())
 
@@ -556,7 +849,7 @@ var _ = Describe("apiserver controller tests", func() {
 						{
 							Type:               operatorv1.ComponentDegraded,
 							Status:             operatorv1.ConditionTrue,
-							Reason:             string(operatorv1.ResourceUpdateError),
+							Reason:             string(operatorv1.ImageSetError),
 							Message:            "Error resolving ImageSet for components",
 							ObservedGeneration: generation,
 						},
@@ -595,7 +888,7 @@ var _ = Describe("apiserver controller tests", func() {
 
 			Expect(instance.Status.Conditions[2].Type).To(Equal("Degraded"))
 			Expect(string(instance.Status.Conditions[2].Status)).To(Equal(string(operatorv1.ConditionTrue)))
-			Expect(instance.Status.Conditions[2].Reason).To(Equal(string(operatorv1.ResourceUpdateError)))
+			Expect(instance.Status.Conditions[2].Reason).To(Equal(string(operatorv1.ImageSetError)))
 			Expect(instance.Status.Conditions[2].Message).To(Equal("Error resolving ImageSet for components"))
 			Expect(instance.Status.Conditions[2].ObservedGeneration).To(Equal(generation))
 		})
@@ -908,4 +1201,591 @@ var _ = Describe("apiserver controller tests", func() {
 			})
 		})
 	})
+
+	Context("egress selector configuration", func() {
+		var r ReconcileAPIServer
+
+		BeforeEach(func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).NotTo(HaveOccurred())
+			apiServer.Spec.EgressSelectorConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "egress-selector"},
+				Key:                  "config.yaml",
+			}
+			Expect(cli.Update(ctx, apiServer)).NotTo(HaveOccurred())
+
+			r = ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+		})
+
+		It("should degrade when the egress selector ConfigMap does not exist", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Egress selector ConfigMap egress-selector not found", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Egress selector ConfigMap egress-selector not found", mock.Anything, mock.Anything)
+		})
+
+		It("should degrade when the egress selector ConfigMap does not have the referenced key", func() {
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-selector", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"other.yaml": "{}"},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Egress selector ConfigMap egress-selector has no key config.yaml", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Egress selector ConfigMap egress-selector has no key config.yaml", mock.Anything, mock.Anything)
+		})
+
+		It("should configure the API server with the egress selector ConfigMap", func() {
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "egress-selector", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"config.yaml": "kind: EgressSelectorConfiguration"},
+			})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "egress-selector", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &cm)).To(BeNil())
+			Expect(cm.Data).To(Equal(map[string]string{"config.yaml": "kind: EgressSelectorConfiguration"}))
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			apiserver := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver")
+			Expect(apiserver).NotTo(BeNil())
+			Expect(apiserver.Args).To(ContainElement("--egress-selector-config-file=/etc/tigera/egress-selector/egress-selector-config.yaml"))
+		})
+	})
+
+	Context("additional metadata", func() {
+		It("should add the additional labels and annotations of the Installation to the API server Deployment", func() {
+			installation.Spec.AdditionalLabels = map[string]string{"gitops.example.com/owner": "platform", "k8s-app": "gitops"}
+			installation.Spec.AdditionalAnnotations = map[string]string{"gitops.example.com/source": "cluster-config"}
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			r := ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			Expect(d.Labels).To(HaveKeyWithValue("gitops.example.com/owner", "platform"))
+			Expect(d.Labels).To(HaveKeyWithValue("k8s-app", "tigera-apiserver"))
+			Expect(d.Annotations).To(HaveKeyWithValue("gitops.example.com/source", "cluster-config"))
+		})
+	})
+
+	Context("tracing configuration", func() {
+		var r ReconcileAPIServer
+
+		BeforeEach(func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).NotTo(HaveOccurred())
+			apiServer.Spec.TracingConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-tracing"},
+				Key:                  "tracing.yaml",
+			}
+			Expect(cli.Update(ctx, apiServer)).NotTo(HaveOccurred())
+
+			r = ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+		})
+
+		It("should degrade when the tracing ConfigMap does not exist", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Tracing ConfigMap apiserver-tracing not found", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Tracing ConfigMap apiserver-tracing not found", mock.Anything, mock.Anything)
+		})
+
+		It("should configure the API server with the tracing ConfigMap", func() {
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "apiserver-tracing", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"tracing.yaml": "kind: TracingConfiguration"},
+			})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "apiserver-tracing", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &cm)).To(BeNil())
+			Expect(cm.Data).To(Equal(map[string]string{"tracing.yaml": "kind: TracingConfiguration"}))
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			apiserver := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver")
+			Expect(apiserver).NotTo(BeNil())
+			Expect(apiserver.Args).To(ContainElement("--tracing-config-file=/etc/tigera/tracing/tracing-config.yaml"))
+		})
+	})
+
+	Context("client CA configuration", func() {
+		var r ReconcileAPIServer
+		var clientCA, clusterCA []byte
+
+		BeforeEach(func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+
+			ca, err := tls.MakeCA("client-ca")
+			Expect(err).NotTo(HaveOccurred())
+			clientCA, _, err = ca.Config.GetPEMBytes()
+			Expect(err).NotTo(HaveOccurred())
+			ca, err = tls.MakeCA("cluster-ca")
+			Expect(err).NotTo(HaveOccurred())
+			clusterCA, _, err = ca.Config.GetPEMBytes()
+			Expect(err).NotTo(HaveOccurred())
+
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).NotTo(HaveOccurred())
+			apiServer.Spec.ClientCAConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-client-ca"},
+				Key:                  "ca.crt",
+			}
+			Expect(cli.Update(ctx, apiServer)).NotTo(HaveOccurred())
+
+			r = ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+		})
+
+		It("should degrade when the client CA ConfigMap does not exist", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Client CA ConfigMap apiserver-client-ca not found", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Client CA ConfigMap apiserver-client-ca not found", mock.Anything, mock.Anything)
+		})
+
+		It("should degrade when the client CA ConfigMap does not hold valid PEM", func() {
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "apiserver-client-ca", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"ca.crt": "not a certificate"},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Client CA ConfigMap apiserver-client-ca key ca.crt is not valid", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Client CA ConfigMap apiserver-client-ca key ca.crt is not valid", mock.Anything, mock.Anything)
+		})
+
+		It("should configure the API server with the client CA merged with the cluster client CA", func() {
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "apiserver-client-ca", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"ca.crt": string(clientCA)},
+			})).NotTo(HaveOccurred())
+			Expect(cli.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "extension-apiserver-authentication", Namespace: "kube-system"},
+				Data:       map[string]string{"client-ca-file": string(clusterCA)},
+			})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "calico-apiserver-client-ca", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &cm)).To(BeNil())
+			Expect(cm.Data["client-ca.crt"]).To(Equal(strings.TrimSpace(string(clusterCA)) + "\n" + strings.TrimSpace(string(clientCA)) + "\n"))
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			apiserver := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver")
+			Expect(apiserver).NotTo(BeNil())
+			Expect(apiserver.Args).To(ContainElement("--client-ca-file=/etc/tigera/client-ca/client-ca.crt"))
+		})
+	})
+
+	Context("TLS configuration", func() {
+		var r ReconcileAPIServer
+
+		BeforeEach(func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			r = ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+		})
+
+		setTLS := func(tlsCfg *operatorv1.APIServerTLS) {
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).NotTo(HaveOccurred())
+			apiServer.Spec.TLS = tlsCfg
+			Expect(cli.Update(ctx, apiServer)).NotTo(HaveOccurred())
+		}
+
+		It("should degrade on an invalid TLS minimum version", func() {
+			setTLS(&operatorv1.APIServerTLS{MinVersion: "TLS1.0"})
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "APIServer is invalid", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).Should(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "APIServer is invalid", mock.Anything, mock.Anything)
+		})
+
+		It("should configure the API server with the TLS minimum version and cipher suites", func() {
+			setTLS(&operatorv1.APIServerTLS{
+				MinVersion:   "VersionTLS12",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			})
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			apiserver := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver")
+			Expect(apiserver).NotTo(BeNil())
+			Expect(apiserver.Args).To(ContainElements("--tls-min-version=VersionTLS12", "--tls-cipher-suites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"))
+		})
+	})
+
+	Context("GOAWAY chance", func() {
+		var r ReconcileAPIServer
+
+		BeforeEach(func() {
+			r = ReconcileAPIServer{
+				client:              cli,
+				scheme:              scheme,
+				provider:            operatorv1.ProviderNone,
+				enterpriseCRDsExist: true,
+				status:              mockStatus,
+				tierWatchReady:      ready,
+			}
+		})
+
+		apiServerArgs := func() []string {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			d := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "tigera-apiserver", Namespace: "tigera-system"}}
+			Expect(test.GetResource(cli, &d)).To(BeNil())
+			apiserver := test.GetContainer(d.Spec.Template.Spec.Containers, "calico-apiserver")
+			Expect(apiserver).NotTo(BeNil())
+			return apiserver.Args
+		}
+
+		setGoAwayChance := func(chance string) {
+			apiServer := &operatorv1.APIServer{}
+			Expect(cli.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, apiServer)).NotTo(HaveOccurred())
+			apiServer.Spec.GoAwayChance = chance
+			Expect(cli.Update(ctx, apiServer)).NotTo(HaveOccurred())
+		}
+
+		It("should default the GOAWAY chance when the API server runs multiple replicas", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(apiServerArgs()).To(ContainElement("--goaway-chance=0.001"))
+		})
+
+		It("should not set a GOAWAY chance when the API server runs a single replica", func() {
+			installation.Spec.ControlPlaneReplicas = ptr.Int32ToPtr(1)
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			Expect(apiServerArgs()).NotTo(ContainElement(HavePrefix("--goaway-chance")))
+		})
+
+		It("should use the configured GOAWAY chance regardless of the replicas", func() {
+			Expect(cli.Create(ctx, installation)).To(BeNil())
+			setGoAwayChance("0.005")
+			Expect(apiServerArgs()).To(ContainElement("--goaway-chance=0.005"))
+
+			By("disabling GOAWAY on a multi-replica API server")
+			setGoAwayChance("0")
+			Expect(apiServerArgs()).To(ContainElement("--goaway-chance=0"))
+		})
+	})
+
+	Context("client CA bundle validation", func() {
+		It("should accept a bundle of CA certificates", func() {
+			ca1, err := tls.MakeCA("ca-1")
+			Expect(err).NotTo(HaveOccurred())
+			pem1, _, err := ca1.Config.GetPEMBytes()
+			Expect(err).NotTo(HaveOccurred())
+			ca2, err := tls.MakeCA("ca-2")
+			Expect(err).NotTo(HaveOccurred())
+			pem2, _, err := ca2.Config.GetPEMBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validateClientCABundle(string(pem1) + "\n" + string(pem2))).NotTo(HaveOccurred())
+		})
+
+		It("should reject a bundle without certificates", func() {
+			Expect(validateClientCABundle("")).To(HaveOccurred())
+			Expect(validateClientCABundle("not a certificate")).To(HaveOccurred())
+		})
+
+		It("should reject a bundle with trailing data that is not PEM", func() {
+			ca, err := tls.MakeCA("ca")
+			Expect(err).NotTo(HaveOccurred())
+			caPEM, _, err := ca.Config.GetPEMBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validateClientCABundle(string(caPEM) + "garbage")).To(HaveOccurred())
+		})
+
+		It("should reject a certificate that is not a CA", func() {
+			Expect(validateClientCABundle(string(packetCaptureSecret.Data["cert.crt"]))).To(HaveOccurred())
+		})
+	})
+
+	Context("APIServer spec validation", func() {
+		var instance *operatorv1.APIServer
+
+		BeforeEach(func() {
+			instance = &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		})
+
+		It("should accept a default APIServer", func() {
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should accept a positive watch progress notify interval", func() {
+			instance.Spec.WatchProgressNotifyInterval = &metav1.Duration{Duration: 10 * time.Second}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a non-positive watch progress notify interval", func() {
+			instance.Spec.WatchProgressNotifyInterval = &metav1.Duration{Duration: 0}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.WatchProgressNotifyInterval = &metav1.Duration{Duration: -time.Second}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a minimum request timeout of whole seconds", func() {
+			instance.Spec.MinRequestTimeout = &metav1.Duration{Duration: 2 * time.Hour}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a minimum request timeout that is not a positive whole number of seconds", func() {
+			instance.Spec.MinRequestTimeout = &metav1.Duration{Duration: 0}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.MinRequestTimeout = &metav1.Duration{Duration: -time.Minute}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.MinRequestTimeout = &metav1.Duration{Duration: 1500 * time.Millisecond}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a positive event TTL", func() {
+			instance.Spec.EventTTL = &metav1.Duration{Duration: 24 * time.Hour}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a non-positive event TTL", func() {
+			instance.Spec.EventTTL = &metav1.Duration{Duration: 0}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.EventTTL = &metav1.Duration{Duration: -time.Hour}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept valid CORS allowed origin patterns", func() {
+			instance.Spec.CORSAllowedOrigins = []string{`//dashboard\.example\.com$`, `//localhost(:[0-9]+)?$`}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject invalid CORS allowed origin patterns", func() {
+			instance.Spec.CORSAllowedOrigins = []string{`//dashboard(\.example\.com$`}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.CORSAllowedOrigins = []string{`//a\.example\.com$,//b\.example\.com$`}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.CORSAllowedOrigins = []string{""}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept valid API audiences", func() {
+			instance.Spec.APIAudiences = []string{"https://kubernetes.default.svc", "vault"}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject invalid API audiences", func() {
+			instance.Spec.APIAudiences = []string{""}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.APIAudiences = []string{"vault,consul"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.APIAudiences = []string{"my vault"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.APIAudiences = []string{"vault", "vault"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject an HTTP/2 max streams per connection that is not positive", func() {
+			instance.Spec.HTTP2MaxStreamsPerConnection = ptr.Int32ToPtr(1000)
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.HTTP2MaxStreamsPerConnection = ptr.Int32ToPtr(0)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.HTTP2MaxStreamsPerConnection = ptr.Int32ToPtr(-1)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a negative revision history limit", func() {
+			instance.Spec.RevisionHistoryLimit = ptr.Int32ToPtr(0)
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.RevisionHistoryLimit = ptr.Int32ToPtr(-1)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should validate the GOAWAY chance", func() {
+			for _, chance := range []string{"0", "0.001", "0.02"} {
+				instance.Spec.GoAwayChance = chance
+				Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			}
+			for _, chance := range []string{"-0.001", "0.03", "1", "NaN", "often"} {
+				instance.Spec.GoAwayChance = chance
+				Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			}
+		})
+
+		It("should reject audit log rotation values that are not positive", func() {
+			instance.Spec.AuditLogRotation = &operatorv1.APIServerAuditLogRotation{
+				MaxAge:    ptr.Int32ToPtr(30),
+				MaxBackup: ptr.Int32ToPtr(10),
+				MaxSize:   ptr.Int32ToPtr(100),
+			}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.AuditLogRotation.MaxAge = ptr.Int32ToPtr(0)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.AuditLogRotation.MaxAge = nil
+			instance.Spec.AuditLogRotation.MaxBackup = ptr.Int32ToPtr(-1)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.AuditLogRotation.MaxBackup = nil
+			instance.Spec.AuditLogRotation.MaxSize = ptr.Int32ToPtr(0)
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject an incomplete egress selector ConfigMap reference", func() {
+			instance.Spec.EgressSelectorConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "egress-selector"},
+			}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.EgressSelectorConfigMap = &corev1.ConfigMapKeySelector{Key: "config.yaml"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject an incomplete client CA ConfigMap reference", func() {
+			instance.Spec.ClientCAConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-client-ca"},
+			}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.ClientCAConfigMap = &corev1.ConfigMapKeySelector{Key: "ca.crt"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should validate the additional DNS names", func() {
+			instance.Spec.AdditionalDNSNames = []string{"apiserver.example.com", "*.gateway.example.com"}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.AdditionalDNSNames = []string{"not a dns name"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.AdditionalDNSNames = []string{""}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should validate the TLS configuration", func() {
+			instance.Spec.TLS = &operatorv1.APIServerTLS{
+				MinVersion:   "VersionTLS12",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.TLS = &operatorv1.APIServerTLS{MinVersion: "VersionTLS13"}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+
+			instance.Spec.TLS = &operatorv1.APIServerTLS{MinVersion: "TLS1.2"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.TLS = &operatorv1.APIServerTLS{CipherSuites: []string{"TLS_NOT_A_CIPHER"}}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.TLS = &operatorv1.APIServerTLS{
+				MinVersion:   "VersionTLS13",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject an incomplete tracing ConfigMap reference", func() {
+			instance.Spec.TracingConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-tracing"},
+			}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.TracingConfigMap = &corev1.ConfigMapKeySelector{Key: "tracing.yaml"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a valid shutdown delay", func() {
+			instance.Spec.ShutdownDelayDuration = &metav1.Duration{Duration: 0}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a negative shutdown delay", func() {
+			instance.Spec.ShutdownDelayDuration = &metav1.Duration{Duration: -time.Second}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept valid health check exclusions", func() {
+			instance.Spec.LivezExcludedChecks = []string{"etcd", "poststarthook/start-informers"}
+			instance.Spec.ReadyzExcludedChecks = []string{"informer-sync"}
+			Expect(validateAPIServerResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject invalid health check names", func() {
+			instance.Spec.LivezExcludedChecks = []string{"etcd,log"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.LivezExcludedChecks = nil
+			instance.Spec.ReadyzExcludedChecks = []string{"/readyz"}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+			instance.Spec.ReadyzExcludedChecks = []string{""}
+			Expect(validateAPIServerResource(instance)).To(HaveOccurred())
+		})
+
+		It("should validate the PodDisruptionBudget against the control plane replicas", func() {
+			minAvailable := intstr.FromInt(2)
+			instance.Spec.PodDisruptionBudget = &operatorv1.PodDisruptionBudgetConfig{MinAvailable: &minAvailable}
+			Expect(validateAPIServerPodDisruptionBudget(instance, &operatorv1.InstallationSpec{ControlPlaneReplicas: ptr.Int32ToPtr(3)})).NotTo(HaveOccurred())
+			Expect(validateAPIServerPodDisruptionBudget(instance, &operatorv1.InstallationSpec{ControlPlaneReplicas: ptr.Int32ToPtr(2)})).To(HaveOccurred())
+			Expect(validateAPIServerPodDisruptionBudget(instance, &operatorv1.InstallationSpec{})).To(HaveOccurred())
+		})
+
+		It("should reject a PodDisruptionBudget that sets both minAvailable and maxUnavailable", func() {
+			value := intstr.FromInt(1)
+			instance.Spec.PodDisruptionBudget = &operatorv1.PodDisruptionBudgetConfig{MinAvailable: &value, MaxUnavailable: &value}
+			Expect(validateAPIServerPodDisruptionBudget(instance, &operatorv1.InstallationSpec{ControlPlaneReplicas: ptr.Int32ToPtr(3)})).To(HaveOccurred())
+		})
+	})
 })
+
+// fakeManifestInspector reports the configured images as multi-arch, and records the images it inspects.
+type fakeManifestInspector struct {
+	multiArch map[string]bool
+	err       error
+	inspected []string
+}
+
+func (f *fakeManifestInspector) IsMultiArch(ctx context.Context, ref string) (bool, error) {
+	f.inspected = append(f.inspected, ref)
+	return f.multiArch[ref], f.err
+}
@@ -419,15 +419,10 @@ var _ = Describe("authentication controller tests", func() {
 					components.ComponentDex.Version)))
 		})
 		It("should use images from imageset", func() {
-			Expect(cli.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/dex", Digest: "sha256:dexhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(cli.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/dex", Digest: "sha256:dexhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			r := ReconcileAuthentication{
 				client:         cli,
@@ -74,6 +74,20 @@ type certificateManager struct {
 	// create new CAs. Most instances should simply read the existing CA and use it to sign
 	// certificates.
 	allowCACreation bool
+
+	// The validity of the key pairs created by GetOrCreateKeyPair.
+	certificateDuration time.Duration
+
+	// Operator signed key pairs are renewed by GetOrCreateKeyPair once they expire within this window.
+	renewalWindow time.Duration
+
+	// The key pairs renewed by GetOrCreateKeyPair and the earliest time at which one of the key pairs it returned
+	// is due for renewal.
+	renewedKeyPairs []string
+	nextRenewal     time.Time
+
+	// Whether operator signed key pairs with DNS names that were not requested are replaced by GetOrCreateKeyPair.
+	exactDNSNames bool
 }
 
 // CertificateManager can sign new certificates and has methods to retrieve existing KeyPairs and Certificates. If a user
@@ -103,8 +117,12 @@ type CertificateManager interface {
 	// CreateMultiTenantTrustedBundleWithSystemRootCertificates is an alternative to CreateTrustedBundleWithSystemRootCertificates that is appropriate for
 	// multi-tenant management clusters.
 	CreateMultiTenantTrustedBundleWithSystemRootCertificates(certificates ...certificatemanagement.CertificateInterface) (certificatemanagement.TrustedBundle, error)
-	// AddToStatusManager lets the status manager monitor pending CSRs if the certificate management is enabled.
+	// AddToStatusManager lets the status manager monitor pending CSRs if the certificate management is enabled and
+	// reports the key pairs that GetOrCreateKeyPair renewed.
 	AddToStatusManager(manager status.StatusManager, namespace string)
+	// NextRenewal returns the earliest time at which a key pair returned by GetOrCreateKeyPair is due for renewal, or
+	// the zero time if none of them will be renewed.
+	NextRenewal() time.Time
 	// KeyPair Returns the CA KeyPairInterface, so it can be rendered in the operator namespace.
 	KeyPair() certificatemanagement.KeyPairInterface
 	// LoadTrustedBundle loads an existing trusted bundle to pass to render.
@@ -139,6 +157,44 @@ func WithTenant(t *operatorv1.Tenant) Option {
 	}
 }
 
+// WithCertificateDuration sets the validity of the key pairs created by GetOrCreateKeyPair. Existing operator signed
+// key pairs that are valid for longer are replaced. A zero duration selects tls.DefaultCertificateDuration.
+func WithCertificateDuration(d time.Duration) Option {
+	return func(cm *certificateManager) error {
+		if d < 0 {
+			return fmt.Errorf("certificate duration must not be negative, got %s", d)
+		}
+		if d > 0 {
+			cm.certificateDuration = d
+		}
+		return nil
+	}
+}
+
+// WithCertificateRenewalWindow sets how long before expiry operator signed key pairs are renewed by
+// GetOrCreateKeyPair. A zero window selects tls.DefaultCertificateRenewalWindow.
+func WithCertificateRenewalWindow(d time.Duration) Option {
+	return func(cm *certificateManager) error {
+		if d < 0 {
+			return fmt.Errorf("certificate renewal window must not be negative, got %s", d)
+		}
+		if d > 0 {
+			cm.renewalWindow = d
+		}
+		return nil
+	}
+}
+
+// WithExactDNSNames makes GetOrCreateKeyPair replace operator signed key pairs whose certificates have DNS names that
+// were not requested, for example because a configurable name was removed. By default, only key pairs that lack a
+// requested name are replaced. User supplied key pairs are never replaced.
+func WithExactDNSNames() Option {
+	return func(cm *certificateManager) error {
+		cm.exactDNSNames = true
+		return nil
+	}
+}
+
 // Create creates a signer of new certificates and has methods to retrieve existing KeyPairs and Certificates. If a user
 // brings their own secrets, CertificateManager will preserve and return them.
 func Create(cli client.Client, installation *operatorv1.InstallationSpec, clusterDomain, ns string, opts ...Option) (CertificateManager, error) {
@@ -154,12 +210,16 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 
 	// Create a certificatemanager instance and apply any user-provided options to
 	// initialize it.
-	cm := &certificateManager{log: log}
+	cm := &certificateManager{log: log, certificateDuration: tls.DefaultCertificateDuration, renewalWindow: tls.DefaultCertificateRenewalWindow}
 	for _, opt := range opts {
 		if err := opt(cm); err != nil {
 			return nil, err
 		}
 	}
+	if cm.renewalWindow >= cm.certificateDuration {
+		// Otherwise every key pair would be due for renewal as soon as it is issued.
+		cm.renewalWindow = cm.certificateDuration / 2
+	}
 	cm.log.V(2).Info("Creating CertificateManager in namespace", "ns", ns)
 
 	// Determine the name of the CA secret to use. Default to the tigera CA name. For
@@ -177,21 +237,9 @@ func Create(cli client.Client, installation *operatorv1.InstallationSpec, cluste
 		}
 		// We instantiate csrImage regardless of whether certificate management is enabled; it may still be used.
 		if installation.Variant == operatorv1.TigeraSecureEnterprise {
-			csrImage, err = components.GetReference(
-				components.ComponentTigeraCSRInitContainer,
-				installation.Registry,
-				installation.ImagePath,
-				installation.ImagePrefix,
-				imageSet,
-			)
+			csrImage, err = components.GetInstallationReference(components.ComponentTigeraCSRInitContainer, installation, imageSet)
 		} else {
-			csrImage, err = components.GetReference(
-				components.ComponentCalicoCSRInitContainer,
-				installation.Registry,
-				installation.ImagePath,
-				installation.ImagePrefix,
-				imageSet,
-			)
+			csrImage, err = components.GetInstallationReference(components.ComponentCalicoCSRInitContainer, installation, imageSet)
 		}
 		if err != nil {
 			return nil, err
@@ -290,13 +338,33 @@ func (cm *certificateManager) KeyPair() certificatemanagement.KeyPairInterface {
 	return cm.keyPair
 }
 
-// AddToStatusManager lets the status manager monitor pending CSRs if the certificate management is enabled.
+// AddToStatusManager lets the status manager monitor pending CSRs if the certificate management is enabled and
+// reports the key pairs that GetOrCreateKeyPair renewed.
 func (cm *certificateManager) AddToStatusManager(statusManager status.StatusManager, namespace string) {
 	if cm.CertificateManagement() != nil {
 		statusManager.AddCertificateSigningRequests(namespace, map[string]string{"k8s-app": namespace})
 	} else {
 		statusManager.RemoveCertificateSigningRequests(namespace)
 	}
+	if len(cm.renewedKeyPairs) > 0 {
+		statusManager.AddCertificateRenewals(namespace, cm.renewedKeyPairs)
+	} else {
+		statusManager.RemoveCertificateRenewals(namespace)
+	}
+}
+
+// NextRenewal returns the earliest time at which a key pair returned by GetOrCreateKeyPair is due for renewal, or
+// the zero time if none of them will be renewed.
+func (cm *certificateManager) NextRenewal() time.Time {
+	return cm.nextRenewal
+}
+
+// trackRenewal records when a key pair that expires at notAfter is due for renewal.
+func (cm *certificateManager) trackRenewal(notAfter time.Time) {
+	renewal := notAfter.Add(-cm.renewalWindow)
+	if cm.nextRenewal.IsZero() || renewal.Before(cm.nextRenewal) {
+		cm.nextRenewal = renewal
+	}
 }
 
 func (cm *certificateManager) CreateCSRKeyPair(secretName, namespace string, dnsNames []string) certificatemanagement.KeyPairInterface {
@@ -321,9 +389,20 @@ func (cm *certificateManager) GetOrCreateKeyPair(cli client.Client, secretName,
 	}
 	if err != nil && !kerrors.IsNotFound(err) {
 		return nil, err
+	} else if keyPair != nil && !keyPair.BYO() && x509Cert.NotAfter.After(time.Now().Add(cm.certificateDuration)) {
+		// The certificate outlives the configured duration, for example because the duration was reduced.
+		cm.log.Info("KeyPair is valid for longer than the certificate duration, create a new one", "namespace", secretNamespace, "name", secretName, "notAfter", x509Cert.NotAfter)
+	} else if keyPair != nil && !keyPair.BYO() && x509Cert.NotAfter.Before(time.Now().Add(cm.renewalWindow)) {
+		cm.log.Info("KeyPair is about to expire, create a new one", "namespace", secretNamespace, "name", secretName, "notAfter", x509Cert.NotAfter)
+		cm.renewedKeyPairs = append(cm.renewedKeyPairs, fmt.Sprintf("%s/%s", secretNamespace, secretName))
 	} else if keyPair != nil {
 		err = HasExpectedDNSNames(secretName, secretNamespace, x509Cert, dnsNames)
-		if err == nil {
+		if err == nil && cm.exactDNSNames && !keyPair.BYO() && !sets.NewString(x509Cert.DNSNames...).Equal(sets.NewString(dnsNames...)) {
+			cm.log.Info("KeyPair has DNS names that were not requested, create a new one", "namespace", secretNamespace, "name", secretName)
+		} else if err == nil {
+			if !keyPair.BYO() {
+				cm.trackRenewal(x509Cert.NotAfter)
+			}
 			return keyPair, nil
 		} else if keyPair.BYO() {
 			cm.log.V(3).Info("secret %s has invalid DNS names, the expected names are: %v", secretName, dnsNames)
@@ -334,7 +413,7 @@ func (cm *certificateManager) GetOrCreateKeyPair(cli client.Client, secretName,
 	}
 
 	// If we reach here, it means we need to create a new KeyPair.
-	tlsCfg, err := cm.MakeServerCertForDuration(sets.NewString(dnsNames...), tls.DefaultCertificateDuration, tls.SetServerAuth, tls.SetClientAuth)
+	tlsCfg, err := cm.MakeServerCertForDuration(sets.NewString(dnsNames...), cm.certificateDuration, tls.SetServerAuth, tls.SetClientAuth)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create signed cert pair: %s", err)
 	}
@@ -342,6 +421,7 @@ func (cm *certificateManager) GetOrCreateKeyPair(cli client.Client, secretName,
 	if err := tlsCfg.WriteCertConfig(crtContent, keyContent); err != nil {
 		return nil, err
 	}
+	cm.trackRenewal(tlsCfg.Certs[0].NotAfter)
 
 	return &certificatemanagement.KeyPair{
 		Issuer:         cm.keyPair,
@@ -34,6 +34,7 @@ import (
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
+	"github.com/tigera/operator/pkg/controller/status"
 	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
@@ -282,6 +283,166 @@ var _ = Describe("Test CertificateManagement suite", func() {
 			})
 		})
 
+		Describe("test certificate duration", func() {
+			var shortDuration = 90 * 24 * time.Hour
+
+			BeforeEach(func() {
+				Expect(cli.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+			})
+
+			It("should issue key pairs for the configured duration", func() {
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(shortDuration))
+				Expect(err).NotTo(HaveOccurred())
+				kp, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				cert, err := certificatemanagement.ParseCertificate(kp.GetCertificatePEM())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(shortDuration), time.Minute))
+			})
+
+			It("should replace an operator signed key pair when the duration is reduced", func() {
+				keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(shortDuration))
+				Expect(err).NotTo(HaveOccurred())
+				keyPair2, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyPair2.GetCertificatePEM()).NotTo(Equal(keyPair.GetCertificatePEM()))
+				Expect(keyPair2.GetIssuer()).NotTo(BeNil())
+				Expect(cli.Update(ctx, keyPair2.Secret(appNs))).NotTo(HaveOccurred())
+
+				By("keeping the replacement key pair on subsequent calls")
+				keyPair3, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyPair3.HashAnnotationValue()).To(Equal(keyPair2.HashAnnotationValue()))
+			})
+
+			It("should not replace a byo key pair when the duration is reduced", func() {
+				Expect(cli.Create(ctx, byoSecret)).NotTo(HaveOccurred())
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(time.Minute))
+				Expect(err).NotTo(HaveOccurred())
+				kp, err := cm.GetOrCreateKeyPair(cli, byoSecret.Name, byoSecret.Namespace, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.BYO()).To(BeTrue())
+			})
+
+			It("should reject a negative duration", func() {
+				_, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(-time.Hour))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("test certificate renewal", func() {
+			var nearExpirySecret *corev1.Secret
+
+			BeforeEach(func() {
+				Expect(cli.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+				ca, err := crypto.GetCAFromBytes(certificateManager.KeyPair().GetCertificatePEM(), certificateManager.KeyPair().Secret("").Data[corev1.TLSPrivateKeyKey])
+				Expect(err).NotTo(HaveOccurred())
+				nearExpirySecret, err = secret.CreateTLSSecret(ca, appSecretName, appNs, corev1.TLSPrivateKeyKey, corev1.TLSCertKey, 7*24*time.Hour,
+					[]crypto.CertificateExtensionFunc{tls.SetServerAuth, tls.SetClientAuth}, appDNSNames...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, nearExpirySecret)).NotTo(HaveOccurred())
+			})
+
+			It("should issue a new key pair when an operator signed key pair is about to expire", func() {
+				kp, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.GetCertificatePEM()).NotTo(Equal(nearExpirySecret.Data[corev1.TLSCertKey]))
+				cert, err := certificatemanagement.ParseCertificate(kp.GetCertificatePEM())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.NotAfter).To(BeTemporally("~", time.Now().Add(tls.DefaultCertificateDuration), time.Minute))
+				Expect(certificateManager.NextRenewal()).To(BeTemporally("~", cert.NotAfter.Add(-tls.DefaultCertificateRenewalWindow), time.Second))
+
+				mockStatus := &status.MockStatus{}
+				mockStatus.On("RemoveCertificateSigningRequests", appNs)
+				mockStatus.On("AddCertificateRenewals", appNs, []string{appNs + "/" + appSecretName})
+				certificateManager.AddToStatusManager(mockStatus, appNs)
+				mockStatus.AssertExpectations(GinkgoT())
+			})
+
+			It("should keep an operator signed key pair that expires after the renewal window", func() {
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateRenewalWindow(24*time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				kp, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.GetCertificatePEM()).To(Equal(nearExpirySecret.Data[corev1.TLSCertKey]))
+				Expect(cm.NextRenewal()).To(BeTemporally("~", time.Now().Add(6*24*time.Hour), time.Minute))
+
+				mockStatus := &status.MockStatus{}
+				mockStatus.On("RemoveCertificateSigningRequests", appNs)
+				mockStatus.On("RemoveCertificateRenewals", appNs)
+				cm.AddToStatusManager(mockStatus, appNs)
+				mockStatus.AssertExpectations(GinkgoT())
+			})
+
+			It("should not renew a byo key pair that is about to expire", func() {
+				Expect(cli.Delete(ctx, nearExpirySecret)).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, byoSecret)).NotTo(HaveOccurred())
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace())
+				Expect(err).NotTo(HaveOccurred())
+				kp, err := cm.GetOrCreateKeyPair(cli, byoSecret.Name, byoSecret.Namespace, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.BYO()).To(BeTrue())
+				Expect(cm.NextRenewal().IsZero()).To(BeTrue())
+			})
+
+			It("should reject a negative renewal window", func() {
+				_, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateRenewalWindow(-time.Hour))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("test exact DNS names", func() {
+			extraDNSNames := append([]string{"extra-name"}, appDNSNames...)
+
+			BeforeEach(func() {
+				Expect(cli.Create(ctx, certificateManager.KeyPair().Secret(common.OperatorNamespace()))).NotTo(HaveOccurred())
+			})
+
+			It("should keep an operator signed key pair with DNS names that were not requested by default", func() {
+				keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, extraDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+
+				keyPair2, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyPair2.GetCertificatePEM()).To(Equal(keyPair.GetCertificatePEM()))
+			})
+
+			It("should replace an operator signed key pair with DNS names that were not requested", func() {
+				keyPair, err := certificateManager.GetOrCreateKeyPair(cli, appSecretName, appNs, extraDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cli.Create(ctx, keyPair.Secret(appNs))).NotTo(HaveOccurred())
+
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithExactDNSNames())
+				Expect(err).NotTo(HaveOccurred())
+				keyPair2, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				cert, err := certificatemanagement.ParseCertificate(keyPair2.GetCertificatePEM())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cert.DNSNames).To(ConsistOf(appDNSNames))
+				Expect(cli.Update(ctx, keyPair2.Secret(appNs))).NotTo(HaveOccurred())
+
+				By("keeping the replacement key pair on subsequent calls")
+				keyPair3, err := cm.GetOrCreateKeyPair(cli, appSecretName, appNs, appDNSNames)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyPair3.HashAnnotationValue()).To(Equal(keyPair2.HashAnnotationValue()))
+			})
+
+			It("should not replace a byo key pair with DNS names that were not requested", func() {
+				Expect(cli.Create(ctx, byoSecret)).NotTo(HaveOccurred())
+				cm, err := certificatemanager.Create(cli, installation, clusterDomain, common.OperatorNamespace(), certificatemanager.WithExactDNSNames())
+				Expect(err).NotTo(HaveOccurred())
+				kp, err := cm.GetOrCreateKeyPair(cli, byoSecret.Name, byoSecret.Namespace, []string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kp.BYO()).To(BeTrue())
+				Expect(kp.GetCertificatePEM()).To(Equal(byoSecret.Data["cert.crt"]))
+			})
+		})
+
 		Describe("test certificate expiry", func() {
 			It("should create a new secret when it has expired", func() {
 				secret := expiredSecret
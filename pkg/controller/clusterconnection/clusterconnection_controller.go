@@ -16,8 +16,10 @@ package clusterconnection
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/go-logr/logr"
 
@@ -145,6 +147,12 @@ func add(mgr manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("%s failed to watch Secret resource %s: %w", controllerName, render.GuardianSecretName, err)
 	}
 
+	// Watch for changes to the secrets in the operator namespace, so that changes to the tunnel CA bundle referenced
+	// by the ManagementClusterConnection trigger a reconcile.
+	if err = utils.AddSecretsWatch(c, "", common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("%s failed to watch Secret resources in namespace %s: %w", controllerName, common.OperatorNamespace(), err)
+	}
+
 	// Watch for changes to the secrets associated with the PacketCapture APIs.
 	if err = utils.AddSecretsWatch(c, render.PacketCaptureServerCert, common.OperatorNamespace()); err != nil {
 		return fmt.Errorf("%s failed to watch Secret resource %s: %w", controllerName, render.PacketCaptureServerCert, err)
@@ -241,6 +249,11 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if err = validateManagementClusterConnection(managementClusterConnection); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate ManagementClusterConnection", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+
 	preDefaultPatchFrom := client.MergeFrom(managementClusterConnection.DeepCopy())
 	fillDefaults(managementClusterConnection)
 
@@ -313,6 +326,27 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 		trustedCertBundle.AddCertificates(secret)
 	}
 
+	// Trust the custom tunnel CA bundle, if any, in addition to the CA implied by the CA type.
+	if caBundleSecret := managementClusterConnection.Spec.TLS.CABundleSecret; caBundleSecret != nil {
+		secret, err := utils.GetSecret(ctx, r.Client, caBundleSecret.Name, common.OperatorNamespace())
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to retrieve %s", caBundleSecret.Name), err, reqLogger)
+			return reconcile.Result{}, err
+		} else if secret == nil || len(secret.Data[caBundleSecret.Key]) == 0 {
+			msg := fmt.Sprintf("Waiting for key '%s' of secret '%s' to become available", caBundleSecret.Key, caBundleSecret.Name)
+			reqLogger.Info(msg)
+			r.status.SetDegraded(operatorv1.ResourceNotReady, msg, nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		caBundle := secret.Data[caBundleSecret.Key]
+		if !x509.NewCertPool().AppendCertsFromPEM(caBundle) {
+			err = fmt.Errorf("key '%s' of secret '%s' does not contain a PEM-encoded certificate", caBundleSecret.Key, caBundleSecret.Name)
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Invalid tunnel CA bundle", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+		trustedCertBundle.AddCertificates(certificatemanagement.NewCertificate(secret.Name, secret.Namespace, caBundle, nil))
+	}
+
 	// Validate that the tier watch is ready before querying the tier to ensure we utilize the cache.
 	if !r.tierWatchReady.IsReady() {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tier watch to be established", nil, reqLogger)
@@ -355,7 +389,7 @@ func (r *ReconcileConnection) Reconcile(ctx context.Context, request reconcile.R
 
 	ch := utils.NewComponentHandler(log, r.Client, r.Scheme, managementClusterConnection)
 	guardianCfg := &render.GuardianConfiguration{
-		URL:                         managementClusterConnection.Spec.ManagementClusterAddr,
+		URLs:                        managementClusterAddrs(managementClusterConnection),
 		TunnelCAType:                managementClusterConnection.Spec.TLS.CA,
 		PullSecrets:                 pullSecrets,
 		Openshift:                   r.Provider == operatorv1.ProviderOpenShift,
@@ -408,6 +442,35 @@ func fillDefaults(mcc *operatorv1.ManagementClusterConnection) {
 	}
 }
 
+// validateManagementClusterConnection verifies that the failover addresses of the ManagementClusterConnection have the
+// form host:port and are listed once, and that its tunnel timings are positive.
+func validateManagementClusterConnection(mcc *operatorv1.ManagementClusterConnection) error {
+	seen := map[string]bool{mcc.Spec.ManagementClusterAddr: true}
+	for _, addr := range mcc.Spec.ManagementClusterAddrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("ManagementClusterConnection spec.managementClusterAddrs entry %q is invalid: %w", addr, err)
+		}
+		if host == "" {
+			return fmt.Errorf("ManagementClusterConnection spec.managementClusterAddrs entry %q must specify a host", addr)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return fmt.Errorf("ManagementClusterConnection spec.managementClusterAddrs entry %q must specify a port between 1 and 65535", addr)
+		}
+		if seen[addr] {
+			return fmt.Errorf("ManagementClusterConnection spec.managementClusterAddrs entry %q is listed more than once", addr)
+		}
+		seen[addr] = true
+	}
+	if t := mcc.Spec.ReconnectTimeout; t != nil && t.Duration <= 0 {
+		return fmt.Errorf("ManagementClusterConnection spec.reconnectTimeout must be a positive duration, got %s", t.Duration)
+	}
+	if i := mcc.Spec.KeepAliveInterval; i != nil && i.Duration <= 0 {
+		return fmt.Errorf("ManagementClusterConnection spec.keepAliveInterval must be a positive duration, got %s", i.Duration)
+	}
+	return nil
+}
+
 func networkPolicyRequiresEgressAccessControl(connection *operatorv1.ManagementClusterConnection, log logr.Logger) bool {
 	if clusterAddrHasDomain, err := managementClusterAddrHasDomain(connection); err == nil && clusterAddrHasDomain {
 		return true
@@ -424,10 +487,24 @@ func networkPolicyRequiresEgressAccessControl(connection *operatorv1.ManagementC
 }
 
 func managementClusterAddrHasDomain(connection *operatorv1.ManagementClusterConnection) (bool, error) {
-	host, _, err := net.SplitHostPort(connection.Spec.ManagementClusterAddr)
-	if err != nil {
-		return false, err
+	for _, addr := range managementClusterAddrs(connection) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return false, err
+		}
+		if net.ParseIP(host) == nil {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	return net.ParseIP(host) == nil, nil
+// managementClusterAddrs returns the addresses of the management cluster in the order that Guardian tries them:
+// ManagementClusterAddr, if specified, followed by ManagementClusterAddrs.
+func managementClusterAddrs(connection *operatorv1.ManagementClusterConnection) []string {
+	var addrs []string
+	if connection.Spec.ManagementClusterAddr != "" {
+		addrs = append(addrs, connection.Spec.ManagementClusterAddr)
+	}
+	return append(addrs, connection.Spec.ManagementClusterAddrs...)
 }
@@ -17,6 +17,7 @@ package clusterconnection_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -24,6 +25,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -44,6 +46,7 @@ import (
 	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	"github.com/tigera/operator/pkg/render/monitor"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"github.com/tigera/operator/test"
 )
 
@@ -160,6 +163,114 @@ var _ = Describe("ManagementClusterConnection controller tests", func() {
 		})
 	})
 
+	Context("tunnel TLS", func() {
+		BeforeEach(func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cfg)).NotTo(HaveOccurred())
+			cfg.Spec.TLS = &operatorv1.ManagementClusterTLS{
+				CABundleSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tunnel-ca"}, Key: "tls.crt"},
+				ServerName:     "voltron.example.com",
+			}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+		})
+
+		It("should trust the configured CA bundle and server name", func() {
+			caSecret, err := certificatemanagement.CreateSelfSignedSecret("tunnel-ca", common.OperatorNamespace(), "voltron", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Create(ctx, caSecret)).NotTo(HaveOccurred())
+
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			bundle := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: certificatemanagement.TrustedCertConfigMapName, Namespace: render.GuardianNamespace}, bundle)).NotTo(HaveOccurred())
+			Expect(bundle.Data[certificatemanagement.TrustedCertConfigMapKeyName]).To(ContainSubstring(string(caSecret.Data["tls.crt"])))
+
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).NotTo(HaveOccurred())
+			guardian := test.GetContainer(dpl.Spec.Template.Spec.Containers, render.GuardianDeploymentName)
+			Expect(guardian.Env).To(ContainElements(
+				corev1.EnvVar{Name: "GUARDIAN_VOLTRON_CA_BUNDLE_PATH", Value: certificatemanagement.TrustedCertBundleMountPath},
+				corev1.EnvVar{Name: "GUARDIAN_VOLTRON_SERVER_NAME", Value: "voltron.example.com"},
+			))
+		})
+
+		It("should wait for the CA bundle secret", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).To(HaveOccurred())
+		})
+	})
+
+	Context("tunnel timings", func() {
+		It("should render the reconnect timeout and keepalive interval when configured", func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cfg)).NotTo(HaveOccurred())
+			cfg.Spec.ReconnectTimeout = &metav1.Duration{Duration: 30 * time.Second}
+			cfg.Spec.KeepAliveInterval = &metav1.Duration{Duration: 10 * time.Second}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).NotTo(HaveOccurred())
+			guardian := test.GetContainer(dpl.Spec.Template.Spec.Containers, render.GuardianDeploymentName)
+			Expect(guardian.Env).To(ContainElements(
+				corev1.EnvVar{Name: "GUARDIAN_TUNNEL_DIAL_TIMEOUT", Value: "30s"},
+				corev1.EnvVar{Name: "GUARDIAN_KEEP_ALIVE_INTERVAL", Value: "10s"},
+			))
+		})
+
+		It("should degrade when the reconnect timeout or keepalive interval is not positive", func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cfg)).NotTo(HaveOccurred())
+			cfg.Spec.KeepAliveInterval = &metav1.Duration{Duration: 0}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Failed to validate ManagementClusterConnection", mock.Anything, mock.Anything)
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).To(HaveOccurred())
+		})
+	})
+
+	Context("management cluster addresses", func() {
+		It("should render a single management cluster address as before", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).NotTo(HaveOccurred())
+			guardian := test.GetContainer(dpl.Spec.Template.Spec.Containers, render.GuardianDeploymentName)
+			Expect(guardian.Env).To(ContainElement(corev1.EnvVar{Name: "GUARDIAN_VOLTRON_URL", Value: "127.0.0.1:12345"}))
+			Expect(guardian.Env).NotTo(ContainElement(HaveField("Name", "GUARDIAN_VOLTRON_URLS")))
+		})
+
+		It("should render the failover addresses after the management cluster address", func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cfg)).NotTo(HaveOccurred())
+			cfg.Spec.ManagementClusterAddrs = []string{"127.0.0.2:12345", "[fd00::2]:12345"}
+			Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).NotTo(HaveOccurred())
+			guardian := test.GetContainer(dpl.Spec.Template.Spec.Containers, render.GuardianDeploymentName)
+			Expect(guardian.Env).To(ContainElements(
+				corev1.EnvVar{Name: "GUARDIAN_VOLTRON_URL", Value: "127.0.0.1:12345"},
+				corev1.EnvVar{Name: "GUARDIAN_VOLTRON_URLS", Value: "127.0.0.1:12345,127.0.0.2:12345,[fd00::2]:12345"},
+			))
+		})
+
+		It("should degrade when a failover address is malformed", func() {
+			for _, addrs := range [][]string{{"127.0.0.2"}, {":12345"}, {"127.0.0.2:http"}, {"127.0.0.2:0"}, {"127.0.0.1:12345"}} {
+				Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cfg)).NotTo(HaveOccurred())
+				cfg.Spec.ManagementClusterAddrs = addrs
+				Expect(c.Update(ctx, cfg)).NotTo(HaveOccurred())
+
+				_, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).NotTo(HaveOccurred())
+				mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Failed to validate ManagementClusterConnection", mock.Anything, mock.Anything)
+				Expect(c.Get(ctx, client.ObjectKey{Name: render.GuardianDeploymentName, Namespace: render.GuardianNamespace}, dpl)).To(HaveOccurred())
+			}
+		})
+	})
+
 	Context("image reconciliation", func() {
 		It("should use builtin images", func() {
 			r = clusterconnection.NewReconcilerWithShims(c, scheme, mockStatus, operatorv1.ProviderNone, ready)
@@ -183,15 +294,10 @@ var _ = Describe("ManagementClusterConnection controller tests", func() {
 					components.ComponentGuardian.Version)))
 		})
 		It("should use images from imageset", func() {
-			Expect(c.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/guardian", Digest: "sha256:guardianhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/guardian", Digest: "sha256:guardianhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			r = clusterconnection.NewReconcilerWithShims(c, scheme, mockStatus, operatorv1.ProviderNone, ready)
 			_, err := r.Reconcile(ctx, reconcile.Request{})
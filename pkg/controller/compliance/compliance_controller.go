@@ -17,12 +17,23 @@ package compliance
 import (
 	"context"
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	// The time zone database is embedded so that Compliance timezones can be validated regardless of the
+	// operator image contents.
+	_ "time/tzdata"
 
 	"github.com/tigera/operator/pkg/controller/tenancy"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 
-	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
@@ -36,8 +47,10 @@ import (
 	"github.com/tigera/operator/pkg/dns"
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,13 +94,19 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 	}
 
+	if opts.ResyncPeriod > 0 {
+		if err = utils.AddPeriodicReconcile(complianceController, opts.ResyncPeriod, eventHandler); err != nil {
+			return fmt.Errorf("compliance-controller failed to create periodic reconcile watch: %w", err)
+		}
+	}
+
 	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		log.Error(err, "Failed to establish a connection to k8s")
 		return err
 	}
 
-	installNS, _, watchNamespaces := tenancy.GetWatchNamespaces(opts.MultiTenant, render.PolicyRecommendationNamespace)
+	installNS, truthNS, watchNamespaces := tenancy.GetWatchNamespaces(opts.MultiTenant, render.PolicyRecommendationNamespace)
 
 	go utils.WaitToAddLicenseKeyWatch(complianceController, k8sClient, log, licenseAPIReady)
 
@@ -129,6 +148,12 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		}
 	}
 
+	// The benchmarker profile ConfigMap is referenced by name from the Compliance, so watch all ConfigMaps in the
+	// namespace it is read from.
+	if err = utils.AddConfigMapWatch(complianceController, "", truthNS, eventHandler); err != nil {
+		return fmt.Errorf("compliance-controller failed to watch ConfigMaps: %w", err)
+	}
+
 	// Watch for changes to primary resource ManagementCluster
 	if err = complianceController.WatchObject(&operatorv1.ManagementCluster{}, eventHandler); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch primary resource: %w", err)
@@ -143,6 +168,10 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("compliance-controller failed to watch resource: %w", err)
 	}
 
+	if err = complianceController.WatchObject(&operatorv1.Monitor{}, eventHandler); err != nil {
+		return fmt.Errorf("compliance-controller failed to watch Monitor resource: %w", err)
+	}
+
 	// Watch for changes to TigeraStatus.
 	if err = utils.AddTigeraStatusWatch(complianceController, ResourceName); err != nil {
 		return fmt.Errorf("compliance-controller failed to watch compliance Tigerastatus: %w", err)
@@ -153,17 +182,29 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 // newReconciler returns a new *reconcile.Reconciler
 func newReconciler(mgr manager.Manager, opts options.AddOptions, licenseAPIReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+	// Degraded events are recorded on the Compliance CR.
+	events := status.WithEventRecorder(mgr.GetEventRecorderFor("compliance-controller"), &operatorv1.Compliance{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})
 	r := &ReconcileCompliance{
 		client:          mgr.GetClient(),
 		scheme:          mgr.GetScheme(),
 		provider:        opts.DetectedProvider,
-		status:          status.New(mgr.GetClient(), "compliance", opts.KubernetesVersion),
+		status:          status.New(mgr.GetClient(), "compliance", opts.KubernetesVersion, events),
 		clusterDomain:   opts.ClusterDomain,
 		licenseAPIReady: licenseAPIReady,
 		tierWatchReady:  tierWatchReady,
 		usePSP:          opts.UsePSP,
 		multiTenant:     opts.MultiTenant,
 		externalElastic: opts.ElasticExternal,
+
+		certificateDuration:      opts.CertificateDuration,
+		certificateRenewalWindow: opts.CertificateRenewalWindow,
+		requeueInterval:          opts.RequeueInterval,
+		dryRun:                   opts.DryRun,
+		serverSideApply:          opts.UseServerSideApply,
+		unmanagedSelector:        opts.UnmanagedResourceSelector,
+	}
+	if opts.AuditSpecChanges {
+		r.specAuditor = utils.NewSpecAuditor(log.WithName("audit"))
 	}
 	r.status.Run(opts.ShutdownContext)
 	return r
@@ -183,9 +224,32 @@ type ReconcileCompliance struct {
 	clusterDomain   string
 	licenseAPIReady *utils.ReadyFlag
 	tierWatchReady  *utils.ReadyFlag
+	tierWaiter      utils.TierWaiter
 	usePSP          bool
 	multiTenant     bool
 	externalElastic bool
+
+	// The validity of the key pairs issued by this controller. The default is used when zero.
+	certificateDuration time.Duration
+
+	// How long before expiry the key pairs issued by this controller are renewed. The default is used when zero.
+	certificateRenewalWindow time.Duration
+
+	// specAuditor, if set, keeps an audit trail of the spec changes this controller acts on.
+	specAuditor *utils.SpecAuditor
+
+	// How long to wait before reconciling again when resources are not yet available. utils.StandardRetry is
+	// used when zero.
+	requeueInterval time.Duration
+
+	// dryRun, if set, makes the component handler log the writes it would make instead of making them.
+	dryRun bool
+
+	// serverSideApply, if set, makes the component handler write objects using server-side apply.
+	serverSideApply bool
+
+	// unmanagedSelector selects existing objects that the component handler should not update.
+	unmanagedSelector labels.Selector
 }
 
 func GetCompliance(ctx context.Context, cli client.Client, mt bool, ns string) (*operatorv1.Compliance, error) {
@@ -260,6 +324,14 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		}
 	}
 
+	if err = validateComplianceResource(instance); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Compliance", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+	if r.specAuditor != nil {
+		r.specAuditor.Record(instance, instance.Spec)
+	}
+
 	if !utils.IsAPIServerReady(r.client, reqLogger) {
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Tigera API server to be ready", nil, reqLogger)
 		return reconcile.Result{}, err
@@ -272,15 +344,8 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		if errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
-		} else {
-			log.Error(err, "Error querying allow-tigera tier")
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
-			return reconcile.Result{}, err
-		}
+	if result, err := r.tierWaiter.EnsureTierReady(ctx, r.client, r.status, r.requeueInterval, reqLogger); result != nil {
+		return *result, err
 	}
 
 	if !r.licenseAPIReady.IsReady() {
@@ -292,10 +357,18 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	if err != nil {
 		if errors.IsNotFound(err) {
 			r.status.SetDegraded(operatorv1.ResourceNotFound, "License not found", err, reqLogger)
-			return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+			return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
 		}
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying license", err, reqLogger)
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
+	}
+
+	// Report a license that is about to expire in the Compliance status conditions, without degrading the component.
+	if utils.SetLicenseExpiringCondition(&instance.Status.Conditions, license, instance.Generation) {
+		if err := r.client.Status().Update(ctx, instance); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to update the Compliance license condition", err, reqLogger)
+			return reconcile.Result{}, err
+		}
 	}
 
 	// Query for the installation object.
@@ -315,6 +388,15 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	if err = utils.ValidateClusterRole(ctx, r.client); err != nil {
+		if utils.IsClusterRoleConflictError(err) {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
+		} else {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the cluster role", err, reqLogger)
+		}
+		return reconcile.Result{}, err
+	}
+
 	managementCluster, err := utils.GetManagementCluster(ctx, r.client)
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading ManagementCluster", err, reqLogger)
@@ -327,15 +409,9 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	if managementClusterConnection != nil && managementCluster != nil {
-		err = fmt.Errorf("having both a ManagementCluster and a ManagementClusterConnection is not supported")
-		r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
-		return reconcile.Result{}, err
-	}
-
 	var opts []certificatemanager.Option
 
-	opts = append(opts, certificatemanager.WithTenant(tenant), certificatemanager.WithLogger(reqLogger))
+	opts = append(opts, certificatemanager.WithTenant(tenant), certificatemanager.WithLogger(reqLogger), certificatemanager.WithCertificateDuration(r.certificateDuration), certificatemanager.WithCertificateRenewalWindow(r.certificateRenewalWindow))
 
 	certificateManager, err := certificatemanager.Create(r.client, network, r.clusterDomain, helper.TruthNamespace(), opts...)
 	if err != nil {
@@ -381,19 +457,35 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		bundleMaker = nil
 	}
 
+	// The compliance metrics are scraped by the Prometheus instance of the Monitor, so they are only served when the
+	// Monitor is installed.
+	monitorEnabled := true
+	if err = r.client.Get(ctx, utils.DefaultTSEEInstanceKey, &operatorv1.Monitor{}); err != nil {
+		if !errors.IsNotFound(err) {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying Monitor", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		monitorEnabled = false
+	}
+	metricsEnabled := !r.multiTenant && render.ComplianceMetricsEnabled(instance, monitorEnabled)
+
 	// Get the key pairs for each component, generating them as needed.
 	type complianceKeyPair struct {
 		SecretName string
+		Component  string
 		Interface  certificatemanagement.KeyPairInterface
 	}
-	snapshotterKeyPair := complianceKeyPair{SecretName: render.ComplianceSnapshotterSecret}
-	benchmarkerKeyPair := complianceKeyPair{SecretName: render.ComplianceBenchmarkerSecret}
+	snapshotterKeyPair := complianceKeyPair{SecretName: render.ComplianceSnapshotterSecret, Component: render.ComplianceSnapshotterName}
+	benchmarkerKeyPair := complianceKeyPair{SecretName: render.ComplianceBenchmarkerSecret, Component: render.ComplianceBenchmarkerName}
 	reporterKeyPair := complianceKeyPair{SecretName: render.ComplianceReporterSecret}
-	controllerKeyPair := complianceKeyPair{SecretName: render.ComplianceControllerSecret}
+	controllerKeyPair := complianceKeyPair{SecretName: render.ComplianceControllerSecret, Component: render.ComplianceControllerName}
 	for _, kp := range []*complianceKeyPair{&snapshotterKeyPair, &benchmarkerKeyPair, &reporterKeyPair, &controllerKeyPair} {
-		// These key pairs are only used as client credentials for mTLS with Linseed, and so do not need DNS names listed
-		// as they do not act as server certs.
+		// These key pairs are used as client credentials for mTLS with Linseed, and so do not need DNS names listed
+		// unless they also act as the server certs of the metrics endpoints.
 		dnsNames := []string{"localhost"}
+		if metricsEnabled && kp.Component != "" {
+			dnsNames = append(dnsNames, dns.GetServiceDNSNames(render.ComplianceMetricsServiceName(kp.Component), helper.InstallNamespace(), r.clusterDomain)...)
+		}
 		kp.Interface, err = certificateManager.GetOrCreateKeyPair(r.client, kp.SecretName, helper.TruthNamespace(), dnsNames)
 		if err != nil {
 			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("failed to retrieve / validate  %s", kp.SecretName), err, reqLogger)
@@ -426,8 +518,25 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, nil
 	}
 
+	var benchmarkerProfile *corev1.ConfigMap
+	if ref := instance.Spec.BenchmarkerProfileConfigMap; ref != nil {
+		benchmarkerProfile = &corev1.ConfigMap{}
+		if err = r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: helper.TruthNamespace()}, benchmarkerProfile); err != nil {
+			if errors.IsNotFound(err) {
+				r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Benchmarker profile ConfigMap %s not found", ref.Name), err, reqLogger)
+				return reconcile.Result{}, nil
+			}
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying benchmarker profile ConfigMap", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if _, ok := benchmarkerProfile.Data[ref.Key]; !ok {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Benchmarker profile ConfigMap %s has no key %s", ref.Name, ref.Key), nil, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
 	// Create a component handler to manage the rendered component.
-	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	handler := utils.NewComponentHandler(log, r.client, r.scheme, instance, utils.WithAdditionalMetadata(network.AdditionalLabels, network.AdditionalAnnotations), utils.WithDryRun(r.dryRun), utils.WithServerSideApply(r.serverSideApply), utils.WithUnmanagedSelector(r.unmanagedSelector))
 
 	keyValidatorConfig, err := utils.GetKeyValidatorConfig(ctx, r.client, authenticationCR, r.clusterDomain)
 	if err != nil {
@@ -461,6 +570,8 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		Tenant:                      tenant,
 		Compliance:                  instance,
 		ExternalElastic:             r.externalElastic,
+		BenchmarkerProfile:          benchmarkerProfile,
+		MonitorEnabled:              monitorEnabled,
 	}
 
 	// Render the desired objects from the CRD and create or update them.
@@ -470,8 +581,12 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ApplyImageSet(ctx, r.client, variant, comp); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+	imageSet, err := imageset.GetImageSet(ctx, r.client, variant)
+	if err == nil {
+		err = imageset.ValidateImageSet(imageSet, variant)
+	}
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ImageSetError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 	certificateComponent := rcertificatemanagement.CertificateManagement(&rcertificatemanagement.Config{
@@ -488,11 +603,15 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 		TrustedBundle: bundleMaker,
 	})
 
-	for _, comp := range []render.Component{namespaceComp, certificateComponent, comp} {
-		if err := handler.CreateOrUpdateOrDelete(ctx, comp, r.status); err != nil {
-			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating / deleting resource", err, reqLogger)
-			return reconcile.Result{}, err
+	// The compliance component declares the namespace and the allow-tigera tier as dependencies, so it is applied
+	// after the namespace component, and only once the tier exists.
+	if err := handler.CreateOrUpdateOrDeleteAll(ctx, imageSet, []render.Component{namespaceComp, certificateComponent, comp}, r.status); err != nil {
+		if utils.IsDependencyNotReady(err) {
+			r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for the dependencies of the compliance components", err, reqLogger)
+			return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
 		}
+		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating / deleting resource", err, reqLogger)
+		return reconcile.Result{}, err
 	}
 
 	if hasNoLicense {
@@ -507,7 +626,7 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	if !r.status.IsAvailable() {
 		// Schedule a kick to check again in the near future. Hopefully by then
 		// things will be available.
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
 	}
 
 	// Everything is available - update the CRD status.
@@ -515,5 +634,202 @@ func (r *ReconcileCompliance) Reconcile(ctx context.Context, request reconcile.R
 	if err = r.client.Status().Update(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{}, nil
+
+	// Reconcile again when one of the compliance key pairs is due for renewal.
+	result := reconcile.Result{}
+	if next := certificateManager.NextRenewal(); !next.IsZero() {
+		result.RequeueAfter = time.Until(next)
+	}
+	// Reconcile again when the license enters its expiry warning period, so that the condition reports it.
+	if expiry := utils.LicenseExpiry(license); !expiry.IsZero() {
+		if warn := time.Until(expiry.Add(-utils.LicenseExpiryWarningPeriod)); warn > 0 && (result.RequeueAfter == 0 || warn < result.RequeueAfter) {
+			result.RequeueAfter = warn
+		}
+	}
+	return result, nil
+}
+
+// maxServerRequestBodySize is the largest request body size limit accepted for the compliance server, so that a single
+// request cannot be allowed to exhaust its memory.
+var maxServerRequestBodySize = resource.MustParse("1Gi")
+
+// validateComplianceResource validates the Compliance resource and returns an error if it is invalid.
+func validateComplianceResource(instance *operatorv1.Compliance) error {
+	if l := instance.Spec.JobBackoffLimit; l != nil && *l < 0 {
+		return fmt.Errorf("Compliance spec.jobBackoffLimit must not be negative, got %d", *l)
+	}
+	if s := instance.Spec.ServerWorkerPoolSize; s != nil && *s <= 0 {
+		return fmt.Errorf("Compliance spec.serverWorkerPoolSize must be positive, got %d", *s)
+	}
+	if s := instance.Spec.ServerMaxRequestBodySize; s != nil {
+		if _, whole := s.AsInt64(); !whole || s.Sign() <= 0 || s.Cmp(maxServerRequestBodySize) > 0 {
+			return fmt.Errorf("Compliance spec.serverMaxRequestBodySize must be a whole number of bytes between 1 and %s, got %s", maxServerRequestBodySize.String(), s.String())
+		}
+	}
+	if t := instance.Spec.ServerIdleTimeout; t != nil && t.Duration <= 0 {
+		return fmt.Errorf("Compliance spec.serverIdleTimeout must be positive, got %s", t.Duration)
+	}
+	if schedule := instance.Spec.SnapshotSchedule; schedule != "" {
+		if err := validateCronSchedule(schedule); err != nil {
+			return fmt.Errorf("Compliance spec.snapshotSchedule %q is not a valid cron schedule: %w", schedule, err)
+		}
+	}
+	if s := instance.Spec.SnapshotterBatchSize; s != nil && *s <= 0 {
+		return fmt.Errorf("Compliance spec.snapshotterBatchSize must be positive, got %d", *s)
+	}
+	if i := instance.Spec.SnapshotterFlushInterval; i != nil && i.Duration <= 0 {
+		return fmt.Errorf("Compliance spec.snapshotterFlushInterval must be positive, got %s", i.Duration)
+	}
+	if p := instance.Spec.SoftMemoryLimitPercent; p != nil && (*p < 1 || *p > 100) {
+		return fmt.Errorf("Compliance spec.softMemoryLimitPercent must be between 1 and 100, got %d", *p)
+	}
+	if err := poddisruptionbudget.Validate(instance.Spec.ComplianceServerPodDisruptionBudget, render.ComplianceServerReplicas()); err != nil {
+		return fmt.Errorf("Compliance spec.complianceServerPodDisruptionBudget is not valid: %w", err)
+	}
+	if tz := instance.Spec.Timezone; tz != "" {
+		// LoadLocation treats "Local" as the operator's own time zone, which is not meaningful for the compliance pods.
+		if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+			return fmt.Errorf("Compliance spec.timezone %q is not a valid time zone database name", tz)
+		}
+	}
+	if ref := instance.Spec.BenchmarkerProfileConfigMap; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf("Compliance spec.benchmarkerProfileConfigMap must specify both a name and a key")
+	}
+	if err := validateBenchmarkerResultOutput(instance.Spec.BenchmarkerResultOutput); err != nil {
+		return fmt.Errorf("Compliance spec.benchmarkerResultOutput is invalid: %w", err)
+	}
+	for _, entry := range instance.Spec.NoProxy {
+		if entry == "" || strings.ContainsAny(entry, ", \t\n") {
+			return fmt.Errorf("Compliance spec.noProxy entry %q must be a non-empty host, domain or CIDR without commas or whitespace", entry)
+		}
+	}
+	if err := validateReportRetention(instance.Spec.ReportRetention); err != nil {
+		return fmt.Errorf("Compliance spec.reportRetention is invalid: %w", err)
+	}
+	seen := map[operatorv1.ComplianceReportOutputFormat]bool{}
+	for _, f := range instance.Spec.ReportOutputFormats {
+		switch f {
+		case operatorv1.ComplianceReportOutputFormatJSON, operatorv1.ComplianceReportOutputFormatCSV, operatorv1.ComplianceReportOutputFormatYAML:
+		default:
+			return fmt.Errorf("Compliance spec.reportOutputFormats contains unsupported format %q", f)
+		}
+		if seen[f] {
+			return fmt.Errorf("Compliance spec.reportOutputFormats contains duplicate format %q", f)
+		}
+		seen[f] = true
+	}
+	return nil
+}
+
+// cronDescriptors are the predefined schedules accepted in place of a five field cron expression.
+var cronDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true, "@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// cronFieldBounds are the names and the inclusive bounds of the fields of a cron expression, in order. Both 0 and 7
+// mean Sunday in the day of the week field.
+var cronFieldBounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of the month", 1, 31},
+	{"month", 1, 12},
+	{"day of the week", 0, 7},
+}
+
+// validateCronSchedule verifies that schedule is a standard five field cron expression, in which each field is a
+// comma separated list of values, ranges or * with an optional step, or one of the predefined descriptors.
+func validateCronSchedule(schedule string) error {
+	if strings.HasPrefix(schedule, "@") {
+		if !cronDescriptors[schedule] {
+			return fmt.Errorf("unknown descriptor %s", schedule)
+		}
+		return nil
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFieldBounds) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFieldBounds), len(fields))
+	}
+	for i, field := range fields {
+		bounds := cronFieldBounds[i]
+		for _, item := range strings.Split(field, ",") {
+			if err := validateCronItem(item, bounds.min, bounds.max); err != nil {
+				return fmt.Errorf("%s field %q is invalid: %w", bounds.name, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCronItem verifies that item is *, a value or a range between min and max, optionally followed by a
+// positive step.
+func validateCronItem(item string, min, max int) error {
+	rng, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("step %q must be a positive number", step)
+		}
+	}
+	if rng == "*" {
+		return nil
+	}
+	lo, hi, isRange := strings.Cut(rng, "-")
+	if !isRange {
+		hi = lo
+	}
+	start, err := strconv.Atoi(lo)
+	if err != nil || start < min || start > max {
+		return fmt.Errorf("%q must be a number between %d and %d", lo, min, max)
+	}
+	end, err := strconv.Atoi(hi)
+	if err != nil || end < min || end > max {
+		return fmt.Errorf("%q must be a number between %d and %d", hi, min, max)
+	}
+	if start > end {
+		return fmt.Errorf("range %q must not end before it starts", rng)
+	}
+	return nil
+}
+
+// validateReportRetention verifies that the report retention limits reports by a positive age, a positive count or both.
+func validateReportRetention(retention *operatorv1.ComplianceReportRetention) error {
+	if retention == nil {
+		return nil
+	}
+	if retention.MaxAge == nil && retention.MaxReports == nil {
+		return fmt.Errorf("at least one of maxAge and maxReports must be specified")
+	}
+	if a := retention.MaxAge; a != nil && a.Duration <= 0 {
+		return fmt.Errorf("maxAge must be positive, got %s", a.Duration)
+	}
+	if n := retention.MaxReports; n != nil && *n <= 0 {
+		return fmt.Errorf("maxReports must be positive, got %d", *n)
+	}
+	return nil
+}
+
+// validateBenchmarkerResultOutput verifies that the benchmarker result volume is mounted at, and backed by, clean
+// absolute paths, and that it is backed by exactly one of a host path and a PersistentVolumeClaim.
+func validateBenchmarkerResultOutput(output *operatorv1.ComplianceBenchmarkerResultOutput) error {
+	if output == nil {
+		return nil
+	}
+	if !path.IsAbs(output.Path) || path.Clean(output.Path) != output.Path || output.Path == "/" {
+		return fmt.Errorf("path %q must be a clean absolute path other than the root directory", output.Path)
+	}
+	if (output.HostPath == "") == (output.PersistentVolumeClaimName == "") {
+		return fmt.Errorf("exactly one of hostPath and persistentVolumeClaimName must be specified")
+	}
+	if output.HostPath != "" && (!path.IsAbs(output.HostPath) || path.Clean(output.HostPath) != output.HostPath || output.HostPath == "/") {
+		return fmt.Errorf("hostPath %q must be a clean absolute path other than the root directory", output.HostPath)
+	}
+	if name := output.PersistentVolumeClaimName; name != "" {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("persistentVolumeClaimName %q is not a valid name: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
 }
@@ -24,9 +24,12 @@ import (
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/tls"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/mock"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
@@ -41,14 +44,19 @@ import (
 	"github.com/tigera/operator/pkg/render"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -67,6 +75,7 @@ var _ = Describe("Compliance controller tests", func() {
 		scheme = runtime.NewScheme()
 		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
 		Expect(appsv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+		Expect(batchv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(rbacv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 		Expect(operatorv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
@@ -79,8 +88,10 @@ var _ = Describe("Compliance controller tests", func() {
 		mockStatus.On("AddDeployments", mock.Anything).Return()
 		mockStatus.On("RemoveDeployments", mock.Anything).Return()
 		mockStatus.On("RemoveDaemonsets", mock.Anything).Return()
+		mockStatus.On("RemoveCronJobs", mock.Anything).Return()
 		mockStatus.On("AddStatefulSets", mock.Anything).Return()
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 		mockStatus.On("AddCronJobs", mock.Anything)
 		mockStatus.On("IsAvailable").Return(true)
 		mockStatus.On("OnCRFound").Return()
@@ -470,19 +481,14 @@ var _ = Describe("Compliance controller tests", func() {
 					components.ComponentComplianceServer.Version)))
 		})
 		It("should use images from imageset", func() {
-			Expect(c.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/compliance-benchmarker", Digest: "sha256:benchmarkerhash"},
-						{Image: "tigera/compliance-controller", Digest: "sha256:controllerhash"},
-						{Image: "tigera/compliance-reporter", Digest: "sha256:reporterhash"},
-						{Image: "tigera/compliance-server", Digest: "sha256:serverhash"},
-						{Image: "tigera/compliance-snapshotter", Digest: "sha256:snapshotterhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/compliance-benchmarker", Digest: "sha256:benchmarkerhash"},
+				operatorv1.Image{Image: "tigera/compliance-controller", Digest: "sha256:controllerhash"},
+				operatorv1.Image{Image: "tigera/compliance-reporter", Digest: "sha256:reporterhash"},
+				operatorv1.Image{Image: "tigera/compliance-server", Digest: "sha256:serverhash"},
+				operatorv1.Image{Image: "tigera/compliance-snapshotter", Digest: "sha256:snapshotterhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
@@ -567,6 +573,23 @@ var _ = Describe("Compliance controller tests", func() {
 					components.ComponentComplianceServer.Image,
 					"sha256:serverhash")))
 		})
+		It("should degrade when the imageset is missing images", func() {
+			Expect(c.Create(ctx, &operatorv1.ImageSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
+				Spec: operatorv1.ImageSetSpec{
+					Images: []operatorv1.Image{
+						{Image: "tigera/compliance-controller", Digest: "sha256:controllerhash"},
+						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+					},
+				},
+			})).ToNot(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ImageSetError, "Error with images from ImageSet", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing images"))
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ImageSetError, "Error with images from ImageSet", mock.Anything, mock.Anything)
+		})
 	})
 
 	Context("allow-tigera reconciliation", func() {
@@ -594,12 +617,53 @@ var _ = Describe("Compliance controller tests", func() {
 			test.DeleteAllowTigeraTierAndExpectWait(ctx, c, &r, mockStatus)
 		})
 
+		It("should stop requeueing and point at the License when the allow-tigera tier is not created", func() {
+			test.DeleteAllowTigeraTierAndExpectWait(ctx, c, &r, mockStatus)
+			for i := 2; i < utils.AllowTigeraTierMaxAttempts; i++ {
+				_, err := r.Reconcile(ctx, reconcile.Request{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			msg := fmt.Sprintf("The allow-tigera tier has not been created after %d attempts, "+
+				"check that a valid License is applied and see the 'tiers' TigeraStatus for more information", utils.AllowTigeraTierMaxAttempts)
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, msg, mock.Anything, mock.Anything).Return()
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, msg, mock.Anything, mock.Anything)
+		})
+
 		It("should wait if tier watch is not ready", func() {
 			r.tierWatchReady = &utils.ReadyFlag{}
 			test.ExpectWaitForTierWatch(ctx, &r, mockStatus)
 		})
 	})
 
+	It("should requeue if the allow-tigera tier is deleted before the compliance components are applied", func() {
+		// Delete the tier once the controller has checked that it exists, so that it is missing when the compliance
+		// component's dependencies are checked.
+		r.client = interceptor.NewClient(c.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := cli.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if _, ok := obj.(*v3.Tier); ok {
+					return cli.Delete(ctx, obj)
+				}
+				return nil
+			},
+		})
+		mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for the dependencies of the compliance components", mock.Anything, mock.Anything).Return()
+
+		result, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(utils.StandardRetry))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotReady, "Waiting for the dependencies of the compliance components", mock.Anything, mock.Anything)
+
+		By("not creating the compliance deployments")
+		Expect(c.Get(ctx, client.ObjectKey{Name: render.ComplianceServerName, Namespace: render.ComplianceNamespace}, &appsv1.Deployment{})).To(HaveOccurred())
+	})
+
 	Context("Feature compliance not active", func() {
 		BeforeEach(func() {
 			By("Deleting the previous license")
@@ -681,6 +745,45 @@ var _ = Describe("Compliance controller tests", func() {
 		})
 	})
 
+	Context("License expiry", func() {
+		setLicenseExpiry := func(expiry time.Time) {
+			license := &v3.LicenseKey{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "default"}, license)).NotTo(HaveOccurred())
+			license.Status.Expiry = metav1.NewTime(expiry)
+			Expect(c.Update(ctx, license)).NotTo(HaveOccurred())
+		}
+
+		It("should report a license that expires soon without degrading", func() {
+			setLicenseExpiry(time.Now().Add(7 * 24 * time.Hour))
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockStatus.AssertCalled(GinkgoT(), "ClearDegraded")
+
+			instance, err := GetCompliance(ctx, r.client, false, "notused")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status.State).To(Equal(operatorv1.TigeraStatusReady))
+			condition := meta.FindStatusCondition(instance.Status.Conditions, utils.LicenseExpiringConditionType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("LicenseExpiring"))
+		})
+
+		It("should reconcile again when the license enters the expiry warning period", func() {
+			setLicenseExpiry(time.Now().Add(60 * 24 * time.Hour))
+			result, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 46*24*time.Hour))
+
+			instance, err := GetCompliance(ctx, r.client, false, "notused")
+			Expect(err).NotTo(HaveOccurred())
+			condition := meta.FindStatusCondition(instance.Status.Conditions, utils.LicenseExpiringConditionType)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		})
+	})
+
 	Context("Reconcile for Condition status", func() {
 		generation := int64(2)
 
@@ -759,7 +862,7 @@ var _ = Describe("Compliance controller tests", func() {
 						{
 							Type:               operatorv1.ComponentDegraded,
 							Status:             operatorv1.ConditionTrue,
-							Reason:             string(operatorv1.ResourceUpdateError),
+							Reason:             string(operatorv1.ImageSetError),
 							Message:            "Error resolving ImageSet for components",
 							ObservedGeneration: generation,
 						},
@@ -791,7 +894,7 @@ var _ = Describe("Compliance controller tests", func() {
 
 			Expect(instance.Status.Conditions[2].Type).To(Equal("Degraded"))
 			Expect(string(instance.Status.Conditions[2].Status)).To(Equal(string(operatorv1.ConditionTrue)))
-			Expect(instance.Status.Conditions[2].Reason).To(Equal(string(operatorv1.ResourceUpdateError)))
+			Expect(instance.Status.Conditions[2].Reason).To(Equal(string(operatorv1.ImageSetError)))
 			Expect(instance.Status.Conditions[2].Message).To(Equal("Error resolving ImageSet for components"))
 			Expect(instance.Status.Conditions[2].ObservedGeneration).To(Equal(generation))
 		})
@@ -857,6 +960,315 @@ var _ = Describe("Compliance controller tests", func() {
 		})
 	})
 
+	Context("benchmarker profile", func() {
+		BeforeEach(func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cr)).NotTo(HaveOccurred())
+			cr.Spec.BenchmarkerProfileConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "hardened-cis"},
+				Key:                  "profile.yaml",
+			}
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		})
+
+		It("should degrade when the benchmarker profile ConfigMap does not exist", func() {
+			mockStatus.On("SetDegraded", operatorv1.ResourceNotFound, "Benchmarker profile ConfigMap hardened-cis not found", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceNotFound, "Benchmarker profile ConfigMap hardened-cis not found", mock.Anything, mock.Anything)
+		})
+
+		It("should degrade when the benchmarker profile ConfigMap has no such key", func() {
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "hardened-cis", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"other.yaml": "controls: []"},
+			})).NotTo(HaveOccurred())
+			mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Benchmarker profile ConfigMap hardened-cis has no key profile.yaml", mock.Anything, mock.Anything).Return()
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Benchmarker profile ConfigMap hardened-cis has no key profile.yaml", mock.Anything, mock.Anything)
+		})
+
+		It("should mount the benchmarker profile ConfigMap into the benchmarker", func() {
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "hardened-cis", Namespace: common.OperatorNamespace()},
+				Data:       map[string]string{"profile.yaml": "controls: []"},
+			})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			cm := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "hardened-cis", Namespace: render.ComplianceNamespace}}
+			Expect(test.GetResource(c, &cm)).To(BeNil())
+			Expect(cm.Data).To(Equal(map[string]string{"profile.yaml": "controls: []"}))
+
+			ds := appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceBenchmarkerName, Namespace: render.ComplianceNamespace}}
+			Expect(test.GetResource(c, &ds)).To(BeNil())
+			benchmarker := test.GetContainer(ds.Spec.Template.Spec.Containers, render.ComplianceBenchmarkerName)
+			Expect(benchmarker).NotTo(BeNil())
+			Expect(benchmarker.Env).To(ContainElement(corev1.EnvVar{Name: "BENCHMARK_PROFILE", Value: "/etc/tigera/benchmark/profile.yaml"}))
+		})
+	})
+
+	It("should degrade on an invalid snapshot schedule", func() {
+		Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cr)).NotTo(HaveOccurred())
+		cr.Spec.SnapshotSchedule = "every hour"
+		Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		mockStatus.On("SetDegraded", operatorv1.ResourceValidationError, "Failed to validate Compliance", mock.Anything, mock.Anything).Return()
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).ShouldNot(HaveOccurred())
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", operatorv1.ResourceValidationError, "Failed to validate Compliance", mock.Anything, mock.Anything)
+	})
+
+	Context("Prometheus metrics", func() {
+		BeforeEach(func() {
+			Expect(c.Get(ctx, client.ObjectKey{Name: "tigera-secure"}, cr)).NotTo(HaveOccurred())
+			enabled := operatorv1.ComplianceMetricsEnabled
+			cr.Spec.PrometheusMetrics = &enabled
+			Expect(c.Update(ctx, cr)).NotTo(HaveOccurred())
+		})
+
+		It("should create the compliance ServiceMonitors when the Monitor is installed", func() {
+			Expect(c.Create(ctx, &operatorv1.Monitor{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			for _, name := range []string{render.ComplianceControllerName, render.ComplianceSnapshotterName, render.ComplianceBenchmarkerName} {
+				sm := monitoringv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceMetricsServiceName(name), Namespace: common.TigeraPrometheusNamespace}}
+				Expect(test.GetResource(c, &sm)).To(BeNil())
+			}
+
+			// The controller key pair is also the server certificate of the metrics endpoint.
+			kp := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceControllerSecret, Namespace: common.OperatorNamespace()}}
+			Expect(test.GetResource(c, &kp)).To(BeNil())
+			cert, err := certificatemanagement.ParseCertificate(kp.Data[corev1.TLSCertKey])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert.DNSNames).To(ContainElement(render.ComplianceMetricsServiceName(render.ComplianceControllerName)))
+		})
+
+		It("should not create the compliance ServiceMonitors when the Monitor is not installed", func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			sm := monitoringv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: render.ComplianceMetricsServiceName(render.ComplianceControllerName), Namespace: common.TigeraPrometheusNamespace}}
+			Expect(errors.IsNotFound(test.GetResource(c, &sm))).To(BeTrue())
+		})
+	})
+
+	Context("Compliance spec validation", func() {
+		var instance *operatorv1.Compliance
+
+		BeforeEach(func() {
+			instance = &operatorv1.Compliance{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}}
+		})
+
+		It("should accept a default Compliance", func() {
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should accept a positive server worker pool size", func() {
+			poolSize := int32(4)
+			instance.Spec.ServerWorkerPoolSize = &poolSize
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should accept a server max request body size within the limit", func() {
+			size := resource.MustParse("10Mi")
+			instance.Spec.ServerMaxRequestBodySize = &size
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject an invalid server max request body size",
+			func(size string) {
+				q := resource.MustParse(size)
+				instance.Spec.ServerMaxRequestBodySize = &q
+				Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			},
+			Entry("zero", "0"),
+			Entry("negative", "-1Mi"),
+			Entry("fractional bytes", "0.5"),
+			Entry("above the maximum", "2Gi"),
+		)
+
+		It("should accept a positive server idle timeout", func() {
+			instance.Spec.ServerIdleTimeout = &metav1.Duration{Duration: 90 * time.Second}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a non-positive server idle timeout", func() {
+			instance.Spec.ServerIdleTimeout = &metav1.Duration{}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.ServerIdleTimeout = &metav1.Duration{Duration: -time.Second}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a positive snapshotter batch size and flush interval", func() {
+			batchSize := int32(100)
+			instance.Spec.SnapshotterBatchSize = &batchSize
+			instance.Spec.SnapshotterFlushInterval = &metav1.Duration{Duration: time.Minute}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject a non-positive snapshotter batch size", func() {
+			batchSize := int32(0)
+			instance.Spec.SnapshotterBatchSize = &batchSize
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a non-positive snapshotter flush interval", func() {
+			instance.Spec.SnapshotterFlushInterval = &metav1.Duration{}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a non-positive server worker pool size", func() {
+			poolSize := int32(0)
+			instance.Spec.ServerWorkerPoolSize = &poolSize
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a negative job backoff limit", func() {
+			limit := int32(-1)
+			instance.Spec.JobBackoffLimit = &limit
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			limit = 0
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should accept a time zone database name", func() {
+			instance.Spec.Timezone = "America/New_York"
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject an unknown time zone", func() {
+			instance.Spec.Timezone = "Mars/Olympus_Mons"
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.Timezone = "Local"
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept hosts, domains and CIDRs in the proxy bypass list", func() {
+			instance.Spec.NoProxy = []string{"es.example.com", ".example.com", "10.0.0.0/8"}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject empty proxy bypass entries and entries with commas or whitespace", func() {
+			instance.Spec.NoProxy = []string{""}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.NoProxy = []string{"a.example.com,b.example.com"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.NoProxy = []string{"a.example.com b.example.com"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept supported report output formats", func() {
+			instance.Spec.ReportOutputFormats = []operatorv1.ComplianceReportOutputFormat{operatorv1.ComplianceReportOutputFormatJSON, operatorv1.ComplianceReportOutputFormatCSV}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject unsupported or duplicate report output formats", func() {
+			instance.Spec.ReportOutputFormats = []operatorv1.ComplianceReportOutputFormat{"PDF"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.ReportOutputFormats = []operatorv1.ComplianceReportOutputFormat{operatorv1.ComplianceReportOutputFormatCSV, operatorv1.ComplianceReportOutputFormatCSV}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a report retention by age, by count or by both", func() {
+			maxReports := int32(10)
+			instance.Spec.ReportRetention = &operatorv1.ComplianceReportRetention{MaxAge: &metav1.Duration{Duration: 720 * time.Hour}}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.ReportRetention = &operatorv1.ComplianceReportRetention{MaxReports: &maxReports}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.ReportRetention.MaxAge = &metav1.Duration{Duration: 720 * time.Hour}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject an empty or non-positive report retention", func() {
+			maxReports := int32(0)
+			instance.Spec.ReportRetention = &operatorv1.ComplianceReportRetention{}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.ReportRetention = &operatorv1.ComplianceReportRetention{MaxAge: &metav1.Duration{Duration: -time.Hour}}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.ReportRetention = &operatorv1.ComplianceReportRetention{MaxReports: &maxReports}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject an incomplete benchmarker profile ConfigMap reference", func() {
+			instance.Spec.BenchmarkerProfileConfigMap = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "hardened-cis"},
+			}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.BenchmarkerProfileConfigMap = &corev1.ConfigMapKeySelector{Key: "profile.yaml"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should accept a benchmarker result output on a host path or a persistent volume claim", func() {
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/var/log/calico/benchmarks", HostPath: "/var/log/benchmarks"}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/var/log/calico/benchmarks", PersistentVolumeClaimName: "benchmark-results"}
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should reject an invalid benchmarker result output", func() {
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "benchmarks", HostPath: "/var/log/benchmarks"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/", HostPath: "/var/log/benchmarks"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/var/log/calico/benchmarks"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/var/log/calico/benchmarks", HostPath: "/var/log/benchmarks", PersistentVolumeClaimName: "benchmark-results"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			instance.Spec.BenchmarkerResultOutput = &operatorv1.ComplianceBenchmarkerResultOutput{Path: "/var/log/calico/benchmarks", PersistentVolumeClaimName: "Benchmark_Results"}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a compliance server PodDisruptionBudget while the server runs a single replica", func() {
+			maxUnavailable := intstr.FromInt(1)
+			instance.Spec.ComplianceServerPodDisruptionBudget = &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable}
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+		})
+
+		It("should reject a soft memory limit percentage outside of 1 to 100", func() {
+			percent := int32(0)
+			instance.Spec.SoftMemoryLimitPercent = &percent
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			percent = 101
+			Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			percent = 75
+			Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should accept a valid snapshot schedule",
+			func(schedule string) {
+				instance.Spec.SnapshotSchedule = schedule
+				Expect(validateComplianceResource(instance)).NotTo(HaveOccurred())
+			},
+			Entry("hourly", "0 * * * *"),
+			Entry("every 15 minutes during working hours", "*/15 9-17 * * 1-5"),
+			Entry("lists", "0,30 0,12 1,15 * *"),
+			Entry("Sunday as 7", "0 0 * * 7"),
+			Entry("descriptor", "@hourly"),
+		)
+
+		DescribeTable("should reject an invalid snapshot schedule",
+			func(schedule string) {
+				instance.Spec.SnapshotSchedule = schedule
+				Expect(validateComplianceResource(instance)).To(HaveOccurred())
+			},
+			Entry("too few fields", "0 * * *"),
+			Entry("too many fields", "0 0 * * * *"),
+			Entry("minute out of range", "60 * * * *"),
+			Entry("month out of range", "0 0 1 13 *"),
+			Entry("day of the month out of range", "0 0 0 * *"),
+			Entry("reversed range", "0 17-9 * * *"),
+			Entry("zero step", "*/0 * * * *"),
+			Entry("not a number", "0 noon * * *"),
+			Entry("unknown descriptor", "@fortnightly"),
+		)
+	})
+
 	Context("Multi-tenant/namespaced reconciliation", func() {
 		tenantANamespace := "tenant-a"
 		tenantBNamespace := "tenant-b"
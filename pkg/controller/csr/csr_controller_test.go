@@ -228,8 +228,11 @@ func validX509CR() *x509.CertificateRequest {
 	extKeyUsagesVal, err := asn1.Marshal(extKeyUsages)
 	Expect(err).NotTo(HaveOccurred())
 	return &x509.CertificateRequest{
-		Subject:            subj,
-		DNSNames:           []string{"prometheus-http-api", "prometheus-http-api.tigera-prometheus", "prometheus-http-api.tigera-prometheus.svc", "prometheus-http-api.tigera-prometheus.svc.cluster.local"},
+		Subject: subj,
+		DNSNames: []string{
+			"prometheus-http-api", "prometheus-http-api.tigera-prometheus", "prometheus-http-api.tigera-prometheus.svc", "prometheus-http-api.tigera-prometheus.svc.cluster.local",
+			"alertmanager-http-api", "alertmanager-http-api.tigera-prometheus", "alertmanager-http-api.tigera-prometheus.svc", "alertmanager-http-api.tigera-prometheus.svc.cluster.local",
+		},
 		IPAddresses:        []net.IP{net.ParseIP("1.2.3.4")},
 		SignatureAlgorithm: x509.SHA256WithRSA,
 		ExtraExtensions: []pkix.Extension{
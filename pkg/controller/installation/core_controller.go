@@ -30,6 +30,7 @@ import (
 
 	"github.com/go-logr/logr"
 	configv1 "github.com/openshift/api/config/v1"
+	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -180,19 +181,21 @@ func newReconciler(mgr manager.Manager, opts options.AddOptions) (*ReconcileInst
 	typhaScaler := newTyphaAutoscaler(cs, nodeIndexInformer, typhaListWatch, statusManager)
 
 	r := &ReconcileInstallation{
-		config:               mgr.GetConfig(),
-		client:               mgr.GetClient(),
-		scheme:               mgr.GetScheme(),
-		watches:              make(map[runtime.Object]struct{}),
-		autoDetectedProvider: opts.DetectedProvider,
-		status:               statusManager,
-		typhaAutoscaler:      typhaScaler,
-		namespaceMigration:   nm,
-		enterpriseCRDsExist:  opts.EnterpriseCRDExists,
-		clusterDomain:        opts.ClusterDomain,
-		manageCRDs:           opts.ManageCRDs,
-		usePSP:               opts.UsePSP,
-		tierWatchReady:       &utils.ReadyFlag{},
+		config:                mgr.GetConfig(),
+		client:                mgr.GetClient(),
+		scheme:                mgr.GetScheme(),
+		watches:               make(map[runtime.Object]struct{}),
+		autoDetectedProvider:  opts.DetectedProvider,
+		status:                statusManager,
+		typhaAutoscaler:       typhaScaler,
+		namespaceMigration:    nm,
+		enterpriseCRDsExist:   opts.EnterpriseCRDExists,
+		clusterDomain:         opts.ClusterDomain,
+		manageCRDs:            opts.ManageCRDs,
+		usePSP:                opts.UsePSP,
+		tierWatchReady:        &utils.ReadyFlag{},
+		operatorNetworkPolicy: opts.OperatorNetworkPolicy,
+		manageWebhookCert:     opts.ManageWebhookServerCert,
 	}
 	r.status.Run(opts.ShutdownContext)
 	r.typhaAutoscaler.start(opts.ShutdownContext)
@@ -237,6 +240,14 @@ func add(c ctrlruntime.Controller, r *ReconcileInstallation) error {
 		}
 	}
 
+	// Watch the endpoints of the kubernetes service, so that the operator's network policy follows the API server
+	// addresses.
+	if r.operatorNetworkPolicy {
+		if err = utils.AddNamespacedWatch(c, kubernetesServiceEndpoints(), &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("tigera-installation-controller failed to watch the kubernetes service endpoints: %w", err)
+		}
+	}
+
 	if err = utils.AddConfigMapWatch(c, active.ActiveConfigMapName, common.CalicoNamespace, &handler.EnqueueRequestForObject{}); err != nil {
 		return fmt.Errorf("tigera-installation-controller failed to watch ConfigMap %s: %w", active.ActiveConfigMapName, err)
 	}
@@ -312,6 +323,15 @@ func add(c ctrlruntime.Controller, r *ReconcileInstallation) error {
 		return fmt.Errorf("tigera-installation-controller failed to watch IPPool resource: %w", err)
 	}
 
+	if r.manageWebhookCert {
+		// Watch the webhook configurations so that the CA bundle is injected again if they are re-applied.
+		for _, obj := range []client.Object{&admregv1.ValidatingWebhookConfiguration{}, &admregv1.MutatingWebhookConfiguration{}} {
+			if err = c.WatchObject(obj, &handler.EnqueueRequestForObject{}); err != nil {
+				return fmt.Errorf("tigera-installation-controller failed to watch %T: %w", obj, err)
+			}
+		}
+	}
+
 	// Perform periodic reconciliation. This acts as a backstop to catch reconcile issues,
 	// and also makes sure we spot when things change that might not trigger a reconciliation.
 	err = utils.AddPeriodicReconcile(c, utils.PeriodicReconcileTime, &handler.EnqueueRequestForObject{})
@@ -365,6 +385,38 @@ type ReconcileInstallation struct {
 	manageCRDs           bool
 	usePSP               bool
 	tierWatchReady       *utils.ReadyFlag
+
+	// Whether the operator should render a NetworkPolicy for its own pod.
+	operatorNetworkPolicy bool
+
+	// Whether the operator should manage the certificate of its own webhook server.
+	manageWebhookCert bool
+}
+
+// kubernetesServiceEndpoints returns the Endpoints of the kubernetes service, which list the API server addresses.
+func kubernetesServiceEndpoints() *corev1.Endpoints {
+	return &corev1.Endpoints{
+		TypeMeta:   metav1.TypeMeta{Kind: "Endpoints", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: metav1.NamespaceDefault},
+	}
+}
+
+// getAPIServerAddresses returns the addresses of the endpoints of the kubernetes service.
+func getAPIServerAddresses(ctx context.Context, client client.Client) ([]string, error) {
+	endpoints := kubernetesServiceEndpoints()
+	if err := client.Get(ctx, types.NamespacedName{Name: endpoints.Name, Namespace: endpoints.Namespace}, endpoints); err != nil {
+		return nil, fmt.Errorf("unable to read the kubernetes service endpoints: %w", err)
+	}
+	var addresses []string
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			addresses = append(addresses, address.IP)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("the kubernetes service has no endpoint addresses")
+	}
+	return addresses, nil
 }
 
 // getActivePools returns the full set of enabled IP pools in the cluster.
@@ -433,6 +485,11 @@ func fillDefaults(instance *operator.Installation, currentPools *crdv1.IPPoolLis
 		// Make sure registry, except for the special case "UseDefault", always ends with a slash.
 		instance.Spec.Registry = fmt.Sprintf("%s/", instance.Spec.Registry)
 	}
+	for image, registry := range instance.Spec.RegistryOverrides {
+		if len(registry) != 0 && registry != components.UseDefault && !strings.HasSuffix(registry, "/") {
+			instance.Spec.RegistryOverrides[image] = fmt.Sprintf("%s/", registry)
+		}
+	}
 
 	if len(instance.Spec.Variant) == 0 {
 		// Default to installing Calico.
@@ -1188,6 +1245,35 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	// Issue the certificate for the operator's own webhook server. The operator pod is not managed by the operator, so
+	// the certificate cannot be provisioned with certificate management.
+	var webhookTLS certificatemanagement.KeyPairInterface
+	if r.manageWebhookCert && !installationMarkedForDeletion {
+		webhookTLS, err = certificateManager.GetOrCreateKeyPair(
+			r.client,
+			render.OperatorWebhookTLSSecretName,
+			common.OperatorNamespace(),
+			dns.GetServiceDNSNames(render.OperatorWebhookServiceName, common.OperatorNamespace(), r.clusterDomain))
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceReadError, "Error finding or creating TLS certificate for the operator webhook server", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if webhookTLS.UseCertificateManagement() {
+			reqLogger.Info("Certificate management is enabled, the operator webhook server certificate must be provided", "secret", render.OperatorWebhookTLSSecretName)
+			webhookTLS = nil
+		}
+	}
+
+	// The operator's network policy restricts its egress to the addresses of the API server.
+	var apiServerAddresses []string
+	if r.operatorNetworkPolicy && !installationMarkedForDeletion {
+		apiServerAddresses, err = getAPIServerAddresses(ctx, r.client)
+		if err != nil {
+			r.status.SetDegraded(operator.ResourceReadError, "Error reading the API server addresses for the operator network policy", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	nodeAppArmorProfile := ""
 	a := instance.GetObjectMeta().GetAnnotations()
 	if val, ok := a[techPreviewFeatureSeccompApparmor]; ok {
@@ -1203,6 +1289,19 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 	// Render namespaces for Calico.
 	components = append(components, render.Namespaces(namespaceCfg))
 
+	// Render (or remove) the NetworkPolicy for the operator's own pod.
+	components = append(components, render.OperatorNetworkPolicy(&render.OperatorNetworkPolicyConfiguration{
+		Installation:       &instance.Spec,
+		K8sServiceEp:       k8sapi.Endpoint,
+		APIServerAddresses: apiServerAddresses,
+		Enabled:            r.operatorNetworkPolicy && !installationMarkedForDeletion,
+	}))
+
+	// Render (or remove) the Service for the operator's own webhook server.
+	components = append(components, render.OperatorWebhook(&render.OperatorWebhookConfiguration{
+		Enabled: r.manageWebhookCert && !installationMarkedForDeletion,
+	}))
+
 	if newActiveCM != nil && !installationMarkedForDeletion {
 		log.Info("adding active configmap")
 		components = append(components, render.NewPassthrough(newActiveCM))
@@ -1247,6 +1346,7 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 				rcertificatemanagement.NewKeyPairOption(nodePrometheusTLS, true, true),
 				rcertificatemanagement.NewKeyPairOption(typhaNodeTLS.TyphaSecret, true, true),
 				rcertificatemanagement.NewKeyPairOption(kubeControllerTLS, true, true),
+				rcertificatemanagement.NewKeyPairOption(webhookTLS, true, false),
 			},
 			TrustedBundle: typhaNodeTLS.TrustedBundle,
 		}))
@@ -1346,7 +1446,7 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ValidateImageSet(imageSet); err != nil {
+	if err = imageset.ValidateImageSet(imageSet, instance.Spec.Variant); err != nil {
 		r.status.SetDegraded(operator.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
@@ -1365,6 +1465,13 @@ func (r *ReconcileInstallation) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	if webhookTLS != nil {
+		if err = injectWebhookCABundle(ctx, r.client, webhookCABundle(webhookTLS)); err != nil {
+			r.status.SetDegraded(operator.ResourceUpdateError, "Error injecting the CA bundle into the operator webhook configurations", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+	}
+
 	// TODO: We handle too many components in this controller at the moment. Once we are done consolidating,
 	// we can have the CreateOrUpdate logic handle this for us.
 	r.status.AddDaemonsets([]types.NamespacedName{{Name: common.NodeDaemonSetName, Namespace: common.CalicoNamespace}})
@@ -27,8 +27,10 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/mock"
 
+	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -122,6 +124,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(schedv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 			Expect(operator.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 			Expect(storagev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(netv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 			// Create a client that will have a crud interface of k8s objects.
 			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
@@ -157,6 +160,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			mockStatus.On("ClearDegraded")
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything)
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
@@ -319,21 +323,16 @@ var _ = Describe("Testing core-controller installation", func() {
 		})
 
 		It("should use images from imageset", func() {
-			imageSet := &operator.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operator.ImageSetSpec{
-					Images: []operator.Image{
-						{Image: "tigera/kube-controllers", Digest: "sha256:tigerakubecontrollerhash"},
-						{Image: "tigera/typha", Digest: "sha256:tigeratyphahash"},
-						{Image: "tigera/cnx-node", Digest: "sha256:tigeracnxnodehash"},
-						{Image: "tigera/cni", Digest: "sha256:tigeracnihash"},
-						{Image: "tigera/pod2daemon-flexvol", Digest: "sha256:calicoflexvolhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
-						{Image: "tigera/csi", Digest: "sha256:calicocsihash"},
-						{Image: "tigera/node-driver-registrar", Digest: "sha256:caliconodedriverregistrarhash"},
-					},
-				},
-			}
+			imageSet := test.CompleteImageSet(operator.TigeraSecureEnterprise,
+				operator.Image{Image: "tigera/kube-controllers", Digest: "sha256:tigerakubecontrollerhash"},
+				operator.Image{Image: "tigera/typha", Digest: "sha256:tigeratyphahash"},
+				operator.Image{Image: "tigera/cnx-node", Digest: "sha256:tigeracnxnodehash"},
+				operator.Image{Image: "tigera/cni", Digest: "sha256:tigeracnihash"},
+				operator.Image{Image: "tigera/pod2daemon-flexvol", Digest: "sha256:calicoflexvolhash"},
+				operator.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
+				operator.Image{Image: "tigera/csi", Digest: "sha256:calicocsihash"},
+				operator.Image{Image: "tigera/node-driver-registrar", Digest: "sha256:caliconodedriverregistrarhash"},
+			)
 			Expect(c.Create(ctx, imageSet)).ToNot(HaveOccurred())
 
 			_, err := r.Reconcile(ctx, reconcile.Request{})
@@ -529,6 +528,8 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(schedv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 			Expect(operator.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 			Expect(storagev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(netv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(admregv1.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 			// Create a client that will have a crud interface of k8s objects.
 			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
@@ -566,6 +567,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			mockStatus.On("ClearDegraded")
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything)
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
@@ -707,6 +709,75 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(test.GetResource(c, typhaSecret)).To(BeNil())
 			Expect(typhaSecret.GetOwnerReferences()).To(HaveLen(0))
 		})
+
+		It("should render the operator network policy only when enabled", func() {
+			policy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: render.OperatorNetworkPolicyName, Namespace: common.OperatorNamespace()}}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(HaveOccurred())
+
+			r.operatorNetworkPolicy = true
+			mockStatus.On("SetDegraded", operator.ResourceReadError, "Error reading the API server addresses for the operator network policy", mock.Anything, mock.Anything).Return().Once()
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).Should(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(HaveOccurred())
+
+			By("restricting the API server egress to the addresses of the kubernetes service endpoints")
+			Expect(c.Create(ctx, &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: metav1.NamespaceDefault},
+				Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "172.16.0.10"}}}},
+			})).NotTo(HaveOccurred())
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).NotTo(HaveOccurred())
+			Expect(policy.Spec.PolicyTypes).To(ConsistOf(netv1.PolicyTypeEgress))
+			Expect(policy.Spec.Egress[1].To).To(ContainElement(netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "172.16.0.10/32"}}))
+
+			r.operatorNetworkPolicy = false
+			_, err = r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(HaveOccurred())
+		})
+
+		It("should issue the webhook server certificate and inject the CA bundle when enabled", func() {
+			otherWebhookCABundle := []byte("other")
+			vwc := &admregv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: render.OperatorWebhookConfigurationName},
+				Webhooks: []admregv1.ValidatingWebhook{
+					{
+						Name: "validate.operator.tigera.io",
+						ClientConfig: admregv1.WebhookClientConfig{
+							Service: &admregv1.ServiceReference{Name: render.OperatorWebhookServiceName, Namespace: common.OperatorNamespace()},
+						},
+					},
+					{
+						Name: "validate.other.example.com",
+						ClientConfig: admregv1.WebhookClientConfig{
+							Service:  &admregv1.ServiceReference{Name: "other", Namespace: "other"},
+							CABundle: otherWebhookCABundle,
+						},
+					},
+				},
+			}
+			Expect(c.Create(ctx, vwc)).NotTo(HaveOccurred())
+
+			r.manageWebhookCert = true
+			_, err := r.Reconcile(ctx, reconcile.Request{})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: render.OperatorWebhookTLSSecretName, Namespace: common.OperatorNamespace()}, secret)).NotTo(HaveOccurred())
+			test.VerifyCert(secret, dns.GetServiceDNSNames(render.OperatorWebhookServiceName, common.OperatorNamespace(), dns.DefaultClusterDomain)...)
+
+			service := &corev1.Service{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: render.OperatorWebhookServiceName, Namespace: common.OperatorNamespace()}, service)).NotTo(HaveOccurred())
+			Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(render.OperatorWebhookPort))
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(vwc), vwc)).NotTo(HaveOccurred())
+			Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(certificateManager.KeyPair().GetCertificatePEM()))
+			Expect(vwc.Webhooks[1].ClientConfig.CABundle).To(Equal(otherWebhookCABundle))
+		})
 	})
 
 	Context("Reconcile tests", func() {
@@ -735,6 +806,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(schedv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 			Expect(operator.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 			Expect(storagev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(netv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 			// Create a client that will have a crud interface of k8s objects.
 			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
@@ -783,6 +855,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			mockStatus.On("OnCRFound").Return()
 			mockStatus.On("ClearDegraded")
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
@@ -1455,6 +1528,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			Expect(schedv1.SchemeBuilder.AddToScheme(scheme)).ShouldNot(HaveOccurred())
 			Expect(operator.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 			Expect(storagev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+			Expect(netv1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
 
 			// Create a client that will have a crud interface of k8s objects.
 			c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
@@ -1492,6 +1566,7 @@ var _ = Describe("Testing core-controller installation", func() {
 			mockStatus.On("ClearDegraded")
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything)
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
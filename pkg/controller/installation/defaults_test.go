@@ -348,6 +348,26 @@ var _ = Describe("Defaulting logic tests", func() {
 		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
 	})
 
+	It("should correct missing slashes on registry overrides", func() {
+		instance := &operator.Installation{
+			Spec: operator.InstallationSpec{
+				RegistryOverrides: map[string]string{
+					"calico/node":  "test-reg",
+					"calico/typha": "other-reg/",
+					"calico/cni":   components.UseDefault,
+				},
+			},
+		}
+		err := fillDefaults(instance, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.Spec.RegistryOverrides).To(Equal(map[string]string{
+			"calico/node":  "test-reg/",
+			"calico/typha": "other-reg/",
+			"calico/cni":   components.UseDefault,
+		}))
+		Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+	})
+
 	It("should properly fill defaults for an IPv6-only instance", func() {
 		instance := &operator.Installation{
 			Spec: operator.InstallationSpec{
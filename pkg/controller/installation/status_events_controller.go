@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/options"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+// AddStatusEventsController adds a controller that mirrors the condition transitions of every TigeraStatus as Events on
+// the Installation, so that the state changes of all components can be followed in a single stream.
+func AddStatusEventsController(mgr manager.Manager, opts options.AddOptions) error {
+	r := NewStatusEvents(mgr.GetClient(), mgr.GetEventRecorderFor("tigera-status-events"))
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tigera-status-events-controller").
+		For(&operatorv1.TigeraStatus{}).
+		Complete(r)
+}
+
+var _ reconcile.Reconciler = &StatusEvents{}
+
+// StatusEvents implements a controller that records an Event on the Installation each time a condition of a
+// TigeraStatus changes. It runs a single worker, so the conditions it has seen need no locking.
+type StatusEvents struct {
+	client   client.Client
+	recorder record.EventRecorder
+
+	// seen holds the conditions of each TigeraStatus, by name, as of the last reconcile.
+	seen map[string][]metav1.Condition
+}
+
+func NewStatusEvents(cli client.Client, recorder record.EventRecorder) *StatusEvents {
+	return &StatusEvents{client: cli, recorder: recorder, seen: map[string][]metav1.Condition{}}
+}
+
+func (r *StatusEvents) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ts := &operatorv1.TigeraStatus{}
+	if err := r.client.Get(ctx, request.NamespacedName, ts); err != nil {
+		if apierrors.IsNotFound(err) {
+			delete(r.seen, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	previous, seen := r.seen[ts.Name]
+	current := status.UpdateStatusCondition(copyConditions(previous), ts.Status.Conditions)
+	transitions := conditionTransitions(previous, current, seen)
+	if len(transitions) == 0 {
+		r.seen[ts.Name] = current
+		return reconcile.Result{}, nil
+	}
+
+	installation := &operatorv1.Installation{}
+	if err := r.client.Get(ctx, utils.DefaultInstanceKey, installation); err != nil {
+		if apierrors.IsNotFound(err) {
+			// There is nothing to record the Events on.
+			r.seen[ts.Name] = current
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	for _, c := range transitions {
+		eventType := corev1.EventTypeNormal
+		if c.Type == string(operatorv1.ComponentDegraded) && c.Status == metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		msg := fmt.Sprintf("%s is %s=%s", ts.Name, c.Type, c.Status)
+		if c.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, c.Message)
+		}
+		r.recorder.Event(installation, eventType, c.Reason, msg)
+	}
+	r.seen[ts.Name] = current
+	return reconcile.Result{}, nil
+}
+
+// conditionTransitions returns the conditions of current whose status differs from the one in previous. When the
+// TigeraStatus was not seen before, for example after the operator restarts, only a Degraded condition is reported, so
+// that a restart does not repeat the Events of every healthy component.
+func conditionTransitions(previous, current []metav1.Condition, seen bool) []metav1.Condition {
+	var transitions []metav1.Condition
+	for _, c := range current {
+		if !seen {
+			if c.Type == string(operatorv1.ComponentDegraded) && c.Status == metav1.ConditionTrue {
+				transitions = append(transitions, c)
+			}
+			continue
+		}
+		if p := findCondition(previous, c.Type); p == nil || p.Status != c.Status {
+			transitions = append(transitions, c)
+		}
+	}
+	return transitions
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func copyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	return append([]metav1.Condition{}, conditions...)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("TigeraStatus events controller", func() {
+	var (
+		ctx      context.Context
+		cli      client.Client
+		recorder *record.FakeRecorder
+		r        *StatusEvents
+		ts       *operator.TigeraStatus
+	)
+
+	setConditions := func(available, degraded operator.ConditionStatus, reason, message string) {
+		ts.Status.Conditions = []operator.TigeraStatusCondition{
+			{Type: operator.ComponentAvailable, Status: available, Reason: string(operator.AllObjectsAvailable)},
+			{Type: operator.ComponentProgressing, Status: operator.ConditionFalse, Reason: string(operator.NotApplicable)},
+			{Type: operator.ComponentDegraded, Status: degraded, Reason: reason, Message: message},
+		}
+		Expect(cli.Status().Update(ctx, ts)).NotTo(HaveOccurred())
+	}
+	reconcileStatus := func() {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "apiserver"}})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		recorder = record.NewFakeRecorder(10)
+		r = NewStatusEvents(cli, recorder)
+
+		Expect(cli.Create(ctx, &operator.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		ts = &operator.TigeraStatus{ObjectMeta: metav1.ObjectMeta{Name: "apiserver"}}
+		Expect(cli.Create(ctx, ts)).NotTo(HaveOccurred())
+		setConditions(operator.ConditionTrue, operator.ConditionFalse, string(operator.NotApplicable), "")
+	})
+
+	It("should record an Installation event when a component becomes degraded", func() {
+		reconcileStatus()
+		Expect(recorder.Events).To(BeEmpty())
+
+		setConditions(operator.ConditionFalse, operator.ConditionTrue, string(operator.ResourceReadError), "Error querying APIServer")
+		reconcileStatus()
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(recorder.Events).To(Receive(Equal("Normal AllObjectsAvailable apiserver is Ready=False")))
+		Expect(recorder.Events).To(Receive(Equal("Warning ResourceReadError apiserver is Degraded=True: Error querying APIServer")))
+
+		By("not recording the same conditions again")
+		reconcileStatus()
+		Expect(recorder.Events).To(BeEmpty())
+
+		By("recording the recovery")
+		setConditions(operator.ConditionTrue, operator.ConditionFalse, string(operator.NotApplicable), "")
+		reconcileStatus()
+		Expect(recorder.Events).To(HaveLen(2))
+	})
+
+	It("should only record a degraded component the first time it sees its TigeraStatus", func() {
+		setConditions(operator.ConditionFalse, operator.ConditionTrue, string(operator.ResourceReadError), "Error querying APIServer")
+		reconcileStatus()
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(recorder.Events).To(Receive(Equal("Warning ResourceReadError apiserver is Degraded=True: Error querying APIServer")))
+	})
+
+	It("should not record events without an Installation", func() {
+		Expect(cli.Delete(ctx, &operator.Installation{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+		setConditions(operator.ConditionFalse, operator.ConditionTrue, string(operator.ResourceReadError), "Error querying APIServer")
+		reconcileStatus()
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
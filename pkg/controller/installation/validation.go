@@ -22,17 +22,20 @@ import (
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/common/k8svalidation"
 	"github.com/tigera/operator/pkg/common/validation"
 	node "github.com/tigera/operator/pkg/common/validation/calico-node"
 	csinodedriver "github.com/tigera/operator/pkg/common/validation/csi-node-driver"
 	kubecontrollers "github.com/tigera/operator/pkg/common/validation/kube-controllers"
 	typha "github.com/tigera/operator/pkg/common/validation/typha"
+	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	"github.com/tigera/operator/pkg/controller/utils"
 	"github.com/tigera/operator/pkg/render"
 	appsv1 "k8s.io/api/apps/v1"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // validateCustomResource validates that the given custom resource is correct. This
@@ -382,6 +385,22 @@ func validateCustomResource(instance *operatorv1.Installation) error {
 		}
 	}
 
+	for image, registry := range instance.Spec.RegistryOverrides {
+		if _, ok := components.GetComponentByImage(image); !ok {
+			return fmt.Errorf("Installation spec.RegistryOverrides contains the unknown image %q", image)
+		}
+		if registry == "" {
+			return fmt.Errorf("Installation spec.RegistryOverrides has an empty registry for image %q", image)
+		}
+	}
+
+	if errs := k8svalidation.ValidateLabels(instance.Spec.AdditionalLabels, field.NewPath("spec", "additionalLabels")); errs.ToAggregate() != nil {
+		return fmt.Errorf("Installation spec.AdditionalLabels is invalid: %w", errs.ToAggregate())
+	}
+	if errs := k8svalidation.ValidateAnnotations(instance.Spec.AdditionalAnnotations, field.NewPath("spec", "additionalAnnotations")); errs.ToAggregate() != nil {
+		return fmt.Errorf("Installation spec.AdditionalAnnotations is invalid: %w", errs.ToAggregate())
+	}
+
 	// Verify CNILogging to not exist for non-calico cni
 	if cni := instance.Spec.CNI.Type; cni != operatorv1.PluginCalico {
 		if instance.Spec.Logging != nil && instance.Spec.Logging.CNI != nil {
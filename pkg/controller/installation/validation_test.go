@@ -1055,6 +1055,44 @@ var _ = Describe("Installation validation tests", func() {
 			})
 		})
 	})
+	Describe("validate RegistryOverrides", func() {
+		It("should not return an error for overrides of known images", func() {
+			instance.Spec.RegistryOverrides = map[string]string{
+				"calico/node":     "mirror.example.com/",
+				"tigera/cnx-node": "UseDefault",
+			}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error for an override of an unknown image", func() {
+			instance.Spec.RegistryOverrides = map[string]string{"calico/unknown": "mirror.example.com/"}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("unknown image \"calico/unknown\"")))
+		})
+
+		It("should return an error for an override with an empty registry", func() {
+			instance.Spec.RegistryOverrides = map[string]string{"calico/node": ""}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("empty registry")))
+		})
+	})
+
+	Describe("validate AdditionalLabels and AdditionalAnnotations", func() {
+		It("should not return an error for valid labels and annotations", func() {
+			instance.Spec.AdditionalLabels = map[string]string{"gitops.example.com/owner": "platform"}
+			instance.Spec.AdditionalAnnotations = map[string]string{"gitops.example.com/source": "https://git.example.com/cluster-config"}
+			Expect(validateCustomResource(instance)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error for an invalid label", func() {
+			instance.Spec.AdditionalLabels = map[string]string{"gitops.example.com/owner": "not a valid value"}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("spec.AdditionalLabels is invalid")))
+		})
+
+		It("should return an error for an invalid annotation key", func() {
+			instance.Spec.AdditionalAnnotations = map[string]string{"not a valid key": "value"}
+			Expect(validateCustomResource(instance)).To(MatchError(ContainSubstring("spec.AdditionalAnnotations is invalid")))
+		})
+	})
+
 	Describe("validate CSIDaemonset", func() {
 		It("should return nil when it is empty", func() {
 			instance.Spec.CSINodeDriverDaemonSet = &operator.CSINodeDriverDaemonSet{}
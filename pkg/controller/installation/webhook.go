@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	admregv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/render"
+	"github.com/tigera/operator/pkg/tls/certificatemanagement"
+)
+
+// webhookCABundle returns the CA bundle that the API server uses to verify the operator's webhook server. The issuer
+// is preferred over the certificate itself, so that the bundle remains valid while a re-issued certificate is
+// propagated to the operator pod.
+func webhookCABundle(keyPair certificatemanagement.KeyPairInterface) []byte {
+	if issuer := keyPair.GetIssuer(); issuer != nil {
+		return issuer.GetCertificatePEM()
+	}
+	return keyPair.GetCertificatePEM()
+}
+
+// isOperatorWebhook returns true if the client config points at the operator's webhook Service.
+func isOperatorWebhook(cc admregv1.WebhookClientConfig) bool {
+	return cc.Service != nil && cc.Service.Namespace == common.OperatorNamespace() && cc.Service.Name == render.OperatorWebhookServiceName
+}
+
+// injectWebhookCABundle sets the CABundle of the webhooks that are served by the operator in the operator's
+// ValidatingWebhookConfiguration and MutatingWebhookConfiguration. The webhook configurations are not created by the
+// operator, so they are patched rather than rendered, and missing configurations are ignored.
+func injectWebhookCABundle(ctx context.Context, cli client.Client, caBundle []byte) error {
+	key := types.NamespacedName{Name: render.OperatorWebhookConfigurationName}
+
+	vwc := &admregv1.ValidatingWebhookConfiguration{}
+	if err := cli.Get(ctx, key, vwc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to read ValidatingWebhookConfiguration %s: %w", key.Name, err)
+		}
+	} else {
+		patchFrom := client.MergeFrom(vwc.DeepCopy())
+		var changed bool
+		for i := range vwc.Webhooks {
+			if isOperatorWebhook(vwc.Webhooks[i].ClientConfig) && !bytes.Equal(vwc.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				vwc.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if changed {
+			if err := cli.Patch(ctx, vwc, patchFrom); err != nil {
+				return fmt.Errorf("failed to update ValidatingWebhookConfiguration %s: %w", key.Name, err)
+			}
+		}
+	}
+
+	mwc := &admregv1.MutatingWebhookConfiguration{}
+	if err := cli.Get(ctx, key, mwc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to read MutatingWebhookConfiguration %s: %w", key.Name, err)
+		}
+	} else {
+		patchFrom := client.MergeFrom(mwc.DeepCopy())
+		var changed bool
+		for i := range mwc.Webhooks {
+			if isOperatorWebhook(mwc.Webhooks[i].ClientConfig) && !bytes.Equal(mwc.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				mwc.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if changed {
+			if err := cli.Patch(ctx, mwc, patchFrom); err != nil {
+				return fmt.Errorf("failed to update MutatingWebhookConfiguration %s: %w", key.Name, err)
+			}
+		}
+	}
+	return nil
+}
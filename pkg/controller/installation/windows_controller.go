@@ -398,7 +398,7 @@ func (r *ReconcileWindows) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ValidateImageSet(imageSet); err != nil {
+	if err = imageset.ValidateImageSet(imageSet, instance.Spec.Variant); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
@@ -83,6 +83,7 @@ var _ = Describe("windows-controller installation tests", func() {
 			mockStatus.On("OnCRFound").Return()
 			mockStatus.On("ClearDegraded")
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
@@ -584,6 +585,7 @@ var _ = Describe("windows-controller installation tests", func() {
 					mockStatus.On("ClearDegraded")
 					mockStatus.On("AddCertificateSigningRequests", mock.Anything)
 					mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+					mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 					mockStatus.On("ReadyToMonitor")
 					mockStatus.On("SetMetaData", mock.Anything).Return()
 					mockStatus.On("SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
@@ -702,27 +704,23 @@ var _ = Describe("windows-controller installation tests", func() {
 					}
 				})
 				It("should use images from imageset", func() {
-					imageSet := &operator.ImageSet{
-						ObjectMeta: metav1.ObjectMeta{Name: "calico-" + components.CalicoRelease},
-						Spec: operator.ImageSetSpec{
-							Images: []operator.Image{
-								{Image: "calico/kube-controllers", Digest: "sha256:tigerakubecontrollerhash"},
-								{Image: "calico/typha", Digest: "sha256:tigeratyphahash"},
-								{Image: "calico/node", Digest: "sha256:tigeracnxnodehash"},
-								{Image: "calico/cni", Digest: "sha256:tigeracnihash"},
-								{Image: "calico/pod2daemon-flexvol", Digest: "sha256:calicoflexvolhash"},
-								{Image: "calico/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
-								{Image: "calico/csi", Digest: "sha256:calicocsihash"},
-								{Image: "calico/node-driver-registrar", Digest: "sha256:caliconodedriverregistrarhash"},
-							},
-						},
+					images := []operator.Image{
+						{Image: "calico/kube-controllers", Digest: "sha256:tigerakubecontrollerhash"},
+						{Image: "calico/typha", Digest: "sha256:tigeratyphahash"},
+						{Image: "calico/node", Digest: "sha256:tigeracnxnodehash"},
+						{Image: "calico/cni", Digest: "sha256:tigeracnihash"},
+						{Image: "calico/pod2daemon-flexvol", Digest: "sha256:calicoflexvolhash"},
+						{Image: "calico/key-cert-provisioner", Digest: "sha256:calicocsrinithash"},
+						{Image: "calico/csi", Digest: "sha256:calicocsihash"},
+						{Image: "calico/node-driver-registrar", Digest: "sha256:caliconodedriverregistrarhash"},
 					}
 					if enableWindows {
-						imageSet.Spec.Images = append(imageSet.Spec.Images, []operator.Image{
+						images = append(images, []operator.Image{
 							{Image: "calico/node-windows", Digest: "sha256:tigeracnxnodewindowshash"},
 							{Image: "calico/cni-windows", Digest: "sha256:tigeracniwindowshash"},
 						}...)
 					}
+					imageSet := test.CompleteImageSet(operator.Calico, images...)
 					Expect(c.Create(ctx, imageSet)).ToNot(HaveOccurred())
 
 					_, err := r.Reconcile(ctx, reconcile.Request{})
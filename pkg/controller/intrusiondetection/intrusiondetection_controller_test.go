@@ -254,17 +254,12 @@ var _ = Describe("IntrusionDetection controller tests", func() {
 		})
 
 		It("should use images from imageset", func() {
-			Expect(c.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/intrusion-detection-controller", Digest: "sha256:intrusiondetectioncontrollerhash"},
-						{Image: "tigera/deep-packet-inspection", Digest: "sha256:deeppacketinspectionhash"},
-						{Image: "tigera/webhooks-processor", Digest: "sha256:webhooksprocessorhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/intrusion-detection-controller", Digest: "sha256:intrusiondetectioncontrollerhash"},
+				operatorv1.Image{Image: "tigera/deep-packet-inspection", Digest: "sha256:deeppacketinspectionhash"},
+				operatorv1.Image{Image: "tigera/webhooks-processor", Digest: "sha256:webhooksprocessorhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
@@ -75,6 +75,7 @@ var _ = Describe("LogCollector controller tests", func() {
 		mockStatus.On("AddCronJobs", mock.Anything)
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
 		mockStatus.On("AddCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 		mockStatus.On("IsAvailable").Return(true)
 		mockStatus.On("OnCRFound").Return()
 		mockStatus.On("ClearDegraded")
@@ -177,16 +178,11 @@ var _ = Describe("LogCollector controller tests", func() {
 					components.ComponentFluentd.Version)))
 		})
 		It("should use images from imageset", func() {
-			Expect(c.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/fluentd", Digest: "sha256:fluentdhash"},
-						{Image: "tigera/fluentd-windows", Digest: "sha256:fluentdwindowshash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/fluentd", Digest: "sha256:fluentdhash"},
+				operatorv1.Image{Image: "tigera/fluentd-windows", Digest: "sha256:fluentdwindowshash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			Expect(c.Create(ctx, &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
@@ -156,6 +156,7 @@ var _ = Describe("LogStorage Dashboards controller", func() {
 			mockStatus.On("AddDeployments", mock.Anything)
 			mockStatus.On("AddStatefulSets", mock.Anything)
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 			mockStatus.On("AddCronJobs", mock.Anything)
 			mockStatus.On("OnCRFound").Return()
 			mockStatus.On("ReadyToMonitor")
@@ -239,15 +240,10 @@ var _ = Describe("LogStorage Dashboards controller", func() {
 		})
 
 		It("should use images from ImageSet", func() {
-			Expect(cli.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/intrusion-detection-job-installer", Digest: "sha256:dashboardhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(cli.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/intrusion-detection-job-installer", Digest: "sha256:dashboardhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			// Reconcile the resources
 			result, err := r.Reconcile(ctx, reconcile.Request{})
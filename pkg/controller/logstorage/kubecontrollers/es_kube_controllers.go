@@ -385,7 +385,7 @@ func (r *ESKubeControllersController) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
-	if err = imageset.ValidateImageSet(imageSet); err != nil {
+	if err = imageset.ValidateImageSet(imageSet, variant); err != nil {
 		r.status.SetDegraded(operatorv1.ResourceValidationError, "Error validating ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
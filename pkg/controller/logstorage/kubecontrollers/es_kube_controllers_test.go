@@ -154,6 +154,7 @@ var _ = Describe("LogStorage ES kube-controllers controller", func() {
 		mockStatus.On("AddDeployments", mock.Anything)
 		mockStatus.On("AddStatefulSets", mock.Anything)
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 		mockStatus.On("AddCronJobs", mock.Anything)
 		mockStatus.On("OnCRFound").Return()
 		mockStatus.On("ReadyToMonitor")
@@ -248,21 +249,16 @@ var _ = Describe("LogStorage ES kube-controllers controller", func() {
 	})
 
 	It("should use images from ImageSet", func() {
-		Expect(cli.Create(ctx, &operatorv1.ImageSet{
-			ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-			Spec: operatorv1.ImageSetSpec{
-				Images: []operatorv1.Image{
-					{Image: "tigera/elasticsearch", Digest: "sha256:elasticsearchhash"},
-					{Image: "tigera/kube-controllers", Digest: "sha256:kubecontrollershash"},
-					{Image: "tigera/kibana", Digest: "sha256:kibanahash"},
-					{Image: "tigera/eck-operator", Digest: "sha256:eckoperatorhash"},
-					{Image: "tigera/elasticsearch-metrics", Digest: "sha256:esmetricshash"},
-					{Image: "tigera/es-gateway", Digest: "sha256:esgatewayhash"},
-					{Image: "tigera/linseed", Digest: "sha256:linseedhash"},
-					{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-				},
-			},
-		})).ToNot(HaveOccurred())
+		Expect(cli.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+			operatorv1.Image{Image: "tigera/elasticsearch", Digest: "sha256:elasticsearchhash"},
+			operatorv1.Image{Image: "tigera/kube-controllers", Digest: "sha256:kubecontrollershash"},
+			operatorv1.Image{Image: "tigera/kibana", Digest: "sha256:kibanahash"},
+			operatorv1.Image{Image: "tigera/eck-operator", Digest: "sha256:eckoperatorhash"},
+			operatorv1.Image{Image: "tigera/elasticsearch-metrics", Digest: "sha256:esmetricshash"},
+			operatorv1.Image{Image: "tigera/es-gateway", Digest: "sha256:esgatewayhash"},
+			operatorv1.Image{Image: "tigera/linseed", Digest: "sha256:linseedhash"},
+			operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+		))).ToNot(HaveOccurred())
 
 		// Run the reconciler.
 		result, err := r.Reconcile(ctx, reconcile.Request{})
@@ -170,6 +170,7 @@ var _ = Describe("LogStorage Secrets controller", func() {
 		mockStatus.On("AddDeployments", mock.Anything)
 		mockStatus.On("AddStatefulSets", mock.Anything)
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 		mockStatus.On("AddCronJobs", mock.Anything)
 		mockStatus.On("OnCRFound").Return()
 		mockStatus.On("ReadyToMonitor")
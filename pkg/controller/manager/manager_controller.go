@@ -144,6 +144,9 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	if err = c.WatchObject(&operatorv1.ImageSet{}, eventHandler); err != nil {
 		return fmt.Errorf("manager-controller failed to watch ImageSet: %w", err)
 	}
+	if err = c.WatchObject(&operatorv1.Monitor{}, eventHandler); err != nil {
+		return fmt.Errorf("manager-controller failed to watch Monitor: %w", err)
+	}
 	if opts.MultiTenant {
 		if err = c.WatchObject(&operatorv1.Tenant{}, &handler.EnqueueRequestForObject{}); err != nil {
 			return fmt.Errorf("manager-controller failed to watch Tenant resource: %w", err)
@@ -399,6 +402,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 	// certificates, in case the user has provided their own cert in lieu of the default certificate.
 
 	var trustedSecretNames []string
+	var prometheusRoutePrefix string
 	if !r.multiTenant {
 		// For multi-tenant systems, we don't support user-provided certs for all components. So, we don't need to include these,
 		// and the bundle will simply use the root CA for the tenant. For single-tenant systems, we need to include these in case
@@ -429,6 +433,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 		if monitorCR.Spec.ExternalPrometheus == nil {
 			trustedSecretNames = append(trustedSecretNames, monitor.PrometheusServerTLSSecretName)
 		}
+		prometheusRoutePrefix = monitor.PrometheusRoutePrefix(monitorCR.Spec)
 
 		if complianceLicenseFeatureActive && complianceCR != nil {
 			// Check that compliance is running.
@@ -694,6 +699,7 @@ func (r *ReconcileManager) Reconcile(ctx context.Context, request reconcile.Requ
 		ExternalElastic:         r.elasticExternal,
 		BindingNamespaces:       namespaces,
 		Manager:                 instance,
+		PrometheusRoutePrefix:   prometheusRoutePrefix,
 	}
 
 	// Render the desired objects from the CRD and create or update them.
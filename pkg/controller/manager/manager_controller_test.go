@@ -140,6 +140,7 @@ var _ = Describe("Manager controller tests", func() {
 			mockStatus.On("AddStatefulSets", mock.Anything).Return()
 			mockStatus.On("AddCertificateSigningRequests", mock.Anything).Return()
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 			mockStatus.On("AddCronJobs", mock.Anything)
 			mockStatus.On("IsAvailable").Return(true)
 			mockStatus.On("OnCRFound").Return()
@@ -450,6 +451,7 @@ var _ = Describe("Manager controller tests", func() {
 			mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for secret 'tigera-secure-linseed-cert' to become available", mock.Anything, mock.Anything).Return().Maybe()
 			mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Waiting for secret internal-manager-tls in namespace tigera-operator to be available", mock.Anything, mock.Anything).Return().Maybe()
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 
@@ -566,6 +568,7 @@ var _ = Describe("Manager controller tests", func() {
 		Context("image reconciliation", func() {
 			It("should use builtin images", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 				_, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
 
@@ -599,17 +602,13 @@ var _ = Describe("Manager controller tests", func() {
 			})
 			It("should use images from imageset", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
-				Expect(c.Create(ctx, &operatorv1.ImageSet{
-					ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-					Spec: operatorv1.ImageSetSpec{
-						Images: []operatorv1.Image{
-							{Image: "tigera/cnx-manager", Digest: "sha256:cnxmanagerhash"},
-							{Image: "tigera/es-proxy", Digest: "sha256:esproxyhash"},
-							{Image: "tigera/voltron", Digest: "sha256:voltronhash"},
-							{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-						},
-					},
-				})).ToNot(HaveOccurred())
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
+				Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+					operatorv1.Image{Image: "tigera/cnx-manager", Digest: "sha256:cnxmanagerhash"},
+					operatorv1.Image{Image: "tigera/es-proxy", Digest: "sha256:esproxyhash"},
+					operatorv1.Image{Image: "tigera/voltron", Digest: "sha256:voltronhash"},
+					operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+				))).ToNot(HaveOccurred())
 
 				_, err := r.Reconcile(ctx, reconcile.Request{})
 				Expect(err).ShouldNot(HaveOccurred())
@@ -710,6 +709,7 @@ var _ = Describe("Manager controller tests", func() {
 				mockStatus.On("ClearDegraded")
 				mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, "Compliance is not ready", mock.Anything, mock.Anything).Return().Maybe()
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 				mockStatus.On("ReadyToMonitor")
 				mockStatus.On("SetMetaData", mock.Anything).Return()
 				r.status = mockStatus
@@ -738,6 +738,7 @@ var _ = Describe("Manager controller tests", func() {
 			generation := int64(2)
 			It("should reconcile with creating new status condition with one item", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 				ts := &operatorv1.TigeraStatus{
 					ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 					Spec:       operatorv1.TigeraStatusSpec{},
@@ -772,6 +773,7 @@ var _ = Describe("Manager controller tests", func() {
 			})
 			It("should reconcile with empty tigerastatus conditions ", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 				ts := &operatorv1.TigeraStatus{
 					ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 					Spec:       operatorv1.TigeraStatusSpec{},
@@ -792,6 +794,7 @@ var _ = Describe("Manager controller tests", func() {
 
 			It("should reconcile with creating new status condition  with multiple conditions as true", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 				ts := &operatorv1.TigeraStatus{
 					ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 					Spec:       operatorv1.TigeraStatusSpec{},
@@ -853,6 +856,7 @@ var _ = Describe("Manager controller tests", func() {
 
 			It("should reconcile with creating new status condition and toggle Available to true & others to false", func() {
 				mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+				mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 				ts := &operatorv1.TigeraStatus{
 					ObjectMeta: metav1.ObjectMeta{Name: "manager"},
 					Spec:       operatorv1.TigeraStatusSpec{},
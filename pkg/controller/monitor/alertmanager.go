@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// alertmanagerConfigKey is the key of the Alertmanager configuration secret holding the configuration file.
+const alertmanagerConfigKey = "alertmanager.yaml"
+
+// alertmanagerConfigFile models the top level of the Alertmanager configuration file. Only the parts
+// the operator validates are typed; the remaining sections are accepted as-is so that Alertmanager
+// remains the authority on their contents.
+type alertmanagerConfigFile struct {
+	Global            *alertmanagerGlobal    `yaml:"global,omitempty"`
+	Route             *alertmanagerRoute     `yaml:"route,omitempty"`
+	InhibitRules      []interface{}          `yaml:"inhibit_rules,omitempty"`
+	Receivers         []alertmanagerReceiver `yaml:"receivers,omitempty"`
+	Templates         []string               `yaml:"templates,omitempty"`
+	MuteTimeIntervals []interface{}          `yaml:"mute_time_intervals,omitempty"`
+	TimeIntervals     []interface{}          `yaml:"time_intervals,omitempty"`
+}
+
+type alertmanagerGlobal struct {
+	ResolveTimeout string                 `yaml:"resolve_timeout,omitempty"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+type alertmanagerRoute struct {
+	Receiver            string               `yaml:"receiver,omitempty"`
+	GroupBy             []string             `yaml:"group_by,omitempty"`
+	GroupWait           string               `yaml:"group_wait,omitempty"`
+	GroupInterval       string               `yaml:"group_interval,omitempty"`
+	RepeatInterval      string               `yaml:"repeat_interval,omitempty"`
+	Match               map[string]string    `yaml:"match,omitempty"`
+	MatchRE             map[string]string    `yaml:"match_re,omitempty"`
+	Matchers            []string             `yaml:"matchers,omitempty"`
+	Continue            bool                 `yaml:"continue,omitempty"`
+	MuteTimeIntervals   []string             `yaml:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string             `yaml:"active_time_intervals,omitempty"`
+	Routes              []*alertmanagerRoute `yaml:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name  string                 `yaml:"name"`
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// validateAlertmanagerConfig verifies that the Alertmanager configuration secret holds a well-formed
+// configuration file: the file must parse, have a top level route with a receiver, every route must
+// reference a defined receiver and every duration must be valid. An invalid configuration would otherwise
+// only surface as a crash looping Alertmanager.
+func validateAlertmanagerConfig(secret *corev1.Secret) error {
+	data, ok := secret.Data[alertmanagerConfigKey]
+	if !ok || len(data) == 0 {
+		return fmt.Errorf("secret %s/%s does not contain the %q key", secret.Namespace, secret.Name, alertmanagerConfigKey)
+	}
+
+	var cfg alertmanagerConfigFile
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return fmt.Errorf("%s is not a valid Alertmanager configuration: %w", alertmanagerConfigKey, err)
+	}
+
+	if cfg.Global != nil && cfg.Global.ResolveTimeout != "" {
+		if _, err := model.ParseDuration(cfg.Global.ResolveTimeout); err != nil {
+			return fmt.Errorf("global.resolve_timeout %q is invalid: %w", cfg.Global.ResolveTimeout, err)
+		}
+	}
+
+	receivers := map[string]bool{}
+	for i, r := range cfg.Receivers {
+		if r.Name == "" {
+			return fmt.Errorf("receivers[%d] is missing a name", i)
+		}
+		if receivers[r.Name] {
+			return fmt.Errorf("receiver %q is defined more than once", r.Name)
+		}
+		receivers[r.Name] = true
+	}
+
+	if cfg.Route == nil {
+		return fmt.Errorf("%s is missing the top level route", alertmanagerConfigKey)
+	}
+	if cfg.Route.Receiver == "" {
+		return fmt.Errorf("the top level route is missing a receiver")
+	}
+	return validateAlertmanagerRoute(cfg.Route, "route", receivers)
+}
+
+// validateAlertmanagerRoute validates a route and its child routes. Child routes inherit the receiver of
+// their parent so an empty receiver is only rejected at the top level.
+func validateAlertmanagerRoute(route *alertmanagerRoute, field string, receivers map[string]bool) error {
+	if route.Receiver != "" && !receivers[route.Receiver] {
+		return fmt.Errorf("%s.receiver references the undefined receiver %q", field, route.Receiver)
+	}
+	for _, d := range []struct{ name, value string }{
+		{"group_wait", route.GroupWait},
+		{"group_interval", route.GroupInterval},
+		{"repeat_interval", route.RepeatInterval},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if _, err := model.ParseDuration(d.value); err != nil {
+			return fmt.Errorf("%s.%s %q is invalid: %w", field, d.name, d.value, err)
+		}
+	}
+	for i, child := range route.Routes {
+		if child == nil {
+			return fmt.Errorf("%s.routes[%d] is empty", field, i)
+		}
+		if err := validateAlertmanagerRoute(child, fmt.Sprintf("%s.routes[%d]", field, i), receivers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
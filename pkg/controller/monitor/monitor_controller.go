@@ -18,13 +18,21 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"net/url"
+	"path"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -33,7 +41,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
 
 	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
@@ -47,6 +56,7 @@ import (
 	"github.com/tigera/operator/pkg/render"
 	rcertificatemanagement "github.com/tigera/operator/pkg/render/certificatemanagement"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	rsecret "github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/kubecontrollers"
 	"github.com/tigera/operator/pkg/render/logstorage/esmetrics"
@@ -64,10 +74,11 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 	}
 
 	prometheusReady := &utils.ReadyFlag{}
+	missingCRDs := &utils.MissingCRDs{}
 	tierWatchReady := &utils.ReadyFlag{}
 
 	// Create the reconciler
-	reconciler := newReconciler(mgr, opts, prometheusReady, tierWatchReady)
+	reconciler := newReconciler(mgr, opts, prometheusReady, missingCRDs, tierWatchReady)
 
 	// Create a new controller
 	c, err := ctrlruntime.NewController("monitor-controller", mgr, controller.Options{Reconciler: reconciler})
@@ -75,6 +86,12 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 		return fmt.Errorf("failed to create monitor-controller: %w", err)
 	}
 
+	if opts.ResyncPeriod > 0 {
+		if err = utils.AddPeriodicReconcile(c, opts.ResyncPeriod, &handler.EnqueueRequestForObject{}); err != nil {
+			return fmt.Errorf("monitor-controller failed to create periodic reconcile watch: %w", err)
+		}
+	}
+
 	k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		log.Error(err, "Failed to establish a connection to k8s")
@@ -95,26 +112,42 @@ func Add(mgr manager.Manager, opts options.AddOptions) error {
 
 	go utils.WaitToAddNetworkPolicyWatches(c, k8sClient, log, policyNames)
 
-	go waitToAddPrometheusWatch(c, k8sClient, log, prometheusReady)
+	go waitToAddPrometheusWatch(c, k8sClient, log, prometheusReady, missingCRDs)
 
 	return add(mgr, c)
 }
 
-func newReconciler(mgr manager.Manager, opts options.AddOptions, prometheusReady *utils.ReadyFlag, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, opts options.AddOptions, prometheusReady *utils.ReadyFlag, missingCRDs *utils.MissingCRDs, tierWatchReady *utils.ReadyFlag) reconcile.Reconciler {
 	r := &ReconcileMonitor{
 		client:          mgr.GetClient(),
 		scheme:          mgr.GetScheme(),
 		provider:        opts.DetectedProvider,
 		status:          status.New(mgr.GetClient(), "monitor", opts.KubernetesVersion),
 		prometheusReady: prometheusReady,
+		missingCRDs:     missingCRDs,
 		tierWatchReady:  tierWatchReady,
 		clusterDomain:   opts.ClusterDomain,
 		usePSP:          opts.UsePSP,
 		multiTenant:     opts.MultiTenant,
+
+		certificateDuration:      opts.CertificateDuration,
+		certificateRenewalWindow: opts.CertificateRenewalWindow,
+		requeueInterval:          opts.RequeueInterval,
+		dryRun:                   opts.DryRun,
+		serverSideApply:          opts.UseServerSideApply,
+		unmanagedSelector:        opts.UnmanagedResourceSelector,
+	}
+	if opts.AuditSpecChanges {
+		r.specAuditor = utils.NewSpecAuditor(log.WithName("audit"))
+	}
+	if opts.DependencyWaitTimeout > 0 {
+		r.dependencyWaitTimeout = opts.DependencyWaitTimeout
+		r.dependencyWaitDeadline = time.Now().Add(opts.DependencyWaitTimeout)
 	}
 
+	// The Alertmanager StatefulSet is registered by reconcile, as it is not deployed when Alertmanager is disabled or
+	// external Alertmanagers are used.
 	r.status.AddStatefulSets([]types.NamespacedName{
-		{Namespace: common.TigeraPrometheusNamespace, Name: fmt.Sprintf("alertmanager-%s", monitor.CalicoNodeAlertmanager)},
 		{Namespace: common.TigeraPrometheusNamespace, Name: fmt.Sprintf("prometheus-%s", monitor.CalicoNodePrometheus)},
 	})
 
@@ -144,6 +177,12 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		return fmt.Errorf("monitor-controller failed to watch ManagementClusterConnection resource: %w", err)
 	}
 
+	// ManagementCluster is watched so that the Monitor is reconciled again once a conflicting cluster role is resolved.
+	err = c.WatchObject(&operatorv1.ManagementCluster{}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return fmt.Errorf("monitor-controller failed to watch ManagementCluster resource: %w", err)
+	}
+
 	for _, secret := range []string{
 		certificatemanagement.CASecretName,
 		esmetrics.ElasticsearchMetricsServerTLSSecret,
@@ -158,6 +197,12 @@ func add(_ manager.Manager, c ctrlruntime.Controller) error {
 		}
 	}
 
+	// The remote write and remote read secrets are referenced by name from the Monitor, so watch all secrets in the
+	// operator namespace.
+	if err = utils.AddSecretsWatch(c, "", common.OperatorNamespace()); err != nil {
+		return fmt.Errorf("monitor-controller failed to watch secrets: %w", err)
+	}
+
 	// Namespaces are watched in case external monitoring config is used.
 	err = c.WatchObject(&corev1.Namespace{}, &handler.EnqueueRequestForObject{})
 	if err != nil {
@@ -186,10 +231,52 @@ type ReconcileMonitor struct {
 	provider        operatorv1.Provider
 	status          status.StatusManager
 	prometheusReady *utils.ReadyFlag
+	missingCRDs     *utils.MissingCRDs
 	tierWatchReady  *utils.ReadyFlag
 	clusterDomain   string
 	usePSP          bool
 	multiTenant     bool
+
+	// The validity of the key pairs issued by this controller. The default is used when zero.
+	certificateDuration time.Duration
+
+	// How long before expiry the key pairs issued by this controller are renewed. The default is used when zero.
+	certificateRenewalWindow time.Duration
+
+	// specAuditor, if set, keeps an audit trail of the spec changes this controller acts on.
+	specAuditor *utils.SpecAuditor
+
+	// How long to wait before reconciling again when resources are not yet available. utils.StandardRetry is
+	// used when zero.
+	requeueInterval time.Duration
+
+	// dryRun, if set, makes the component handler log the writes it would make instead of making them.
+	dryRun bool
+
+	// serverSideApply, if set, makes the component handler write objects using server-side apply.
+	serverSideApply bool
+
+	// unmanagedSelector selects existing objects that the component handler should not update.
+	unmanagedSelector labels.Selector
+
+	// How long this controller waits for the Prometheus operator CRDs, and the time at which that wait
+	// runs out. The controller waits indefinitely when the deadline is zero.
+	dependencyWaitTimeout  time.Duration
+	dependencyWaitDeadline time.Time
+}
+
+// dependencyTimeoutMessage returns the degraded message for when the Prometheus operator CRDs were not
+// installed within the dependency wait timeout.
+func (r *ReconcileMonitor) dependencyTimeoutMessage() string {
+	msg := fmt.Sprintf("Timed out after %s waiting for the Prometheus operator", r.dependencyWaitTimeout)
+	if kinds := r.missingCRDs.Get(); len(kinds) > 0 {
+		names := make([]string, len(kinds))
+		for i, k := range kinds {
+			names[i] = k.String()
+		}
+		msg += fmt.Sprintf(", missing CRD(s): %s", strings.Join(names, ", "))
+	}
+	return msg
 }
 
 func (r *ReconcileMonitor) getMonitor(ctx context.Context) (*operatorv1.Monitor, error) {
@@ -240,6 +327,13 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Failed to write defaults", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = validateMonitorResource(instance); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Monitor", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+	if r.specAuditor != nil {
+		r.specAuditor.Record(instance, instance.Spec)
+	}
 	if instance.Spec.ExternalPrometheus != nil {
 		if err = r.client.Get(ctx, client.ObjectKey{Name: instance.Spec.ExternalPrometheus.Namespace}, &corev1.Namespace{}); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceReadError, fmt.Sprintf("Failed to get external prometheus namespace %s",
@@ -257,6 +351,49 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to query Installation", err, reqLogger)
 		return reconcile.Result{}, err
 	}
+	if err = utils.ValidateClusterRole(ctx, r.client); err != nil {
+		if utils.IsClusterRoleConflictError(err) {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "", err, reqLogger)
+		} else {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error reading the cluster role", err, reqLogger)
+		}
+		return reconcile.Result{}, err
+	}
+
+	if err = validateMonitorPodDisruptionBudgets(instance, install); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Monitor", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+	if err = validateAlertmanagerMeshPolicy(instance, install); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Monitor", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
+	if instance.Spec.StorageSize != nil {
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err = r.client.List(ctx, pvcs, client.InNamespace(common.TigeraPrometheusNamespace)); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list the Prometheus PersistentVolumeClaims", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if err = validatePrometheusStorage(instance, pvcs.Items); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Monitor", err, reqLogger)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	remoteStorageSecrets, missing, err := r.readRemoteStorageSecrets(ctx, instance.Spec)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving remote storage secrets", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+	if len(missing) > 0 {
+		// The secrets are watched, but requeue as well in case they are created before the watch is established.
+		r.status.SetDegraded(operatorv1.ResourceNotFound, fmt.Sprintf("Remote storage secret(s) %s not found in the %s namespace", strings.Join(missing, ", "), common.OperatorNamespace()), nil, reqLogger)
+		return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
+	}
+	if err = validateRemoteStorageSecrets(instance.Spec, remoteStorageSecrets); err != nil {
+		r.status.SetDegraded(operatorv1.ResourceValidationError, "Failed to validate Monitor", err, reqLogger)
+		return reconcile.Result{}, nil
+	}
 
 	pullSecrets, err := utils.GetNetworkingPullSecrets(install, r.client)
 	if err != nil {
@@ -265,12 +402,36 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if !r.prometheusReady.IsReady() {
+		// Once the wait for the Prometheus operator has run out, report it as a missing dependency rather
+		// than as a transient wait. Keep checking so the controller recovers if the operator is installed later.
+		if !r.dependencyWaitDeadline.IsZero() && time.Now().After(r.dependencyWaitDeadline) {
+			r.status.SetDegraded(operatorv1.DependencyTimeout, r.dependencyTimeoutMessage(), nil, reqLogger)
+			return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
+		}
+
 		err = fmt.Errorf("waiting for Prometheus resources")
+		// If the Prometheus CRDs are known to be missing, name them so users know which prerequisite to install.
+		if msg := r.missingCRDs.Message(); msg != "" {
+			r.status.SetDegraded(operatorv1.MissingCRD, msg, err, reqLogger)
+			return reconcile.Result{}, err
+		}
 		r.status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for Prometheus resources to be ready", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
-	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace())
+	staleAdditionalServiceMonitors, err := r.staleAdditionalServiceMonitors(ctx, instance)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list the additional ServiceMonitors", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	staleRemoteStorageSecrets, err := r.staleRemoteStorageSecrets(ctx, instance, remoteStorageSecrets)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Failed to list the remote storage secrets", err, reqLogger)
+		return reconcile.Result{}, err
+	}
+
+	certificateManager, err := certificatemanager.Create(r.client, install, r.clusterDomain, common.OperatorNamespace(), certificatemanager.WithCertificateDuration(r.certificateDuration), certificatemanager.WithCertificateRenewalWindow(r.certificateRenewalWindow))
 	if err != nil {
 		r.status.SetDegraded(operatorv1.ResourceCreateError, "Unable to create the Tigera CA", err, reqLogger)
 		return reconcile.Result{}, err
@@ -340,26 +501,31 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	// Ensure the allow-tigera tier exists, before rendering any network policies within it.
-	includeV3NetworkPolicy := false
-	if err := r.client.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
-		// The creation of the Tier depends on this controller to reconcile it's non-NetworkPolicy resources so that the
-		// License becomes available (in managed clusters). Therefore, if we fail to query the Tier, we exclude NetworkPolicy
-		// from reconciliation and tolerate errors arising from the Tier not being created.
-		if !errors.IsNotFound(err) {
-			r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
-			return reconcile.Result{}, err
-		}
-	} else {
-		includeV3NetworkPolicy = true
+	// The creation of the Tier depends on this controller to reconcile it's non-NetworkPolicy resources so that the
+	// License becomes available (in managed clusters). Therefore, unlike the controllers that wait for the Tier with
+	// utils.TierWaiter, we exclude NetworkPolicy from reconciliation while the Tier has not been created.
+	includeV3NetworkPolicy, err := utils.AllowTigeraTierExists(ctx, r.client)
+	if err != nil {
+		r.status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, reqLogger)
+		return reconcile.Result{}, err
 	}
 
 	// Create a component handler to manage the rendered component.
-	hdler := utils.NewComponentHandler(log, r.client, r.scheme, instance)
+	hdler := utils.NewComponentHandler(log, r.client, r.scheme, instance, utils.WithAdditionalMetadata(install.AdditionalLabels, install.AdditionalAnnotations), utils.WithDryRun(r.dryRun), utils.WithServerSideApply(r.serverSideApply), utils.WithUnmanagedSelector(r.unmanagedSelector))
 
-	alertmanagerConfigSecret, createInOperatorNamespace, err := r.readAlertmanagerConfigSecret(ctx)
-	if err != nil {
-		r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving Alertmanager configuration secret", err, reqLogger)
-		return reconcile.Result{}, err
+	// The Alertmanager configuration secret is only needed when Alertmanager is enabled.
+	var alertmanagerConfigSecret *corev1.Secret
+	var createInOperatorNamespace bool
+	if monitor.AlertmanagerEnabled(instance.Spec) {
+		alertmanagerConfigSecret, createInOperatorNamespace, err = r.readAlertmanagerConfigSecret(ctx)
+		if err != nil {
+			r.status.SetDegraded(operatorv1.ResourceReadError, "Error retrieving Alertmanager configuration secret", err, reqLogger)
+			return reconcile.Result{}, err
+		}
+		if err := validateAlertmanagerConfig(alertmanagerConfigSecret); err != nil {
+			r.status.SetDegraded(operatorv1.ResourceValidationError, fmt.Sprintf("Invalid Alertmanager configuration in secret %s/%s", alertmanagerConfigSecret.Namespace, alertmanagerConfigSecret.Name), err, reqLogger)
+			return reconcile.Result{}, nil
+		}
 	}
 
 	kubeControllersMetricsPort, err := utils.GetKubeControllerMetricsPort(ctx, r.client)
@@ -369,18 +535,21 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	monitorCfg := &monitor.Config{
-		Monitor:                  instance.Spec,
-		Installation:             install,
-		PullSecrets:              pullSecrets,
-		AlertmanagerConfigSecret: alertmanagerConfigSecret,
-		KeyValidatorConfig:       keyValidatorConfig,
-		ServerTLSSecret:          serverTLSSecret,
-		ClientTLSSecret:          clientTLSSecret,
-		ClusterDomain:            r.clusterDomain,
-		TrustedCertBundle:        trustedBundle,
-		Openshift:                r.provider == operatorv1.ProviderOpenShift,
-		KubeControllerPort:       kubeControllersMetricsPort,
-		UsePSP:                   r.usePSP,
+		Monitor:                        instance.Spec,
+		Installation:                   install,
+		PullSecrets:                    pullSecrets,
+		AlertmanagerConfigSecret:       alertmanagerConfigSecret,
+		KeyValidatorConfig:             keyValidatorConfig,
+		ServerTLSSecret:                serverTLSSecret,
+		ClientTLSSecret:                clientTLSSecret,
+		ClusterDomain:                  r.clusterDomain,
+		TrustedCertBundle:              trustedBundle,
+		Openshift:                      r.provider == operatorv1.ProviderOpenShift,
+		KubeControllerPort:             kubeControllersMetricsPort,
+		UsePSP:                         r.usePSP,
+		RemoteStorageSecrets:           remoteStorageSecrets,
+		StaleAdditionalServiceMonitors: staleAdditionalServiceMonitors,
+		StaleRemoteStorageSecrets:      staleRemoteStorageSecrets,
 	}
 
 	// Render prometheus component
@@ -410,10 +579,19 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 	}
 
 	if err = imageset.ApplyImageSet(ctx, r.client, variant, components...); err != nil {
-		r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error with images from ImageSet", err, reqLogger)
+		r.status.SetDegraded(operatorv1.ImageSetError, "Error with images from ImageSet", err, reqLogger)
 		return reconcile.Result{}, err
 	}
 
+	// The Alertmanager StatefulSet is created by the prometheus operator, so it is not registered by the component
+	// handler. When Alertmanager is disabled or external Alertmanagers are used, the component handler stops monitoring
+	// it as it is deleted.
+	if monitor.DeploysAlertmanager(instance.Spec) {
+		r.status.AddStatefulSets([]types.NamespacedName{
+			{Namespace: common.TigeraPrometheusNamespace, Name: monitor.AlertmanagerStatefulSetName},
+		})
+	}
+
 	for _, component := range components {
 		if err = hdler.CreateOrUpdateOrDelete(ctx, component, r.status); err != nil {
 			r.status.SetDegraded(operatorv1.ResourceUpdateError, "Error creating / updating resource", err, reqLogger)
@@ -428,7 +606,7 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 
 	if !r.status.IsAvailable() {
 		// Schedule a kick to check again in the near future. Hopefully by then things will be available.
-		return reconcile.Result{RequeueAfter: utils.StandardRetry}, nil
+		return reconcile.Result{RequeueAfter: utils.RequeueInterval(r.requeueInterval)}, nil
 	}
 
 	instance.Status.State = operatorv1.TigeraStatusReady
@@ -437,7 +615,12 @@ func (r *ReconcileMonitor) Reconcile(ctx context.Context, request reconcile.Requ
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	// Reconcile again when one of the Prometheus key pairs is due for renewal.
+	result := reconcile.Result{}
+	if next := certificateManager.NextRenewal(); !next.IsZero() {
+		result.RequeueAfter = time.Until(next)
+	}
+	return result, nil
 }
 
 func fillDefaults(instance *operatorv1.Monitor) {
@@ -471,9 +654,641 @@ func fillDefaults(instance *operatorv1.Monitor) {
 	}
 }
 
-// PrometheusTLSServerDNSNames returns all the DNS names valid for the prometheus server TLS asset.
+// validateMonitorResource validates the Monitor resource and returns an error if it is invalid.
+func validateMonitorResource(instance *operatorv1.Monitor) error {
+	for i, rc := range instance.Spec.MetricRelabelConfigs {
+		if err := validateRelabelConfig(rc); err != nil {
+			return fmt.Errorf("Monitor spec.metricRelabelings[%d] is invalid: %w", i, err)
+		}
+	}
+	if err := validateScrapeConfig(instance.Spec.ScrapeConfig); err != nil {
+		return fmt.Errorf("Monitor spec.scrapeConfig is invalid: %w", err)
+	}
+	if err := validateTSDB(instance.Spec.TSDB); err != nil {
+		return fmt.Errorf("Monitor spec.tsdb is invalid: %w", err)
+	}
+	if err := validateFederation(instance.Spec.Federation); err != nil {
+		return fmt.Errorf("Monitor spec.federation is invalid: %w", err)
+	}
+	if err := validateRemoteWrite(instance.Spec.RemoteWrite); err != nil {
+		return fmt.Errorf("Monitor spec.remoteWrite is invalid: %w", err)
+	}
+	if err := validateRemoteRead(instance.Spec.RemoteRead); err != nil {
+		return fmt.Errorf("Monitor spec.remoteRead is invalid: %w", err)
+	}
+	if err := validateRoutePrefix(instance.Spec.RoutePrefix); err != nil {
+		return fmt.Errorf("Monitor spec.routePrefix is invalid: %w", err)
+	}
+	if err := validatePrometheusFeatures(instance.Spec.PrometheusFeatures); err != nil {
+		return fmt.Errorf("Monitor spec.prometheusFeatures is invalid: %w", err)
+	}
+	if r := instance.Spec.AlertmanagerReplicas; r != nil && *r < 1 {
+		return fmt.Errorf("Monitor spec.alertmanagerReplicas must be positive, got %d", *r)
+	}
+	if r := instance.Spec.PrometheusReplicas; r != nil && *r < 1 {
+		return fmt.Errorf("Monitor spec.prometheusReplicas must be positive, got %d", *r)
+	}
+	if err := validateExternalAlertmanager(instance.Spec); err != nil {
+		return fmt.Errorf("Monitor spec.externalAlertmanager is invalid: %w", err)
+	}
+	if !monitor.AlertmanagerEnabled(instance.Spec) {
+		if monitor.UsesExternalAlertmanager(instance.Spec) {
+			return fmt.Errorf("Monitor spec.alertmanagerMode cannot be Disabled together with spec.externalAlertmanager")
+		}
+		if instance.Spec.AlertmanagerExternalAccess != nil {
+			return fmt.Errorf("Monitor spec.alertmanagerMode cannot be Disabled together with spec.alertmanagerExternalAccess")
+		}
+	}
+	if level := instance.Spec.AlertmanagerLogLevel; level != nil {
+		switch *level {
+		case operatorv1.LogLevelError, operatorv1.LogLevelWarn, operatorv1.LogLevelInfo, operatorv1.LogLevelDebug:
+		default:
+			return fmt.Errorf("Monitor spec.alertmanagerLogLevel %q is not supported by Alertmanager, must be one of Error, Warn, Info or Debug", *level)
+		}
+	}
+	if err := validatePositiveDuration(instance.Spec.Retention); err != nil {
+		return fmt.Errorf("Monitor spec.retention is invalid: %w", err)
+	}
+	if err := validatePositiveDuration(instance.Spec.QueryTimeout); err != nil {
+		return fmt.Errorf("Monitor spec.queryTimeout is invalid: %w", err)
+	}
+	if err := validateMinimumInterval(instance.Spec.ScrapeInterval); err != nil {
+		return fmt.Errorf("Monitor spec.scrapeInterval is invalid: %w", err)
+	}
+	if err := validateMinimumInterval(instance.Spec.EvaluationInterval); err != nil {
+		return fmt.Errorf("Monitor spec.evaluationInterval is invalid: %w", err)
+	}
+	if c := instance.Spec.PrometheusMaxConnections; c != nil && *c < 1 {
+		return fmt.Errorf("Monitor spec.prometheusMaxConnections must be positive, got %d", *c)
+	}
+	if err := validateAdditionalServiceMonitors(instance.Spec.AdditionalServiceMonitors); err != nil {
+		return fmt.Errorf("Monitor spec.additionalServiceMonitors is invalid: %w", err)
+	}
+	if size := instance.Spec.StorageSize; size != nil && size.Sign() <= 0 {
+		return fmt.Errorf("Monitor spec.storageSize must be positive, got %s", size.String())
+	}
+	return nil
+}
+
+// validateRemoteWrite verifies that each remote write endpoint is an absolute http(s) URL, and that the Secrets it
+// references are named.
+func validateRemoteWrite(remoteWrite []operatorv1.PrometheusRemoteWrite) error {
+	for _, rw := range remoteWrite {
+		if err := validateRemoteStorageEndpoint(rw.URL, rw.BasicAuthSecret, rw.TLSSecret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRemoteRead verifies that each remote read endpoint is an absolute http(s) URL that is listed once, and that
+// the Secrets it references are named.
+func validateRemoteRead(remoteRead []operatorv1.PrometheusRemoteRead) error {
+	seen := map[string]bool{}
+	for _, rr := range remoteRead {
+		if err := validateRemoteStorageEndpoint(rr.URL, rr.BasicAuthSecret, rr.TLSSecret); err != nil {
+			return err
+		}
+		if seen[rr.URL] {
+			return fmt.Errorf("url %q is listed more than once", rr.URL)
+		}
+		seen[rr.URL] = true
+	}
+	return nil
+}
+
+func validateRemoteStorageEndpoint(endpoint string, basicAuthSecret, tlsSecret *corev1.LocalObjectReference) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("url %q must be an http or https URL with a host", endpoint)
+	}
+	if u.User != nil {
+		return fmt.Errorf("url %q must not contain credentials, use basicAuthSecret instead", endpoint)
+	}
+	if basicAuthSecret != nil && basicAuthSecret.Name == "" {
+		return fmt.Errorf("basicAuthSecret for url %q must specify a name", endpoint)
+	}
+	if tlsSecret != nil && tlsSecret.Name == "" {
+		return fmt.Errorf("tlsSecret for url %q must specify a name", endpoint)
+	}
+	return nil
+}
+
+// validateExternalAlertmanager verifies that each external Alertmanager is an absolute http(s) URL that is listed once,
+// and that Alertmanager external access, which exposes the Alertmanager deployed by the operator, is not configured.
+func validateExternalAlertmanager(spec operatorv1.MonitorSpec) error {
+	if !monitor.UsesExternalAlertmanager(spec) {
+		return nil
+	}
+	if spec.AlertmanagerExternalAccess != nil {
+		return fmt.Errorf("cannot be set together with spec.alertmanagerExternalAccess")
+	}
+	seen := map[string]bool{}
+	for _, endpoint := range spec.ExternalAlertmanager {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid url %q: %w", endpoint, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return fmt.Errorf("url %q must be an http or https URL with a host", endpoint)
+		}
+		if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("url %q must not contain credentials, a query or a fragment", endpoint)
+		}
+		if seen[endpoint] {
+			return fmt.Errorf("duplicate url %q", endpoint)
+		}
+		seen[endpoint] = true
+	}
+	return nil
+}
+
+// validatePositiveDuration verifies that the duration, if set, is a positive Prometheus duration.
+func validatePositiveDuration(duration monitoringv1.Duration) error {
+	if duration == "" {
+		return nil
+	}
+	d, err := model.ParseDuration(string(duration))
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", duration)
+	}
+	return nil
+}
+
+// minimumPrometheusInterval is the shortest scrape and rule evaluation interval that the Prometheus of the operator
+// may use. Shorter intervals multiply the load on Prometheus and on the scraped components.
+const minimumPrometheusInterval = 5 * time.Second
+
+// validateMinimumInterval verifies that the interval, if set, is a Prometheus duration of at least
+// minimumPrometheusInterval.
+func validateMinimumInterval(interval monitoringv1.Duration) error {
+	if interval == "" {
+		return nil
+	}
+	d, err := model.ParseDuration(string(interval))
+	if err != nil {
+		return err
+	}
+	if time.Duration(d) < minimumPrometheusInterval {
+		return fmt.Errorf("interval must be at least %s, got %s", minimumPrometheusInterval, interval)
+	}
+	return nil
+}
+
+// validateAdditionalServiceMonitors verifies that each additional ServiceMonitor has a unique name that is not used by
+// an operator managed ServiceMonitor, selects valid namespaces and labels, and has valid endpoints.
+func validateAdditionalServiceMonitors(asms []operatorv1.AdditionalServiceMonitor) error {
+	managed := map[string]bool{}
+	for _, name := range monitor.ManagedServiceMonitorNames() {
+		managed[name] = true
+	}
+	seen := map[string]bool{}
+	for _, asm := range asms {
+		if errs := validation.IsDNS1123Subdomain(asm.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid ServiceMonitor name %q: %s", asm.Name, strings.Join(errs, ", "))
+		}
+		if managed[asm.Name] {
+			return fmt.Errorf("%q is the name of an operator managed ServiceMonitor", asm.Name)
+		}
+		if seen[asm.Name] {
+			return fmt.Errorf("ServiceMonitor %s is listed more than once", asm.Name)
+		}
+		seen[asm.Name] = true
+
+		if len(asm.Namespaces) == 0 {
+			return fmt.Errorf("ServiceMonitor %s must select at least one namespace", asm.Name)
+		}
+		for _, ns := range asm.Namespaces {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return fmt.Errorf("invalid namespace %q for ServiceMonitor %s: %s", ns, asm.Name, strings.Join(errs, ", "))
+			}
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&asm.Selector); err != nil {
+			return fmt.Errorf("invalid selector for ServiceMonitor %s: %w", asm.Name, err)
+		}
+		if len(asm.Endpoints) == 0 {
+			return fmt.Errorf("ServiceMonitor %s must have at least one endpoint", asm.Name)
+		}
+		for i, ep := range asm.Endpoints {
+			if err := validateAdditionalServiceMonitorEndpoint(ep); err != nil {
+				return fmt.Errorf("endpoint %d of ServiceMonitor %s is invalid: %w", i, asm.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAdditionalServiceMonitorEndpoint verifies the port, path, scrape interval and timeout, and relabel configs
+// of an endpoint of an additional ServiceMonitor.
+func validateAdditionalServiceMonitorEndpoint(ep operatorv1.AdditionalServiceMonitorEndpoint) error {
+	if ep.Port < 1 || ep.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got %d", ep.Port)
+	}
+	if ep.Path != "" && !strings.HasPrefix(ep.Path, "/") {
+		return fmt.Errorf("path %q must start with /", ep.Path)
+	}
+	if err := validatePositiveDuration(ep.Interval); err != nil {
+		return fmt.Errorf("invalid interval: %w", err)
+	}
+	if err := validatePositiveDuration(ep.ScrapeTimeout); err != nil {
+		return fmt.Errorf("invalid scrape timeout: %w", err)
+	}
+	if ep.Interval != "" && ep.ScrapeTimeout != "" {
+		// Both durations were parsed successfully above.
+		i, _ := model.ParseDuration(string(ep.Interval))
+		t, _ := model.ParseDuration(string(ep.ScrapeTimeout))
+		if t > i {
+			return fmt.Errorf("scrape timeout %s must not be greater than the interval %s", ep.ScrapeTimeout, ep.Interval)
+		}
+	}
+	for i, rc := range ep.MetricRelabelConfigs {
+		if err := validateRelabelConfig(rc); err != nil {
+			return fmt.Errorf("metricRelabelings[%d] is invalid: %w", i, err)
+		}
+	}
+	for i, rc := range ep.RelabelConfigs {
+		if err := validateRelabelConfig(rc); err != nil {
+			return fmt.Errorf("relabelings[%d] is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validatePrometheusStorage verifies that the storage size does not shrink any of the existing Prometheus volume
+// claims and that the StorageClass matches theirs, as Kubernetes does not allow PersistentVolumeClaims to be reduced
+// in size or moved to another StorageClass.
+func validatePrometheusStorage(instance *operatorv1.Monitor, pvcs []corev1.PersistentVolumeClaim) error {
+	size := instance.Spec.StorageSize
+	if size == nil {
+		return nil
+	}
+	for _, pvc := range pvcs {
+		if !strings.HasPrefix(pvc.Name, monitor.PrometheusStorageClaimPrefix) {
+			continue
+		}
+		if current, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok && size.Cmp(current) < 0 {
+			return fmt.Errorf("Monitor spec.storageSize %s must not be smaller than the %s of the existing PersistentVolumeClaim %s",
+				size.String(), current.String(), pvc.Name)
+		}
+		if class := instance.Spec.StorageClassName; class != "" && pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != class {
+			return fmt.Errorf("Monitor spec.storageClassName %q does not match the StorageClass %q of the existing PersistentVolumeClaim %s; "+
+				"the StorageClass of a claim cannot be changed, so delete the Prometheus claims by hand to recreate them with the new StorageClass",
+				class, *pvc.Spec.StorageClassName, pvc.Name)
+		}
+	}
+	return nil
+}
+
+// validatePrometheusFeatures verifies that each feature is a known Prometheus feature flag and is listed once.
+func validatePrometheusFeatures(features []operatorv1.PrometheusFeature) error {
+	seen := map[operatorv1.PrometheusFeature]bool{}
+	for _, f := range features {
+		switch f {
+		case operatorv1.PrometheusFeatureExemplarStorage,
+			operatorv1.PrometheusFeatureExpandExternalLabels,
+			operatorv1.PrometheusFeatureExtraScrapeMetrics,
+			operatorv1.PrometheusFeatureMemorySnapshotOnShutdown,
+			operatorv1.PrometheusFeatureNativeHistograms,
+			operatorv1.PrometheusFeatureNewServiceDiscoveryManager,
+			operatorv1.PrometheusFeatureNoDefaultScrapePort,
+			operatorv1.PrometheusFeatureAutoGOMAXPROCS:
+		default:
+			return fmt.Errorf("unknown feature %q", f)
+		}
+		if seen[f] {
+			return fmt.Errorf("duplicate feature %q", f)
+		}
+		seen[f] = true
+	}
+	return nil
+}
+
+// validateRoutePrefix verifies that the route prefix is an absolute, clean URL path without a query or fragment.
+func validateRoutePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("%q must start with /", prefix)
+	}
+	u, err := url.Parse(prefix)
+	if err != nil {
+		return err
+	}
+	if u.Path != prefix || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%q must be a plain path without a query, fragment or escaped characters", prefix)
+	}
+	if p := strings.TrimSuffix(prefix, "/"); p != "" && path.Clean(p) != p {
+		return fmt.Errorf("%q must not contain empty, . or .. segments", prefix)
+	}
+	return nil
+}
+
+// validateFederation verifies that each federation source has a unique name usable in a scrape job name, an
+// absolute http(s) endpoint and at least one series selector.
+func validateFederation(sources []operatorv1.PrometheusFederationSource) error {
+	names := map[string]bool{}
+	for _, source := range sources {
+		if errs := validation.IsDNS1123Label(source.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid name %q: %s", source.Name, strings.Join(errs, ", "))
+		}
+		if names[source.Name] {
+			return fmt.Errorf("duplicate name %q", source.Name)
+		}
+		names[source.Name] = true
+
+		u, err := url.Parse(source.Endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint for source %s: %w", source.Name, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("endpoint for source %s must use the http or https scheme", source.Name)
+		}
+		if u.Hostname() == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("endpoint for source %s must be a base URL with a host and no credentials, query or fragment", source.Name)
+		}
+		if p := u.Port(); p != "" {
+			if port, err := strconv.Atoi(p); err != nil || port < 1 || port > 65535 {
+				return fmt.Errorf("endpoint for source %s has an invalid port %q", source.Name, p)
+			}
+		}
+
+		if len(source.Match) == 0 {
+			return fmt.Errorf("source %s must specify at least one series selector", source.Name)
+		}
+		for _, m := range source.Match {
+			if strings.TrimSpace(m) == "" {
+				return fmt.Errorf("source %s has an empty series selector", source.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTSDB verifies that the configured block durations are valid, and that the minimum block duration does not
+// exceed the maximum.
+func validateTSDB(tsdb *operatorv1.PrometheusTSDB) error {
+	if tsdb == nil {
+		return nil
+	}
+	var minDuration, maxDuration model.Duration
+	var err error
+	if tsdb.MinBlockDuration != "" {
+		if minDuration, err = model.ParseDuration(string(tsdb.MinBlockDuration)); err != nil {
+			return fmt.Errorf("invalid minBlockDuration: %w", err)
+		}
+		if minDuration <= 0 {
+			return fmt.Errorf("minBlockDuration must be positive")
+		}
+	}
+	if tsdb.MaxBlockDuration != "" {
+		if maxDuration, err = model.ParseDuration(string(tsdb.MaxBlockDuration)); err != nil {
+			return fmt.Errorf("invalid maxBlockDuration: %w", err)
+		}
+		if maxDuration <= 0 {
+			return fmt.Errorf("maxBlockDuration must be positive")
+		}
+	}
+	if minDuration > 0 && maxDuration > 0 && minDuration > maxDuration {
+		return fmt.Errorf("minBlockDuration %s must not be greater than maxBlockDuration %s", tsdb.MinBlockDuration, tsdb.MaxBlockDuration)
+	}
+	if err := validatePositiveDuration(tsdb.OutOfOrderTimeWindow); err != nil {
+		return fmt.Errorf("invalid outOfOrderTimeWindow: %w", err)
+	}
+	return nil
+}
+
+// validateScrapeConfig verifies that the scrape configuration refers to operator managed ServiceMonitors only, and
+// that none of them end up with a scrape timeout greater than their interval, which Prometheus rejects.
+func validateScrapeConfig(cfg *operatorv1.ScrapeConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	managed := monitor.ManagedServiceMonitorNames()
+	isManaged := map[string]bool{}
+	for _, name := range managed {
+		isManaged[name] = true
+	}
+	for _, sm := range cfg.ServiceMonitors {
+		if !isManaged[sm.Name] {
+			return fmt.Errorf("%q is not an operator managed ServiceMonitor, expected one of %s", sm.Name, strings.Join(managed, ", "))
+		}
+	}
+	for _, name := range managed {
+		interval, timeout := monitor.ScrapeIntervalAndTimeout(cfg, name)
+		i, err := model.ParseDuration(string(interval))
+		if err != nil {
+			return fmt.Errorf("invalid interval for ServiceMonitor %s: %w", name, err)
+		}
+		t, err := model.ParseDuration(string(timeout))
+		if err != nil {
+			return fmt.Errorf("invalid scrape timeout for ServiceMonitor %s: %w", name, err)
+		}
+		if i <= 0 {
+			return fmt.Errorf("interval for ServiceMonitor %s must be positive", name)
+		}
+		if t > i {
+			return fmt.Errorf("scrape timeout %s for ServiceMonitor %s must not be greater than its interval %s", timeout, name, interval)
+		}
+	}
+	return nil
+}
+
+// validateMonitorPodDisruptionBudgets validates the Prometheus and Alertmanager PodDisruptionBudgets against the number
+// of replicas each of them runs.
+func validateMonitorPodDisruptionBudgets(instance *operatorv1.Monitor, install *operatorv1.InstallationSpec) error {
+	if p := instance.Spec.Prometheus; p != nil && p.PrometheusSpec != nil {
+		if err := poddisruptionbudget.Validate(p.PrometheusSpec.PodDisruptionBudget, monitor.PrometheusReplicas(instance.Spec)); err != nil {
+			return fmt.Errorf("Monitor spec.prometheus.spec.podDisruptionBudget is invalid: %w", err)
+		}
+	}
+	if a := instance.Spec.AlertManager; a != nil && a.AlertManagerSpec != nil {
+		if err := poddisruptionbudget.Validate(a.AlertManagerSpec.PodDisruptionBudget, monitor.AlertmanagerReplicas(instance.Spec, install)); err != nil {
+			return fmt.Errorf("Monitor spec.alertManager.spec.podDisruptionBudget is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateAlertmanagerMeshPolicy verifies that the Alertmanager mesh policy is only disabled when Alertmanager has no
+// peers, since the replicas cannot form a cluster without it.
+func validateAlertmanagerMeshPolicy(instance *operatorv1.Monitor, install *operatorv1.InstallationSpec) error {
+	if !monitor.AlertmanagerMeshPolicyEnabled(instance.Spec) && monitor.AlertmanagerReplicas(instance.Spec, install) > 1 {
+		return fmt.Errorf("Monitor spec.alertmanagerMeshPolicy cannot be disabled while Alertmanager runs %d replicas", monitor.AlertmanagerReplicas(instance.Spec, install))
+	}
+	return nil
+}
+
+// validateRelabelConfig performs the same structural checks on a relabel config that Prometheus does when it
+// loads its configuration, so that an invalid config is reported on the Monitor rather than breaking scraping.
+func validateRelabelConfig(rc *monitoringv1.RelabelConfig) error {
+	if rc == nil {
+		return fmt.Errorf("relabel config must not be empty")
+	}
+
+	action := strings.ToLower(rc.Action)
+	if action == "" {
+		action = "replace"
+	}
+	switch action {
+	case "replace", "keep", "drop", "hashmod", "labelmap", "labeldrop", "labelkeep", "lowercase", "uppercase":
+	default:
+		return fmt.Errorf("unknown relabel action %q", rc.Action)
+	}
+
+	if rc.Regex != "" {
+		if _, err := regexp.Compile(rc.Regex); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", rc.Regex, err)
+		}
+	}
+
+	switch action {
+	case "replace", "hashmod", "lowercase", "uppercase":
+		if rc.TargetLabel == "" {
+			return fmt.Errorf("relabel action %q requires targetLabel", action)
+		}
+	case "labeldrop", "labelkeep":
+		if len(rc.SourceLabels) != 0 || rc.TargetLabel != "" || rc.Modulus != 0 || rc.Replacement != "" {
+			return fmt.Errorf("relabel action %q only supports the regex field", action)
+		}
+	}
+
+	if action == "hashmod" && rc.Modulus == 0 {
+		return fmt.Errorf("relabel action %q requires a non-zero modulus", action)
+	}
+	return nil
+}
+
+// PrometheusTLSServerDNSNames returns all the DNS names valid for the prometheus server TLS asset. The asset is also
+// served by the Alertmanager authenticating proxy, so the names of its service are included.
 func PrometheusTLSServerDNSNames(clusterDomain string) []string {
-	return dns.GetServiceDNSNames(monitor.PrometheusServiceServiceName, common.TigeraPrometheusNamespace, clusterDomain)
+	names := dns.GetServiceDNSNames(monitor.PrometheusServiceServiceName, common.TigeraPrometheusNamespace, clusterDomain)
+	return append(names, dns.GetServiceDNSNames(monitor.AlertmanagerExternalServiceName, common.TigeraPrometheusNamespace, clusterDomain)...)
+}
+
+// remoteStorageSecretRefs holds the Secrets referenced by a remote write or remote read endpoint.
+type remoteStorageSecretRefs struct {
+	basicAuth *corev1.LocalObjectReference
+	tls       *corev1.LocalObjectReference
+}
+
+// remoteStorageEndpoints returns the Secrets referenced by each remote write and remote read endpoint of the Monitor.
+func remoteStorageEndpoints(spec operatorv1.MonitorSpec) []remoteStorageSecretRefs {
+	var refs []remoteStorageSecretRefs
+	for _, rw := range spec.RemoteWrite {
+		refs = append(refs, remoteStorageSecretRefs{basicAuth: rw.BasicAuthSecret, tls: rw.TLSSecret})
+	}
+	for _, rr := range spec.RemoteRead {
+		refs = append(refs, remoteStorageSecretRefs{basicAuth: rr.BasicAuthSecret, tls: rr.TLSSecret})
+	}
+	return refs
+}
+
+// staleAdditionalServiceMonitors returns the names of the ServiceMonitors that were created for the additional
+// ServiceMonitors of the Monitor, but that are no longer configured. Only the ServiceMonitors that the Monitor
+// controls are returned, so ServiceMonitors created by users are never deleted.
+func (r *ReconcileMonitor) staleAdditionalServiceMonitors(ctx context.Context, instance *operatorv1.Monitor) ([]string, error) {
+	serviceMonitors := &monitoringv1.ServiceMonitorList{}
+	if err := r.client.List(ctx, serviceMonitors, client.InNamespace(common.TigeraPrometheusNamespace), client.HasLabels{monitor.AdditionalServiceMonitorLabel}); err != nil {
+		return nil, err
+	}
+	configured := map[string]bool{}
+	for _, asm := range instance.Spec.AdditionalServiceMonitors {
+		configured[asm.Name] = true
+	}
+	var stale []string
+	for _, sm := range serviceMonitors.Items {
+		if !configured[sm.Name] && metav1.IsControlledBy(sm, instance) {
+			stale = append(stale, sm.Name)
+		}
+	}
+	return stale, nil
+}
+
+// staleRemoteStorageSecrets returns the names of the copies of remote write and remote read Secrets in the
+// tigera-prometheus namespace whose Secrets are no longer referenced by the Monitor. Only the copies that the Monitor
+// controls are returned.
+func (r *ReconcileMonitor) staleRemoteStorageSecrets(ctx context.Context, instance *operatorv1.Monitor, secrets []*corev1.Secret) ([]string, error) {
+	copies := &corev1.SecretList{}
+	if err := r.client.List(ctx, copies, client.InNamespace(common.TigeraPrometheusNamespace), client.HasLabels{monitor.RemoteStorageSecretLabel}); err != nil {
+		return nil, err
+	}
+	referenced := map[string]bool{}
+	for _, s := range secrets {
+		referenced[s.Name] = true
+	}
+	var stale []string
+	for _, s := range copies.Items {
+		if !referenced[s.Name] && metav1.IsControlledBy(&s, instance) {
+			stale = append(stale, s.Name)
+		}
+	}
+	return stale, nil
+}
+
+// readRemoteStorageSecrets retrieves the Secrets referenced by the remote write and remote read endpoints from the
+// tigera-operator namespace. It also returns the names of the referenced Secrets that do not exist.
+func (r *ReconcileMonitor) readRemoteStorageSecrets(ctx context.Context, spec operatorv1.MonitorSpec) ([]*corev1.Secret, []string, error) {
+	var secrets []*corev1.Secret
+	var missing []string
+	seen := map[string]bool{}
+	for _, endpoint := range remoteStorageEndpoints(spec) {
+		for _, ref := range []*corev1.LocalObjectReference{endpoint.basicAuth, endpoint.tls} {
+			if ref == nil || seen[ref.Name] {
+				continue
+			}
+			seen[ref.Name] = true
+
+			secret, err := utils.GetSecret(ctx, r.client, ref.Name, common.OperatorNamespace())
+			if err != nil {
+				return nil, nil, err
+			} else if secret == nil {
+				missing = append(missing, ref.Name)
+				continue
+			}
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets, missing, nil
+}
+
+// validateRemoteStorageSecrets verifies that the Secrets referenced by the remote write and remote read endpoints
+// hold the keys that Prometheus reads from them.
+func validateRemoteStorageSecrets(spec operatorv1.MonitorSpec, secrets []*corev1.Secret) error {
+	byName := map[string]*corev1.Secret{}
+	for _, s := range secrets {
+		byName[s.Name] = s
+	}
+	hasKeys := func(ref *corev1.LocalObjectReference, keys ...string) error {
+		if ref == nil {
+			return nil
+		}
+		secret, ok := byName[ref.Name]
+		if !ok {
+			return fmt.Errorf("remote storage secret %s not found", ref.Name)
+		}
+		for _, key := range keys {
+			if _, ok := secret.Data[key]; !ok {
+				return fmt.Errorf("remote storage secret %s has no key %s", ref.Name, key)
+			}
+		}
+		return nil
+	}
+	for _, endpoint := range remoteStorageEndpoints(spec) {
+		if err := hasKeys(endpoint.basicAuth, corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey); err != nil {
+			return err
+		}
+		if err := hasKeys(endpoint.tls, corev1.TLSCertKey, corev1.TLSPrivateKeyKey); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 //go:embed alertmanager-config.yaml
@@ -508,7 +1323,7 @@ func (r *ReconcileMonitor) readAlertmanagerConfigSecret(ctx context.Context) (*c
 			Namespace: common.OperatorNamespace(),
 		},
 		Data: map[string][]byte{
-			"alertmanager.yaml": []byte(alertmanagerConfig),
+			alertmanagerConfigKey: []byte(alertmanagerConfig),
 		},
 	}
 
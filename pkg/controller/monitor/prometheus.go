@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
@@ -121,39 +123,37 @@ func addWatch(c ctrlruntime.Controller) error {
 	return err
 }
 
-func requiresPrometheusResources(client kubernetes.Interface) error {
-	resources, err := client.Discovery().ServerResourcesForGroupVersion("monitoring.coreos.com/v1")
-	if err != nil {
-		return err
+// requiresPrometheusResources returns the Prometheus CRDs that the monitor controller depends on but
+// which are not available in the cluster.
+func requiresPrometheusResources(client kubernetes.Interface) ([]schema.GroupKind, error) {
+	expectedKinds := []string{
+		monitoringv1.AlertmanagersKind,
+		monitoringv1.PodMonitorsKind,
+		monitoringv1.PrometheusesKind,
+		monitoringv1.ServiceMonitorsKind,
 	}
 
-	expectedResources := map[string]bool{
-		monitoringv1.AlertmanagersKind:   false,
-		monitoringv1.PodMonitorsKind:     false,
-		monitoringv1.PrometheusesKind:    false,
-		monitoringv1.ServiceMonitorsKind: false,
+	found := map[string]bool{}
+	resources, err := client.Discovery().ServerResourcesForGroupVersion(monitoringv1.SchemeGroupVersion.String())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
 	}
-
-	for _, r := range resources.APIResources {
-		switch r.Kind {
-		case monitoringv1.AlertmanagersKind,
-			monitoringv1.PodMonitorsKind,
-			monitoringv1.PrometheusesKind,
-			monitoringv1.ServiceMonitorsKind:
-			expectedResources[r.Kind] = true
+	if resources != nil {
+		for _, r := range resources.APIResources {
+			found[r.Kind] = true
 		}
 	}
 
-	for k, v := range expectedResources {
-		if !v {
-			return fmt.Errorf("failed to find Prometheus resource: %s", k)
+	var missing []schema.GroupKind
+	for _, k := range expectedKinds {
+		if !found[k] {
+			missing = append(missing, schema.GroupKind{Group: monitoringv1.SchemeGroupVersion.Group, Kind: k})
 		}
 	}
-
-	return nil
+	return missing, nil
 }
 
-func waitToAddPrometheusWatch(c ctrlruntime.Controller, client kubernetes.Interface, log logr.Logger, readyFlag *utils.ReadyFlag) {
+func waitToAddPrometheusWatch(c ctrlruntime.Controller, client kubernetes.Interface, log logr.Logger, readyFlag *utils.ReadyFlag, missingCRDs *utils.MissingCRDs) {
 	const (
 		initBackoff = 30 * time.Second
 		maxBackoff  = 8 * time.Minute
@@ -169,9 +169,13 @@ func waitToAddPrometheusWatch(c ctrlruntime.Controller, client kubernetes.Interf
 		}
 		ticker.Reset(duration)
 
-		if err := requiresPrometheusResources(client); err != nil {
+		if missing, err := requiresPrometheusResources(client); err != nil {
 			log.Info(fmt.Sprintf("%v. monitor-controller will retry.", err))
+		} else if len(missing) > 0 {
+			missingCRDs.Set(missing...)
+			log.Info(fmt.Sprintf("failed to find Prometheus resources %v. monitor-controller will retry.", missing))
 		} else {
+			missingCRDs.Set()
 			// watch for prometheus resource changes
 			if err := addWatch(c); err != nil {
 				log.Info(fmt.Sprintf("%v. monitor-controller will retry.", err))
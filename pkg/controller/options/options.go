@@ -16,11 +16,18 @@ package options
 
 import (
 	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 
 	v1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// DefaultLeaderElectionID is the name of the lease the operator uses for leader election by default.
+const DefaultLeaderElectionID = "operator-lock"
+
 // AddOptions are passed to controllers when added to the controller manager. They
 // detail options detected by the daemon at startup that some controllers may either
 // use to determine if they should run at all, or store them and influence their
@@ -45,4 +52,71 @@ type AddOptions struct {
 
 	// Whether or not the cluster supports PodSecurityPolicies.
 	UsePSP bool
+
+	// Whether or not the operator should render a NetworkPolicy restricting the egress
+	// of its own pod.
+	OperatorNetworkPolicy bool
+
+	// Whether or not the operator should issue its own webhook server certificate and inject its
+	// CA into the operator's webhook configurations.
+	ManageWebhookServerCert bool
+
+	// ResyncPeriod is the interval at which the apiserver, monitor and compliance controllers
+	// reconcile regardless of watch events. Periodic reconciles are disabled when zero.
+	ResyncPeriod time.Duration
+
+	// Whether or not the apiserver controller should warn when the images of the ImageSet in use
+	// are not multi-arch manifests.
+	VerifyMultiArchImages bool
+
+	// CertificateDuration is the validity of the key pairs that the apiserver, monitor and compliance
+	// controllers issue. The default certificate duration is used when zero.
+	CertificateDuration time.Duration
+
+	// CertificateRenewalWindow is how long before expiry the apiserver, monitor and compliance controllers
+	// renew the key pairs they issue. The default renewal window is used when zero.
+	CertificateRenewalWindow time.Duration
+
+	// Whether or not the apiserver, monitor and compliance controllers should log the changes of each
+	// new generation of their CR's spec that they reconcile.
+	AuditSpecChanges bool
+
+	// DependencyWaitTimeout is how long the monitor controller waits for the operators it depends on,
+	// such as the Prometheus operator, to install their CRDs before it reports that the dependency is
+	// missing. The controller waits indefinitely when zero.
+	DependencyWaitTimeout time.Duration
+
+	// RequeueInterval is how long the apiserver, monitor and compliance controllers wait before reconciling
+	// again when the resources they depend on are not yet available. utils.StandardRetry is used when zero.
+	RequeueInterval time.Duration
+
+	// Whether or not the apiserver, monitor and compliance controllers only log the objects they would create,
+	// update or delete, and the changes they would make to existing objects, instead of writing them.
+	DryRun bool
+
+	// Whether or not the apiserver, monitor and compliance controllers create and update the objects they
+	// manage using server-side apply instead of client-side creates and updates.
+	UseServerSideApply bool
+
+	// UnmanagedResourceSelector selects existing resources that the apiserver, monitor and compliance controllers
+	// do not update, so that they can be managed manually. Nothing is selected when nil.
+	UnmanagedResourceSelector labels.Selector
+
+	// LeaderElectionID is the name of the lease the controller manager uses for leader election.
+	// DefaultLeaderElectionID is used when empty.
+	LeaderElectionID string
+
+	// LeaderElectionNamespace is the namespace of the lease the controller manager uses for leader
+	// election. The namespace the operator runs in is used when empty.
+	LeaderElectionNamespace string
+}
+
+// ConfigureLeaderElection sets the leader election lease of the controller manager options, so that
+// operators which must not compete for leadership, such as one per tenant, can use distinct leases.
+func (o AddOptions) ConfigureLeaderElection(mgrOpts *manager.Options) {
+	mgrOpts.LeaderElectionID = o.LeaderElectionID
+	if mgrOpts.LeaderElectionID == "" {
+		mgrOpts.LeaderElectionID = DefaultLeaderElectionID
+	}
+	mgrOpts.LeaderElectionNamespace = o.LeaderElectionNamespace
 }
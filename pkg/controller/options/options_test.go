@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ = Describe("leader election", func() {
+	It("should use the default lease when none is configured", func() {
+		mgrOpts := manager.Options{LeaderElection: true}
+		AddOptions{}.ConfigureLeaderElection(&mgrOpts)
+		Expect(mgrOpts.LeaderElection).To(BeTrue())
+		Expect(mgrOpts.LeaderElectionID).To(Equal("operator-lock"))
+		Expect(mgrOpts.LeaderElectionNamespace).To(BeEmpty())
+	})
+
+	It("should use the configured lease name and namespace", func() {
+		mgrOpts := manager.Options{LeaderElection: true}
+		AddOptions{
+			LeaderElectionID:        "tenant-a-operator-lock",
+			LeaderElectionNamespace: "tenant-a",
+		}.ConfigureLeaderElection(&mgrOpts)
+		Expect(mgrOpts.LeaderElectionID).To(Equal("tenant-a-operator-lock"))
+		Expect(mgrOpts.LeaderElectionNamespace).To(Equal("tenant-a"))
+	})
+})
@@ -90,6 +90,7 @@ var _ = Describe("PolicyRecommendation controller tests", func() {
 		mockStatus.On("SetDegraded", operatorv1.ResourceCreateError, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return().Maybe()
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 		mockStatus.On("SetMetaData", mock.Anything).Return()
 
 		// Create an object we can use throughout the test to do the compliance reconcile loops.
@@ -188,15 +189,10 @@ var _ = Describe("PolicyRecommendation controller tests", func() {
 		})
 
 		It("should use images from imageset", func() {
-			Expect(c.Create(ctx, &operatorv1.ImageSet{
-				ObjectMeta: metav1.ObjectMeta{Name: "enterprise-" + components.EnterpriseRelease},
-				Spec: operatorv1.ImageSetSpec{
-					Images: []operatorv1.Image{
-						{Image: "tigera/policy-recommendation", Digest: "sha256:policyrecommendationcontrollerhash"},
-						{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
-					},
-				},
-			})).ToNot(HaveOccurred())
+			Expect(c.Create(ctx, test.CompleteImageSet(operatorv1.TigeraSecureEnterprise,
+				operatorv1.Image{Image: "tigera/policy-recommendation", Digest: "sha256:policyrecommendationcontrollerhash"},
+				operatorv1.Image{Image: "tigera/key-cert-provisioner", Digest: "sha256:deadbeef0123456789"},
+			))).ToNot(HaveOccurred())
 
 			_, err := r.Reconcile(ctx, reconcile.Request{})
 			Expect(err).ShouldNot(HaveOccurred())
@@ -491,6 +487,7 @@ var _ = Describe("PolicyRecommendation controller tests", func() {
 			mockStatus.On("SetDegraded", operatorv1.ResourceNotReady, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return().Maybe()
 			mockStatus.On("ReadyToMonitor")
 			mockStatus.On("RemoveCertificateSigningRequests", mock.Anything)
+			mockStatus.On("RemoveCertificateRenewals", mock.Anything)
 			mockStatus.On("SetMetaData", mock.Anything).Return()
 		})
 
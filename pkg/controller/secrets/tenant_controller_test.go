@@ -150,6 +150,7 @@ var _ = Describe("Tenant controller", func() {
 		mockStatus.On("ReadyToMonitor")
 		mockStatus.On("ClearDegraded")
 		mockStatus.On("RemoveCertificateSigningRequests", mock.Anything).Return()
+		mockStatus.On("RemoveCertificateRenewals", mock.Anything).Return()
 		r, err = NewTenantControllerWithShims(cli, scheme, mockStatus, dns.DefaultClusterDomain)
 		Expect(err).ShouldNot(HaveOccurred())
 	})
@@ -67,6 +67,10 @@ func (m *MockStatus) AddCertificateSigningRequests(name string, labels map[strin
 	m.Called(name)
 }
 
+func (m *MockStatus) AddCertificateRenewals(name string, keyPairs []string) {
+	m.Called(name, keyPairs)
+}
+
 func (m *MockStatus) RemoveDaemonsets(dss ...types.NamespacedName) {
 	m.Called(dss)
 }
@@ -87,6 +91,10 @@ func (m *MockStatus) RemoveCertificateSigningRequests(label string) {
 	m.Called(label)
 }
 
+func (m *MockStatus) RemoveCertificateRenewals(name string) {
+	m.Called(name)
+}
+
 func (m *MockStatus) SetDegraded(reason operator.TigeraStatusReason, msg string, err error, log logr.Logger) {
 	if err != nil {
 		m.Called(reason, msg, err.Error(), log)
@@ -36,6 +36,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -65,11 +66,13 @@ type StatusManager interface {
 	AddStatefulSets(sss []types.NamespacedName)
 	AddCronJobs(cjs []types.NamespacedName)
 	AddCertificateSigningRequests(name string, labels map[string]string)
+	AddCertificateRenewals(name string, keyPairs []string)
 	RemoveDaemonsets(dss ...types.NamespacedName)
 	RemoveDeployments(dps ...types.NamespacedName)
 	RemoveStatefulSets(sss ...types.NamespacedName)
 	RemoveCronJobs(cjs ...types.NamespacedName)
 	RemoveCertificateSigningRequests(name string)
+	RemoveCertificateRenewals(name string)
 	SetDegraded(reason operator.TigeraStatusReason, msg string, err error, log logr.Logger)
 	ClearDegraded()
 	IsAvailable() bool
@@ -87,6 +90,7 @@ type statusManager struct {
 	statefulsets              map[string]types.NamespacedName
 	cronjobs                  map[string]types.NamespacedName
 	certificatestatusrequests map[string]map[string]string
+	certificaterenewals       map[string][]string
 	lock                      sync.Mutex
 	enabled                   *bool
 	kubernetesVersion         *common.VersionInfo
@@ -111,9 +115,26 @@ type statusManager struct {
 	crExists bool
 
 	observedGeneration int64
+
+	// recorder, if set, records an Event on eventTarget each time the component becomes degraded.
+	recorder    record.EventRecorder
+	eventTarget client.Object
+}
+
+// Option configures optional behaviour of a status manager.
+type Option func(m *statusManager)
+
+// WithEventRecorder makes the status manager record a Warning Event on the given CR each time the component becomes
+// degraded, or is degraded for a different reason, so that the reason is visible in the cluster's events as well as
+// in the TigeraStatus. Only the name and namespace of the CR need to be set.
+func WithEventRecorder(recorder record.EventRecorder, cr client.Object) Option {
+	return func(m *statusManager) {
+		m.recorder = recorder
+		m.eventTarget = cr
+	}
 }
 
-func New(client client.Client, component string, kubernetesVersion *common.VersionInfo) StatusManager {
+func New(client client.Client, component string, kubernetesVersion *common.VersionInfo, opts ...Option) StatusManager {
 	// Best-effort initialization of CR status by checking for its existence.
 	crExists := true
 	ts := &operator.TigeraStatus{}
@@ -125,7 +146,7 @@ func New(client client.Client, component string, kubernetesVersion *common.Versi
 		crExists = false
 	}
 
-	return &statusManager{
+	m := &statusManager{
 		client:                    client,
 		component:                 component,
 		daemonsets:                make(map[string]types.NamespacedName),
@@ -133,9 +154,14 @@ func New(client client.Client, component string, kubernetesVersion *common.Versi
 		statefulsets:              make(map[string]types.NamespacedName),
 		cronjobs:                  make(map[string]types.NamespacedName),
 		certificatestatusrequests: make(map[string]map[string]string),
+		certificaterenewals:       make(map[string][]string),
 		kubernetesVersion:         kubernetesVersion,
 		crExists:                  crExists,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *statusManager) updateStatus() {
@@ -308,6 +334,14 @@ func (m *statusManager) AddCertificateSigningRequests(name string, labels map[st
 	m.certificatestatusrequests[name] = labels
 }
 
+// AddCertificateRenewals tells the status manager that the given key pairs are being renewed, so that the
+// component is reported as progressing until the renewals are removed again.
+func (m *statusManager) AddCertificateRenewals(name string, keyPairs []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.certificaterenewals[name] = keyPairs
+}
+
 // RemoveDaemonsets tells the status manager to stop monitoring the health of the given daemonsets
 func (m *statusManager) RemoveDaemonsets(dss ...types.NamespacedName) {
 	m.lock.Lock()
@@ -351,6 +385,13 @@ func (m *statusManager) RemoveCertificateSigningRequests(name string) {
 	delete(m.certificatestatusrequests, name)
 }
 
+// RemoveCertificateRenewals tells the status manager that the key pairs renewed under the given name are in place.
+func (m *statusManager) RemoveCertificateRenewals(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.certificaterenewals, name)
+}
+
 // SetDegraded sets degraded state with the provided reason and message.
 func (m *statusManager) SetDegraded(reason operator.TigeraStatusReason, msg string, err error, log logr.Logger) {
 	log.WithValues("reason", string(reason)).Error(err, msg)
@@ -358,11 +399,36 @@ func (m *statusManager) SetDegraded(reason operator.TigeraStatusReason, msg stri
 	if err != nil {
 		errormsg = err.Error()
 	}
+	degradedMsg := fmt.Sprintf("%s: %s", msg, errormsg)
 	m.lock.Lock()
-	defer m.lock.Unlock()
+	transitioned := !m.degraded || m.explicitDegradedReason != reason || m.explicitDegradedMsg != degradedMsg
 	m.degraded = true
 	m.explicitDegradedReason = reason
-	m.explicitDegradedMsg = fmt.Sprintf("%s: %s", msg, errormsg)
+	m.explicitDegradedMsg = degradedMsg
+	m.lock.Unlock()
+
+	if transitioned {
+		eventMsg := msg
+		if errormsg != "" {
+			eventMsg = degradedMsg
+		}
+		m.recordDegradedEvent(reason, eventMsg)
+	}
+}
+
+// recordDegradedEvent records a Warning Event for the degraded reason on the CR of the component, if the status
+// manager has an event recorder.
+func (m *statusManager) recordDegradedEvent(reason operator.TigeraStatusReason, msg string) {
+	if m.recorder == nil {
+		return
+	}
+	// Read the CR so that the Event refers to its UID, and is listed when the CR is described.
+	cr := m.eventTarget.DeepCopyObject().(client.Object)
+	if err := m.client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr); err != nil {
+		log.V(1).Info("Not recording degraded event, unable to read the CR", "component", m.component, "error", err)
+		return
+	}
+	m.recorder.Event(cr, corev1.EventTypeWarning, string(reason), msg)
 }
 
 // ClearDegraded clears degraded state.
@@ -522,16 +588,15 @@ func (m *statusManager) syncState() {
 			log.WithValues("reason", err).Info("Failed to query statefulset")
 			continue
 		}
-		if *ss.Spec.Replicas != ss.Status.CurrentReplicas {
-			progressing = append(progressing, fmt.Sprintf("Statefulset %q is not available (awaiting %d replicas)", depnn.String(), ss.Status.CurrentReplicas-*ss.Spec.Replicas))
-		} else if ss.Status.ObservedGeneration < ss.Generation {
-			progressing = append(progressing, fmt.Sprintf("Statefulset %q update is being processed (generation %d, observed generation %d)", ss.String(), ss.Generation, ss.Status.ObservedGeneration))
-		}
-
 		replicas := int32(1)
 		if ss.Spec.Replicas != nil {
 			replicas = *ss.Spec.Replicas
 		}
+		if replicas != ss.Status.CurrentReplicas {
+			progressing = append(progressing, fmt.Sprintf("Statefulset %q is not available (awaiting %d replicas)", depnn.String(), replicas-ss.Status.CurrentReplicas))
+		} else if ss.Status.ObservedGeneration < ss.Generation {
+			progressing = append(progressing, fmt.Sprintf("Statefulset %q update is being processed (generation %d, observed generation %d)", ss.String(), ss.Generation, ss.Status.ObservedGeneration))
+		}
 		// There could be old pods in the Errored, Terminated, or Completed state
 		// but if the following are true then we don't need to worry about those
 		// failed pods so continue.
@@ -587,6 +652,10 @@ func (m *statusManager) syncState() {
 		}
 	}
 
+	for _, keyPairs := range m.certificaterenewals {
+		progressing = append(progressing, fmt.Sprintf("Renewing certificate(s) %v that are about to expire", keyPairs))
+	}
+
 	m.progressing = progressing
 	m.failing = failing
 	m.hasSynced = true
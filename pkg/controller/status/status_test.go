@@ -16,6 +16,7 @@ package status
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -29,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	controllerRuntimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -389,6 +391,32 @@ var _ = Describe("Status reporting tests", func() {
 				sm.updateStatus()
 				Expect(sm.IsDegraded()).To(BeTrue())
 			})
+			It("should report the number of replicas a statefulset is awaiting", func() {
+				sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS1"}})
+				replicas := int32(3)
+
+				Expect(client.Create(ctx, &appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "NS1", Name: "SS1",
+						Generation: gen,
+					},
+					Spec: appsv1.StatefulSetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"ss1Key": "ss1Value"},
+						},
+						Replicas: &replicas,
+					},
+					Status: appsv1.StatefulSetStatus{
+						ObservedGeneration: gen,
+						Replicas:           1,
+						ReadyReplicas:      1,
+						CurrentReplicas:    1,
+						UpdatedReplicas:    1,
+					},
+				})).NotTo(HaveOccurred())
+				sm.updateStatus()
+				Expect(sm.progressing).To(ContainElement(`Statefulset "NS1/SS1" is not available (awaiting 2 replicas)`))
+			})
 		})
 
 		It("Should handle basic state changes", func() {
@@ -459,6 +487,41 @@ var _ = Describe("Status reporting tests", func() {
 			Expect(sm.degradedMessage()).To(Equal("Controller set us degraded: \nThis pod has died"))
 		})
 
+		Context("with an event recorder", func() {
+			var recorder *record.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = record.NewFakeRecorder(10)
+				Expect(client.Create(ctx, &operator.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+				sm = New(client, "test-component", &common.VersionInfo{Major: 1, Minor: 19},
+					WithEventRecorder(recorder, &operator.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).(*statusManager)
+			})
+
+			It("should record a warning event when the component becomes degraded", func() {
+				sm.SetDegraded(operator.ResourceReadError, "Error querying installation", fmt.Errorf("boom"), log)
+				Expect(recorder.Events).To(Receive(Equal("Warning ResourceReadError Error querying installation: boom")))
+
+				sm.SetDegraded(operator.ResourceNotReady, "Waiting for Tier watch to be established", nil, log)
+				Expect(recorder.Events).To(Receive(Equal("Warning ResourceNotReady Waiting for Tier watch to be established")))
+			})
+
+			It("should not record an event again while the component stays degraded for the same reason", func() {
+				sm.SetDegraded(operator.ResourceReadError, "Error querying installation", nil, log)
+				sm.SetDegraded(operator.ResourceReadError, "Error querying installation", nil, log)
+				Expect(recorder.Events).To(HaveLen(1))
+
+				sm.ClearDegraded()
+				sm.SetDegraded(operator.ResourceReadError, "Error querying installation", nil, log)
+				Expect(recorder.Events).To(HaveLen(2))
+			})
+
+			It("should not record an event if the CR does not exist", func() {
+				Expect(client.Delete(ctx, &operator.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).NotTo(HaveOccurred())
+				sm.SetDegraded(operator.ResourceReadError, "Error querying installation", nil, log)
+				Expect(recorder.Events).To(BeEmpty())
+			})
+		})
+
 		It("should contain all the NamespacesNames for all the resources added by multiple calls to Set<Resources>", func() {
 			sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS1"}})
 			sm.AddStatefulSets([]types.NamespacedName{{Namespace: "NS1", Name: "SS2"}})
@@ -536,6 +599,19 @@ var _ = Describe("Status reporting tests", func() {
 			}))
 		})
 
+		It("should report progressing while certificates are being renewed", func() {
+			sm.ReadyToMonitor()
+			sm.AddCertificateRenewals("NS1", []string{"NS1/cert1"})
+			sm.syncState()
+			Expect(sm.IsProgressing()).To(BeTrue())
+			Expect(sm.progressingMessage()).To(ContainSubstring("NS1/cert1"))
+
+			sm.RemoveCertificateRenewals("NS1")
+			sm.syncState()
+			Expect(sm.IsProgressing()).To(BeFalse())
+			Expect(sm.IsAvailable()).To(BeTrue())
+		})
+
 		DescribeTable("Monitor CSRs - k8s v1.18",
 			func(csrs []*certV1beta1.CertificateSigningRequest, expectErr bool, expectPending bool) {
 				for _, csr := range csrs {
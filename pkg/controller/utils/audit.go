@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SpecAuditor keeps an audit trail of the CR spec changes a controller acts on. Each time it sees a new generation of a
+// CR, it logs the top-level spec fields that changed since the generation it saw before, with their values before and
+// after the change. CR specs only reference secrets, so they are logged as is.
+type SpecAuditor struct {
+	log  logr.Logger
+	lock sync.Mutex
+	seen map[types.NamespacedName]auditedSpec
+}
+
+type auditedSpec struct {
+	generation int64
+	fields     map[string]json.RawMessage
+}
+
+// NewSpecAuditor returns a SpecAuditor that writes its audit records to the given logger.
+func NewSpecAuditor(log logr.Logger) *SpecAuditor {
+	return &SpecAuditor{log: log, seen: map[types.NamespacedName]auditedSpec{}}
+}
+
+// Record logs the changes to spec if obj has a generation that was not recorded before. The first generation recorded
+// for a CR is logged in full, as there is nothing to compare it to.
+func (a *SpecAuditor) Record(obj client.Object, spec any) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := client.ObjectKeyFromObject(obj)
+	prev, found := a.seen[key]
+	if found && prev.generation == obj.GetGeneration() {
+		return
+	}
+
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		a.log.Error(err, "Failed to record spec", "name", key.String(), "generation", obj.GetGeneration())
+		return
+	}
+	fields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		a.log.Error(err, "Failed to record spec", "name", key.String(), "generation", obj.GetGeneration())
+		return
+	}
+	a.seen[key] = auditedSpec{generation: obj.GetGeneration(), fields: fields}
+
+	changes := specChanges(prev.fields, fields)
+	if len(changes) == 0 {
+		return
+	}
+	a.log.Info("Reconciling a changed spec", "name", key.String(), "generation", obj.GetGeneration(),
+		"previousGeneration", prev.generation, "changes", changes)
+}
+
+// specChanges returns a sorted description of the fields that differ between before and after.
+func specChanges(before, after map[string]json.RawMessage) []string {
+	names := map[string]bool{}
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+
+	var changes []string
+	for name := range names {
+		b, a := before[name], after[name]
+		if bytes.Equal(b, a) {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("spec.%s: %s -> %s", name, auditValue(b), auditValue(a)))
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func auditValue(v json.RawMessage) string {
+	if v == nil {
+		return "<unset>"
+	}
+	return string(v)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils_test
+
+import (
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/utils"
+)
+
+var _ = Describe("SpecAuditor", func() {
+	var records []string
+	var auditor *utils.SpecAuditor
+	var instance *operatorv1.APIServer
+
+	BeforeEach(func() {
+		records = nil
+		auditor = utils.NewSpecAuditor(funcr.New(func(prefix, args string) {
+			records = append(records, args)
+		}, funcr.Options{}))
+		instance = &operatorv1.APIServer{ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1}}
+	})
+
+	It("should record the first generation of a CR in full", func() {
+		instance.Spec.LivezExcludedChecks = []string{"etcd"}
+		auditor.Record(instance, instance.Spec)
+
+		Expect(records).To(HaveLen(1))
+		Expect(records[0]).To(ContainSubstring(`"generation"=1`))
+		Expect(records[0]).To(ContainSubstring(`spec.livezExcludedChecks: <unset> -> [\"etcd\"]`))
+	})
+
+	It("should record the before and after of the fields changed by a new generation", func() {
+		instance.Spec.LivezExcludedChecks = []string{"etcd"}
+		auditor.Record(instance, instance.Spec)
+
+		instance.Generation = 2
+		instance.Spec.LivezExcludedChecks = nil
+		instance.Spec.ReadyzExcludedChecks = []string{"informer-sync"}
+		auditor.Record(instance, instance.Spec)
+
+		Expect(records).To(HaveLen(2))
+		Expect(records[1]).To(ContainSubstring(`"generation"=2 "previousGeneration"=1`))
+		Expect(records[1]).To(ContainSubstring(`spec.livezExcludedChecks: [\"etcd\"] -> <unset>`))
+		Expect(records[1]).To(ContainSubstring(`spec.readyzExcludedChecks: <unset> -> [\"informer-sync\"]`))
+	})
+
+	It("should not record a generation that was recorded before", func() {
+		instance.Spec.LivezExcludedChecks = []string{"etcd"}
+		auditor.Record(instance, instance.Spec)
+		auditor.Record(instance, instance.Spec)
+
+		Expect(records).To(HaveLen(1))
+	})
+
+	It("should not record a new generation without spec changes", func() {
+		instance.Spec.LivezExcludedChecks = []string{"etcd"}
+		auditor.Record(instance, instance.Spec)
+		instance.Generation = 2
+		auditor.Record(instance, instance.Spec)
+
+		Expect(records).To(HaveLen(1))
+	})
+})
@@ -16,8 +16,10 @@ package utils
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -31,38 +33,111 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	operatorv1 "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/controller/utils/imageset"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 )
 
+// FieldManager is the field manager the operator uses when applying resources with server-side apply.
+const FieldManager = "tigera-operator"
+
+// clientSideFieldManager is the field manager the API server records for the operator's client-side creates and
+// updates, which set no field manager. The API server then uses the prefix of the client's user agent.
+var clientSideFieldManager = strings.Split(rest.DefaultKubernetesUserAgent(), "/")[0]
+
 type ComponentHandler interface {
 	CreateOrUpdateOrDelete(context.Context, render.Component, status.StatusManager) error
+
+	// CreateOrUpdateOrDeleteAll resolves the images of the given components from the ImageSet, which may be nil, and
+	// reconciles them, applying each component that implements render.ComponentWithDependencies after the components
+	// that create its dependencies. If a dependency does not exist by the time its dependent component is applied, a
+	// DependencyNotReadyError is returned so that the caller can requeue the request.
+	CreateOrUpdateOrDeleteAll(context.Context, *operatorv1.ImageSet, []render.Component, status.StatusManager) error
+}
+
+// ComponentHandlerOption configures optional behaviour of a component handler.
+type ComponentHandlerOption func(c *componentHandler)
+
+// WithAdditionalMetadata makes the component handler add the given labels and annotations to every object it creates
+// or updates. Labels and annotations that the rendered object already sets are left unchanged, so that the ones the
+// operator manages take precedence.
+func WithAdditionalMetadata(additionalLabels, additionalAnnotations map[string]string) ComponentHandlerOption {
+	return func(c *componentHandler) {
+		c.additionalLabels = additionalLabels
+		c.additionalAnnotations = additionalAnnotations
+	}
+}
+
+// WithServerSideApply makes the component handler create and update objects using server-side apply with the
+// FieldManager field manager, instead of client-side creates and updates.
+func WithServerSideApply(enabled bool) ComponentHandlerOption {
+	return func(c *componentHandler) {
+		c.serverSideApply = enabled
+	}
+}
+
+// WithUnmanagedSelector makes the component handler skip updating existing objects that match the given label
+// selector, so that they can be managed manually. A nil selector selects nothing.
+func WithUnmanagedSelector(selector labels.Selector) ComponentHandlerOption {
+	return func(c *componentHandler) {
+		c.unmanagedSelector = selector
+	}
+}
+
+// WithDryRun makes the component handler log the objects it would create, update or delete, along with the changes it
+// would make to existing objects, instead of writing them. The status manager is still updated as usual.
+func WithDryRun(enabled bool) ComponentHandlerOption {
+	return func(c *componentHandler) {
+		c.dryRun = enabled
+	}
 }
 
 // cr is allowed to be nil in the case we don't want to put ownership on a resource,
 // this is useful for CRD management so that they are not removed automatically.
-func NewComponentHandler(log logr.Logger, client client.Client, scheme *runtime.Scheme, cr metav1.Object) ComponentHandler {
-	return &componentHandler{
+func NewComponentHandler(log logr.Logger, client client.Client, scheme *runtime.Scheme, cr metav1.Object, opts ...ComponentHandlerOption) ComponentHandler {
+	c := &componentHandler{
 		client: client,
 		scheme: scheme,
 		cr:     cr,
 		log:    log,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 type componentHandler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	cr     metav1.Object
-	log    logr.Logger
+	client          client.Client
+	scheme          *runtime.Scheme
+	cr              metav1.Object
+	log             logr.Logger
+	serverSideApply bool
+
+	// unmanagedSelector selects existing resources that should not be updated. A nil selector selects nothing.
+	unmanagedSelector labels.Selector
+
+	// additionalLabels and additionalAnnotations are added to every object, unless the object already sets them.
+	additionalLabels      map[string]string
+	additionalAnnotations map[string]string
+
+	// dryRun, if set, logs the writes the handler would make instead of making them.
+	dryRun bool
 }
 
 func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.Object, osType rmeta.OSType) error {
@@ -106,6 +181,9 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	// Make sure we have our standard selector and pod labels
 	setStandardSelectorAndLabels(obj)
 
+	// Add any additional labels and annotations without overwriting the ones the object already sets.
+	c.setAdditionalMetadata(obj)
+
 	cur, ok := obj.DeepCopyObject().(client.Object)
 	if !ok {
 		logCtx.V(2).Info("Failed converting object", "obj", obj)
@@ -120,7 +198,18 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 		}
 
 		// Otherwise, if it was not found, we should create it and move on.
+		if c.dryRun {
+			logCtx.Info("Dry run: would create object")
+			return nil
+		}
 		logCtx.V(2).Info("Object does not exist, creating it", "error", err)
+		if c.serverSideApply {
+			if err = c.applyObject(ctx, obj, nil); err != nil {
+				logCtx.WithValues("key", key).Error(err, "Failed to apply object.")
+				return err
+			}
+			return nil
+		}
 		if multipleOwners {
 			labels := om.GetObjectMeta().GetLabels()
 			delete(labels, common.MultipleOwnersLabel)
@@ -139,10 +228,17 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 		logCtx.Info("Ignoring annotated object")
 		return nil
 	}
+	if c.unmanagedSelector != nil && c.unmanagedSelector.Matches(labels.Set(cur.GetLabels())) {
+		logCtx.Info("Skipping object matching the unmanaged resource selector")
+		return nil
+	}
 	logCtx.V(2).Info("Resource already exists, update it")
 
 	// if mergeState returns nil we don't want to update the object
 	if mobj := mergeState(obj, cur); mobj != nil {
+		if c.dryRun {
+			return c.logDryRunUpdate(logCtx, cur, mobj)
+		}
 		switch obj.(type) {
 		case *batchv1.Job:
 			// Jobs can't be updated, they can only be deleted then created
@@ -235,6 +331,15 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 				return nil
 			}
 		}
+		if c.serverSideApply {
+			// Apply the desired state rather than the merged state, so that the operator only takes ownership
+			// of the fields it renders and leaves fields managed by others untouched.
+			if err := c.applyObject(ctx, obj, cur); err != nil {
+				logCtx.WithValues("key", key).Info("Failed to apply object.")
+				return err
+			}
+			return nil
+		}
 		if err := c.client.Update(ctx, mobj); err != nil {
 			logCtx.WithValues("key", key).Info("Failed to update object.")
 			return err
@@ -243,6 +348,81 @@ func (c componentHandler) createOrUpdateObject(ctx context.Context, obj client.O
 	return nil
 }
 
+// applyObject creates or updates the given object using server-side apply with the operator's field manager. cur is
+// the current state of the object, or nil if it does not exist.
+func (c componentHandler) applyObject(ctx context.Context, obj, cur client.Object) error {
+	// Apply requests must include the object's apiVersion and kind.
+	if obj.GetObjectKind().GroupVersionKind().Empty() {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	// The resource version would act as a precondition on the apply, and managed fields may not be set on
+	// apply requests.
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	// Every controller applies with the same field manager, so an owner reference that is not part of the applied
+	// object would be removed. Keep the owner references of the other owners, as mergeState does for updates.
+	if checkIfMultipleOwnersLabel(obj) {
+		if cur != nil {
+			obj.SetOwnerReferences(common.MergeOwnerReferences(obj.GetOwnerReferences(), cur.GetOwnerReferences()))
+		}
+		labels := obj.GetLabels()
+		delete(labels, common.MultipleOwnersLabel)
+		obj.SetLabels(labels)
+	}
+
+	// The fields of objects the operator created or updated before it used server-side apply are owned by the
+	// client-side field manager. Take them over, so that fields the operator no longer renders are removed by
+	// the apply rather than left behind.
+	if cur != nil {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(cur, sets.New(clientSideFieldManager), FieldManager)
+		if err != nil {
+			return err
+		}
+		if patch != nil {
+			if err := c.client.Patch(ctx, cur, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.client.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// logDryRunUpdate logs the changes that updating current to desired would make.
+func (c componentHandler) logDryRunUpdate(logCtx logr.Logger, current, desired client.Object) error {
+	changes, err := objectChanges(current, desired)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	logCtx.Info("Dry run: would update object", "changes", changes)
+	return nil
+}
+
+// logDryRunDelete logs that the given object would be deleted, if it exists.
+func (c componentHandler) logDryRunDelete(ctx context.Context, obj client.Object) error {
+	cur, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed converting object %+v", obj)
+	}
+	if err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), cur); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	ContextLoggerForResource(c.log, obj).Info("Dry run: would delete object")
+	return nil
+}
+
 func resetMetadataForCreate(obj client.Object) {
 	obj.SetResourceVersion("")
 	obj.SetUID("")
@@ -320,7 +500,12 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	}
 
 	for _, obj := range objsToDelete {
-		err := c.client.Delete(ctx, obj)
+		var err error
+		if c.dryRun {
+			err = c.logDryRunDelete(ctx, obj)
+		} else {
+			err = c.client.Delete(ctx, obj)
+		}
 		if err != nil && !errors.IsNotFound(err) {
 			logCtx := ContextLoggerForResource(c.log, obj)
 			logCtx.Error(err, fmt.Sprintf("Error deleting object %v", obj))
@@ -350,6 +535,144 @@ func (c componentHandler) CreateOrUpdateOrDelete(ctx context.Context, component
 	return nil
 }
 
+func (c componentHandler) CreateOrUpdateOrDeleteAll(ctx context.Context, imageSet *operatorv1.ImageSet, components []render.Component, status status.StatusManager) error {
+	// Ordering renders the objects of every component, so their images must be resolved first.
+	if err := imageset.ResolveImages(imageSet, components...); err != nil {
+		return err
+	}
+	ordered, err := c.orderComponents(components)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range ordered {
+		if dc, ok := component.(render.ComponentWithDependencies); ok {
+			for _, dep := range dc.Dependencies() {
+				if err := c.checkDependency(ctx, component, dep); err != nil {
+					return err
+				}
+			}
+		}
+		if err := c.CreateOrUpdateOrDelete(ctx, component, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyKey identifies an object by its type, namespace and name.
+type dependencyKey struct {
+	gvk schema.GroupVersionKind
+	types.NamespacedName
+}
+
+func (c componentHandler) dependencyKeyFor(obj client.Object) (dependencyKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return dependencyKey{}, err
+	}
+	return dependencyKey{gvk: gvk, NamespacedName: client.ObjectKeyFromObject(obj)}, nil
+}
+
+// orderComponents returns the components sorted so that each component comes after the components that create its
+// dependencies. Components are otherwise kept in the order they were given.
+func (c componentHandler) orderComponents(components []render.Component) ([]render.Component, error) {
+	// Map each object that is created to the component that creates it.
+	creators := map[dependencyKey]int{}
+	for i, component := range components {
+		objsToCreate, _ := component.Objects()
+		for _, obj := range objsToCreate {
+			key, err := c.dependencyKeyFor(obj)
+			if err != nil {
+				return nil, err
+			}
+			creators[key] = i
+		}
+	}
+
+	// Build the set of components created before each component. Dependencies that are not created by any of the
+	// components are expected to already exist, and are checked when the dependent component is applied.
+	prerequisites := make([]map[int]bool, len(components))
+	for i, component := range components {
+		prerequisites[i] = map[int]bool{}
+		dc, ok := component.(render.ComponentWithDependencies)
+		if !ok {
+			continue
+		}
+		for _, dep := range dc.Dependencies() {
+			key, err := c.dependencyKeyFor(dep)
+			if err != nil {
+				return nil, err
+			}
+			if j, ok := creators[key]; ok && j != i {
+				prerequisites[i][j] = true
+			}
+		}
+	}
+
+	// Repeatedly take the first component whose prerequisites have all been applied.
+	var ordered []render.Component
+	applied := make([]bool, len(components))
+	for len(ordered) < len(components) {
+		next := -1
+		for i := range components {
+			if applied[i] {
+				continue
+			}
+			ready := true
+			for j := range prerequisites[i] {
+				if !applied[j] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			return nil, fmt.Errorf("components have circular dependencies")
+		}
+		applied[next] = true
+		ordered = append(ordered, components[next])
+	}
+	return ordered, nil
+}
+
+// DependencyNotReadyError is returned by CreateOrUpdateOrDeleteAll when an object that a component depends on does
+// not exist yet.
+type DependencyNotReadyError struct {
+	Component  string
+	Dependency string
+}
+
+func (e *DependencyNotReadyError) Error() string {
+	return fmt.Sprintf("component %s is not ready to be applied: dependency %s does not exist", e.Component, e.Dependency)
+}
+
+// IsDependencyNotReady returns whether the error reports that a component was not applied because one of its
+// dependencies does not exist yet.
+func IsDependencyNotReady(err error) bool {
+	var e *DependencyNotReadyError
+	return goerrors.As(err, &e)
+}
+
+// checkDependency returns a DependencyNotReadyError if the given dependency of the component does not exist.
+func (c componentHandler) checkDependency(ctx context.Context, component render.Component, dep client.Object) error {
+	key := client.ObjectKeyFromObject(dep)
+	if err := c.client.Get(ctx, key, dep.DeepCopyObject().(client.Object)); err != nil {
+		if errors.IsNotFound(err) {
+			return &DependencyNotReadyError{
+				Component:  reflect.TypeOf(component).String(),
+				Dependency: fmt.Sprintf("%T %s", dep, key),
+			}
+		}
+		return fmt.Errorf("failed to read dependency %T %s: %w", dep, key, err)
+	}
+	return nil
+}
+
 // skipAddingOwnerReference returns true if owner is a namespaced resource and
 // controlled object is a cluster scoped resource.
 func skipAddingOwnerReference(owner, controlled metav1.Object) bool {
@@ -745,6 +1068,17 @@ func setStandardSelectorAndLabels(obj client.Object) {
 	podTemplate.ObjectMeta.Labels["app.kubernetes.io/name"] = name
 }
 
+// setAdditionalMetadata adds the additional labels and annotations of the handler to the object, keeping the values
+// of any labels and annotations that the object already sets.
+func (c componentHandler) setAdditionalMetadata(obj client.Object) {
+	if len(c.additionalLabels) > 0 {
+		obj.SetLabels(common.MergeMaps(c.additionalLabels, common.MapExistsOrInitialize(obj.GetLabels())))
+	}
+	if len(c.additionalAnnotations) > 0 {
+		obj.SetAnnotations(common.MergeMaps(c.additionalAnnotations, common.MapExistsOrInitialize(obj.GetAnnotations())))
+	}
+}
+
 // ReadyFlag is used to synchronize access to a boolean flag
 // flag that can be shared between go routines. The flag can be
 // marked as ready once,as part of a initialization procedure and
@@ -767,3 +1101,42 @@ func (r *ReadyFlag) MarkAsReady() {
 	defer r.mu.Unlock()
 	r.isReady = true
 }
+
+// MissingCRDs is used to record the CRDs that a controller depends on but that
+// could not be found in the cluster. It can be shared between go routines so that
+// a background watch can report what is blocking the controller's reconcile.
+type MissingCRDs struct {
+	mu    sync.RWMutex
+	kinds []schema.GroupKind
+}
+
+// Set replaces the recorded missing CRDs.
+func (m *MissingCRDs) Set(kinds ...schema.GroupKind) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kinds = kinds
+}
+
+// Get returns the recorded missing CRDs.
+func (m *MissingCRDs) Get() []schema.GroupKind {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.kinds
+}
+
+// Message returns a user-facing message naming the missing CRDs, or an empty string
+// if no CRDs are recorded as missing.
+func (m *MissingCRDs) Message() string {
+	kinds := m.Get()
+	if len(kinds) == 0 {
+		return ""
+	}
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.String()
+	}
+	return fmt.Sprintf("Waiting for required CRD(s) to be installed: %s", strings.Join(names, ", "))
+}
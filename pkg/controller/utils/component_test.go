@@ -34,8 +34,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	restMeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -528,6 +530,57 @@ var _ = Describe("Component handler tests", func() {
 		Expect(ns.GetLabels()).To(Equal(expectedLabels))
 	})
 
+	It("adds additional labels and annotations without overwriting the ones the operator sets", func() {
+		handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, instance, WithAdditionalMetadata(
+			map[string]string{"gitops.example.com/owner": "platform", "k8s-app": "gitops", fakeComponentLabelKey: "gitops"},
+			map[string]string{"gitops.example.com/source": "cluster-config", fakeComponentAnnotationKey: "gitops"},
+		))
+		fc := &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs: []client.Object{
+				&apps.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test-deployment",
+						Namespace:   "default",
+						Labels:      map[string]string{fakeComponentLabelKey: fakeComponentLabelValue},
+						Annotations: map[string]string{fakeComponentAnnotationKey: fakeComponentAnnotationValue},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "default"},
+				},
+			},
+		}
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+		d := &apps.Deployment{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-deployment", Namespace: "default"}, d)).NotTo(HaveOccurred())
+		Expect(d.Labels).To(Equal(map[string]string{
+			"gitops.example.com/owner": "platform",
+			"k8s-app":                  "test-deployment",
+			"app.kubernetes.io/name":   "test-deployment",
+			fakeComponentLabelKey:      fakeComponentLabelValue,
+		}))
+		Expect(d.Annotations).To(Equal(map[string]string{
+			"gitops.example.com/source": "cluster-config",
+			fakeComponentAnnotationKey:  fakeComponentAnnotationValue,
+		}))
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-secret", Namespace: "default"}, secret)).NotTo(HaveOccurred())
+		Expect(secret.Labels).To(HaveKeyWithValue("gitops.example.com/owner", "platform"))
+		Expect(secret.Annotations).To(HaveKeyWithValue("gitops.example.com/source", "cluster-config"))
+
+		By("adding them to existing objects on update")
+		Expect(c.Delete(ctx, secret)).NotTo(HaveOccurred())
+		Expect(c.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "default"}})).NotTo(HaveOccurred())
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+		secret = &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "test-secret", Namespace: "default"}, secret)).NotTo(HaveOccurred())
+		Expect(secret.Labels).To(HaveKeyWithValue("gitops.example.com/owner", "platform"))
+		Expect(secret.Annotations).To(HaveKeyWithValue("gitops.example.com/source", "cluster-config"))
+	})
+
 	DescribeTable("ensuring ImagePullPolicy is set", func(obj client.Object) {
 		modifyPodSpec(obj, setImagePullPolicy)
 
@@ -1027,6 +1080,74 @@ var _ = Describe("Component handler tests", func() {
 		},
 	)
 
+	Context("components with dependencies", func() {
+		var tier *v3.Tier
+		var policy *v3.NetworkPolicy
+
+		BeforeEach(func() {
+			tier = &v3.Tier{
+				TypeMeta:   metav1.TypeMeta{Kind: "Tier", APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tier"},
+			}
+			policy = &v3.NetworkPolicy{
+				TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tier.test-policy", Namespace: "test-namespace"},
+				Spec:       v3.NetworkPolicySpec{Tier: "test-tier"},
+			}
+		})
+
+		It("applies components after the components that create their dependencies", func() {
+			policyComponent := &fakeDependentComponent{
+				fakeComponent: fakeComponent{supportedOSType: rmeta.OSTypeAny, objs: []client.Object{policy}},
+				dependencies:  []client.Object{&v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "test-tier"}}},
+			}
+			tierComponent := &fakeComponent{supportedOSType: rmeta.OSTypeAny, objs: []client.Object{tier}}
+
+			// The policy component is listed first, but must be applied after the tier component. If it were not, the
+			// tier would not exist when the policy component's dependencies are checked.
+			Expect(handler.CreateOrUpdateOrDeleteAll(ctx, nil, []render.Component{policyComponent, tierComponent}, sm)).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(tier), &v3.Tier{})).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), &v3.NetworkPolicy{})).To(Succeed())
+
+			By("resolving the images of the components before rendering them")
+			Expect(policyComponent.imagesResolved).To(BeTrue())
+			Expect(policyComponent.renderedUnresolved).To(BeFalse())
+		})
+
+		It("returns an error and does not apply a component whose dependencies do not exist", func() {
+			policyComponent := &fakeDependentComponent{
+				fakeComponent: fakeComponent{supportedOSType: rmeta.OSTypeAny, objs: []client.Object{policy}},
+				dependencies:  []client.Object{&v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "test-tier"}}},
+			}
+
+			err := handler.CreateOrUpdateOrDeleteAll(ctx, nil, []render.Component{policyComponent}, sm)
+			Expect(err).To(HaveOccurred())
+			Expect(IsDependencyNotReady(err)).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(policy), &v3.NetworkPolicy{}))).To(BeTrue())
+
+			// Once the dependency has been created, the component is applied.
+			Expect(c.Create(ctx, tier)).To(Succeed())
+			Expect(handler.CreateOrUpdateOrDeleteAll(ctx, nil, []render.Component{policyComponent}, sm)).To(Succeed())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), &v3.NetworkPolicy{})).To(Succeed())
+		})
+
+		It("returns an error if components depend on each other", func() {
+			tierComponent := &fakeDependentComponent{
+				fakeComponent: fakeComponent{supportedOSType: rmeta.OSTypeAny, objs: []client.Object{tier}},
+				dependencies:  []client.Object{policy},
+			}
+			policyComponent := &fakeDependentComponent{
+				fakeComponent: fakeComponent{supportedOSType: rmeta.OSTypeAny, objs: []client.Object{policy}},
+				dependencies:  []client.Object{tier},
+			}
+
+			err := handler.CreateOrUpdateOrDeleteAll(ctx, nil, []render.Component{tierComponent, policyComponent}, sm)
+			Expect(err).To(MatchError(ContainSubstring("circular dependencies")))
+			Expect(IsDependencyNotReady(err)).To(BeFalse())
+			Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(tier), &v3.Tier{}))).To(BeTrue())
+		})
+	})
+
 	It("recreates a service if its ClusterIP is removed", func() {
 		// Simulate creation of a service by earlier version of operator that includes a ClusterIP.
 		svcWithIP := &corev1.Service{
@@ -1669,6 +1790,62 @@ var _ = Describe("Component handler tests", func() {
 			Expect(sa.ImagePullSecrets).To(HaveLen(1))
 		})
 	})
+
+	Context("unmanaged resource selector", func() {
+		var fc *fakeComponent
+
+		BeforeEach(func() {
+			selector := labels.SelectorFromSet(labels.Set{"example.com/managed": "manual"})
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, instance, WithUnmanagedSelector(selector))
+
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs: []client.Object{&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "default"},
+					Data:       map[string]string{"key": "rendered"},
+				}},
+			}
+		})
+
+		It("skips updating existing resources that match the selector", func() {
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cm",
+					Namespace: "default",
+					Labels:    map[string]string{"example.com/managed": "manual"},
+				},
+				Data: map[string]string{"key": "manual"},
+			})).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "default"}, cm)).NotTo(HaveOccurred())
+			Expect(cm.Data).To(Equal(map[string]string{"key": "manual"}))
+			Expect(cm.OwnerReferences).To(BeEmpty())
+		})
+
+		It("updates existing resources that do not match the selector", func() {
+			Expect(c.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "default"},
+				Data:       map[string]string{"key": "manual"},
+			})).NotTo(HaveOccurred())
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "default"}, cm)).NotTo(HaveOccurred())
+			Expect(cm.Data).To(Equal(map[string]string{"key": "rendered"}))
+		})
+
+		It("creates resources that do not exist", func() {
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "default"}, cm)).NotTo(HaveOccurred())
+			Expect(cm.Data).To(Equal(map[string]string{"key": "rendered"}))
+		})
+	})
 })
 
 var _ = Describe("Mocked client Component handler tests", func() {
@@ -1769,6 +1946,139 @@ var _ = Describe("Mocked client Component handler tests", func() {
 		})
 	})
 
+	Context("Server-side apply", func() {
+		var ds *apps.DaemonSet
+		var fc *fakeComponent
+
+		BeforeEach(func() {
+			ds = &apps.DaemonSet{
+				TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ds",
+					Namespace: "default",
+					Labels:    map[string]string{common.MultipleOwnersLabel: "true"},
+				},
+			}
+			fc = &fakeComponent{
+				supportedOSType: rmeta.OSTypeLinux,
+				objs:            []client.Object{ds},
+			}
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, runtime.NewScheme(), nil, WithServerSideApply(true))
+		})
+
+		expectApplied := func() {
+			Expect(mc.Patches).To(HaveLen(1))
+			Expect(mc.Patches[0].Type).To(Equal("application/apply-patch+yaml"))
+			Expect(mc.Patches[0].Options.FieldManager).To(Equal(FieldManager))
+			Expect(*mc.Patches[0].Options.Force).To(BeTrue())
+			Expect(mc.Patches[0].Object.GetLabels()).NotTo(HaveKey(common.MultipleOwnersLabel))
+		}
+
+		It("applies objects that do not exist instead of creating them", func() {
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				Return: errors.NewNotFound(schema.GroupResource{}, "test-ds"),
+			})
+			mc.Info = append(mc.Info, mockReturn{Method: "Patch"})
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(2))
+			expectApplied()
+		})
+
+		It("applies objects that exist instead of updating them", func() {
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				InputMutator: func(object client.Object) {
+					ds.DeepCopyInto(object.(*apps.DaemonSet))
+					object.SetResourceVersion("1")
+				},
+			})
+			mc.Info = append(mc.Info, mockReturn{Method: "Patch"})
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(2))
+			expectApplied()
+			Expect(mc.Patches[0].Object.GetResourceVersion()).To(BeEmpty())
+		})
+
+		It("keeps the owner references of the other owners of objects with multiple owners", func() {
+			scheme := runtime.NewScheme()
+			Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+			owner := &operatorv1.Manager{
+				TypeMeta:   metav1.TypeMeta{Kind: "Manager", APIVersion: "operator.tigera.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure", UID: "manager-uid"},
+			}
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, scheme, owner, WithServerSideApply(true))
+
+			otherOwner := metav1.OwnerReference{APIVersion: "operator.tigera.io/v1", Kind: "APIServer", Name: "tigera-secure", UID: "apiserver-uid"}
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				InputMutator: func(object client.Object) {
+					ds.DeepCopyInto(object.(*apps.DaemonSet))
+					object.SetOwnerReferences([]metav1.OwnerReference{otherOwner})
+				},
+			})
+			mc.Info = append(mc.Info, mockReturn{Method: "Patch"})
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(2))
+			expectApplied()
+			refs := mc.Patches[0].Object.GetOwnerReferences()
+			Expect(refs).To(HaveLen(2))
+			Expect(refs).To(ContainElement(otherOwner))
+			Expect(refs).To(ContainElement(HaveField("UID", types.UID("manager-uid"))))
+		})
+
+		It("takes over the fields owned by the client-side field manager before applying", func() {
+			mc.Info = append(mc.Info, mockReturn{
+				Method: "Get",
+				InputMutator: func(object client.Object) {
+					ds.DeepCopyInto(object.(*apps.DaemonSet))
+					object.SetResourceVersion("1")
+					object.SetManagedFields([]metav1.ManagedFieldsEntry{{
+						Manager:    clientSideFieldManager,
+						Operation:  metav1.ManagedFieldsOperationUpdate,
+						APIVersion: "apps/v1",
+						FieldsType: "FieldsV1",
+						FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:example":{}}}}`)},
+					}})
+				},
+			})
+			mc.Info = append(mc.Info, mockReturn{Method: "Patch"})
+			mc.Info = append(mc.Info, mockReturn{Method: "Patch"})
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(3))
+			Expect(mc.Patches).To(HaveLen(2))
+			Expect(mc.Patches[0].Type).To(Equal(string(types.JSONPatchType)))
+			Expect(mc.Patches[1].Type).To(Equal(string(types.ApplyPatchType)))
+			Expect(mc.Patches[1].Options.FieldManager).To(Equal(FieldManager))
+
+			By("not taking over the fields when the client-side field manager owns none")
+			mc = mockClient{Info: []mockReturn{
+				{Method: "Get", InputMutator: func(object client.Object) { ds.DeepCopyInto(object.(*apps.DaemonSet)) }},
+				{Method: "Patch"},
+			}}
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(2))
+			expectApplied()
+		})
+
+		It("uses client-side updates when server-side apply is not enabled", func() {
+			handler = NewComponentHandler(logf.Log.WithName("test_utils_logger"), c, runtime.NewScheme(), nil)
+			mc.Info = append(mc.Info, mockReturn{
+				Method:       "Get",
+				InputMutator: func(object client.Object) { ds.DeepCopyInto(object.(*apps.DaemonSet)) },
+			})
+			mc.Info = append(mc.Info, mockReturn{Method: "Update"})
+
+			Expect(handler.CreateOrUpdateOrDelete(ctx, fc, nil)).NotTo(HaveOccurred())
+			Expect(mc.Index).To(Equal(2))
+			Expect(mc.Patches).To(BeEmpty())
+		})
+	})
+
 	Context("Network Policy updates", func() {
 		baseNP := &v3.NetworkPolicy{
 			TypeMeta: metav1.TypeMeta{
@@ -1896,6 +2206,7 @@ var _ = Describe("Mocked client Component handler tests", func() {
 // A fake component that only returns ready and always creates the "test-namespace" Namespace.
 type fakeComponent struct {
 	objs            []client.Object
+	objsToDelete    []client.Object
 	supportedOSType rmeta.OSType
 }
 
@@ -1908,13 +2219,38 @@ func (c *fakeComponent) ResolveImages(is *operatorv1.ImageSet) error {
 }
 
 func (c *fakeComponent) Objects() ([]client.Object, []client.Object) {
-	return c.objs, nil
+	return c.objs, c.objsToDelete
 }
 
 func (c *fakeComponent) SupportedOSType() rmeta.OSType {
 	return c.supportedOSType
 }
 
+// A fake component that depends on the given objects, and records whether it was rendered before its images were
+// resolved.
+type fakeDependentComponent struct {
+	fakeComponent
+	dependencies       []client.Object
+	imagesResolved     bool
+	renderedUnresolved bool
+}
+
+func (c *fakeDependentComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	c.imagesResolved = true
+	return nil
+}
+
+func (c *fakeDependentComponent) Objects() ([]client.Object, []client.Object) {
+	if !c.imagesResolved {
+		c.renderedUnresolved = true
+	}
+	return c.fakeComponent.Objects()
+}
+
+func (c *fakeDependentComponent) Dependencies() []client.Object {
+	return c.dependencies
+}
+
 type mockReturn struct {
 	Method       string
 	Return       interface{}
@@ -1924,6 +2260,15 @@ type mockReturn struct {
 type mockClient struct {
 	Info  []mockReturn
 	Index int
+
+	// Patches records the patch types and options of each Patch call.
+	Patches []mockPatch
+}
+
+type mockPatch struct {
+	Type    string
+	Options client.PatchOptions
+	Object  client.Object
 }
 
 func (mc *mockClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
@@ -1992,7 +2337,27 @@ func (mc *mockClient) Update(ctx context.Context, obj client.Object, opts ...cli
 	return v
 }
 func (mc *mockClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
-	panic("Patch not implemented in mockClient")
+	defer func() { mc.Index++ }()
+	funcName := "Patch"
+	if len(mc.Info) <= mc.Index {
+		panic(fmt.Sprintf("mockClient Info doesn't have enough entries for %s %v", funcName, client.ObjectKeyFromObject(obj)))
+	}
+	if mc.Info[mc.Index].Method != funcName {
+		panic(fmt.Sprintf("mockClient current (%d) call is for %v, not %s", mc.Index, mc.Info[mc.Index].Method, funcName))
+	}
+	po := client.PatchOptions{}
+	po.ApplyOptions(opts)
+	mc.Patches = append(mc.Patches, mockPatch{Type: string(patch.Type()), Options: po, Object: obj})
+	if mc.Info[mc.Index].Return == nil {
+		return nil
+	}
+
+	v, ok := mc.Info[mc.Index].Return.(error)
+	if !ok {
+		panic(fmt.Sprintf("mockClient Info didn't have right type for entry %d for %s %v", mc.Index, funcName, client.ObjectKeyFromObject(obj)))
+	}
+
+	return v
 }
 func (mc *mockClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
 	panic("DeleteAll not implemented in mockClient")
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// redactedValue replaces the values that must not be logged, such as the contents of secrets.
+const redactedValue = "<redacted>"
+
+// objectChanges returns a sorted description of the fields of desired that differ from current, each in the form
+// "<path>: <current> -> <desired>". Fields that only current sets, such as the ones defaulted by the API server, are not
+// reported since an update leaves them as they are. The values of secret data are redacted.
+func objectChanges(current, desired client.Object) ([]string, error) {
+	cur, err := toUnstructuredMap(current)
+	if err != nil {
+		return nil, err
+	}
+	des, err := toUnstructuredMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	redact := func(string) bool { return false }
+	if _, ok := desired.(*v1.Secret); ok {
+		redact = func(path string) bool {
+			return strings.HasPrefix(path, "data.") || strings.HasPrefix(path, "stringData.")
+		}
+	}
+
+	var changes []string
+	diffValues("", cur, des, redact, &changes)
+	sort.Strings(changes)
+	return changes, nil
+}
+
+func toUnstructuredMap(obj client.Object) (map[string]interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	// Status is not written by the component handler.
+	delete(m, "status")
+	return m, nil
+}
+
+// diffValues appends a change for each field of desired, below path, that differs from current.
+func diffValues(path string, current, desired interface{}, redact func(string) bool, changes *[]string) {
+	switch d := desired.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		c, _ := current.(map[string]interface{})
+		for key, value := range d {
+			diffValues(joinPath(path, key), c[key], value, redact, changes)
+		}
+		return
+	case []interface{}:
+		c, _ := current.([]interface{})
+		for i, value := range d {
+			var cv interface{}
+			if i < len(c) {
+				cv = c[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), cv, value, redact, changes)
+		}
+		for i := len(d); i < len(c); i++ {
+			*changes = append(*changes, fmt.Sprintf("%s[%d]: %s -> <unset>", path, i, changeValue(c[i], redact(path))))
+		}
+		return
+	}
+	if reflect.DeepEqual(current, desired) {
+		return
+	}
+	*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", path, changeValue(current, redact(path)), changeValue(desired, redact(path))))
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func changeValue(v interface{}, redact bool) string {
+	if v == nil {
+		return "<unset>"
+	}
+	if redact {
+		return redactedValue
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apps "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+	"github.com/tigera/operator/pkg/ptr"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+var _ = Describe("Component handler dry run", func() {
+	var (
+		ctx    context.Context
+		cli    client.Client
+		writes []string
+		sm     status.StatusManager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+		Expect(apps.SchemeBuilder.AddToScheme(scheme)).NotTo(HaveOccurred())
+
+		writes = nil
+		record := func(verb string, obj client.Object) {
+			writes = append(writes, verb+" "+obj.GetName())
+		}
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).
+			WithObjects(
+				&apps.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+					Spec:       apps.DeploymentSpec{Replicas: ptr.Int32ToPtr(1)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "obsolete", Namespace: "default"},
+				},
+			).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					record("create", obj)
+					return c.Create(ctx, obj, opts...)
+				},
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					record("update", obj)
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					record("patch", obj)
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					record("delete", obj)
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).
+			Build()
+		sm = status.New(cli, "fake-component", &common.VersionInfo{Major: 1, Minor: 19})
+	})
+
+	It("should not write any objects", func() {
+		instance := &operatorv1.Manager{
+			TypeMeta:   metav1.TypeMeta{Kind: "Manager", APIVersion: "operator.tigera.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"},
+		}
+		handler := NewComponentHandler(logf.Log.WithName("test_dry_run"), cli, cli.Scheme(), instance, WithDryRun(true))
+		fc := &fakeComponent{
+			supportedOSType: rmeta.OSTypeLinux,
+			objs: []client.Object{
+				&apps.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+					Spec:       apps.DeploymentSpec{Replicas: ptr.Int32ToPtr(3)},
+				},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"}},
+			},
+			objsToDelete: []client.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "obsolete", Namespace: "default"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}},
+			},
+		}
+
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+		Expect(writes).To(BeEmpty())
+
+		d := &apps.Deployment{}
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "existing", Namespace: "default"}, d)).NotTo(HaveOccurred())
+		Expect(*d.Spec.Replicas).To(Equal(int32(1)))
+		Expect(d.OwnerReferences).To(BeEmpty())
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "new", Namespace: "default"}, &corev1.ConfigMap{})).To(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKey{Name: "obsolete", Namespace: "default"}, &corev1.Secret{})).NotTo(HaveOccurred())
+
+		By("writing the objects when dry run is disabled")
+		handler = NewComponentHandler(logf.Log.WithName("test_dry_run"), cli, cli.Scheme(), instance, WithDryRun(false))
+		Expect(handler.CreateOrUpdateOrDelete(ctx, fc, sm)).NotTo(HaveOccurred())
+		Expect(writes).To(ConsistOf("update existing", "create new", "delete obsolete", "delete missing"))
+	})
+})
+
+var _ = Describe("objectChanges", func() {
+	It("should describe the fields that the desired object changes", func() {
+		current := &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+				Labels:    map[string]string{"k8s-app": "test", "external": "label"},
+			},
+			Spec: apps.DeploymentSpec{
+				Replicas: ptr.Int32ToPtr(1),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "a", Image: "a:v1"}, {Name: "b", Image: "b:v1"}},
+				}},
+			},
+			Status: apps.DeploymentStatus{Replicas: 1},
+		}
+		desired := current.DeepCopy()
+		desired.Labels = map[string]string{"k8s-app": "test", "team": "network-operators"}
+		desired.Spec.Replicas = ptr.Int32ToPtr(2)
+		desired.Spec.Template.Spec.Containers = []corev1.Container{{Name: "a", Image: "a:v2"}}
+		desired.Status = apps.DeploymentStatus{}
+
+		changes, err := objectChanges(current, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]string{
+			`metadata.labels.team: <unset> -> "network-operators"`,
+			`spec.replicas: 1 -> 2`,
+			`spec.template.spec.containers[0].image: "a:v1" -> "a:v2"`,
+			`spec.template.spec.containers[1]: {"image":"b:v1","name":"b","resources":{}} -> <unset>`,
+		}))
+	})
+
+	It("should not describe any changes for identical objects", func() {
+		obj := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Data:       map[string]string{"key": "value"},
+		}
+		changes, err := objectChanges(obj, obj.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("should redact the data of secrets", func() {
+		current := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Data:       map[string][]byte{"tls.key": []byte("old")},
+		}
+		desired := current.DeepCopy()
+		desired.Data["tls.key"] = []byte("new")
+		desired.StringData = map[string]string{"password": "secret"}
+
+		changes, err := objectChanges(current, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]string{
+			"data.tls.key: <redacted> -> <redacted>",
+			"stringData.password: <unset> -> <redacted>",
+		}))
+	})
+})
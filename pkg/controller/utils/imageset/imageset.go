@@ -35,7 +35,7 @@ func ApplyImageSet(ctx context.Context, c client.Client, v operator.ProductVaria
 		return err
 	}
 
-	if err = ValidateImageSet(imageSet); err != nil {
+	if err = ValidateImageSet(imageSet, v); err != nil {
 		return err
 	}
 
@@ -47,13 +47,6 @@ func AddImageSetWatch(c ctrlruntime.Controller) error {
 	return c.WatchObject(&operator.ImageSet{}, &handler.EnqueueRequestForObject{})
 }
 
-func getSetName(v operator.ProductVariant) string {
-	if v == operator.TigeraSecureEnterprise {
-		return fmt.Sprintf("enterprise-%s", components.EnterpriseRelease)
-	}
-	return fmt.Sprintf("calico-%s", components.CalicoRelease)
-}
-
 // GetImageSet finds the ImageSet for specified variant.
 func GetImageSet(ctx context.Context, cli client.Client, v operator.ProductVariant) (*operator.ImageSet, error) {
 	isl := &operator.ImageSetList{}
@@ -71,7 +64,7 @@ func GetImageSet(ctx context.Context, cli client.Client, v operator.ProductVaria
 		return nil, nil
 	}
 
-	setName := getSetName(v)
+	setName := components.ImageSetName(v)
 
 	for _, is := range isl.Items {
 		if is.Name == setName {
@@ -82,35 +75,16 @@ func GetImageSet(ctx context.Context, cli client.Client, v operator.ProductVaria
 	return nil, fmt.Errorf("ImageSets exist but none with the expected name %s", setName)
 }
 
-// ValidateImageSet validates that all the images in an ImageSet are images the operator uses
-// and that the Digest is in an allowed format.
-func ValidateImageSet(is *operator.ImageSet) error {
+// ValidateImageSet validates that all the images in an ImageSet are images the operator uses,
+// that the Digest is in an allowed format, and that the ImageSet lists every image of the variant.
+func ValidateImageSet(is *operator.ImageSet, v operator.ProductVariant) error {
 	// None is valid
 	if is == nil {
 		return nil
 	}
 	unknownImages := []string{}
 	for _, img := range is.Spec.Images {
-		valid := false
-		for _, x := range components.CalicoImages {
-			if img.Image == x.Image {
-				valid = true
-				break
-			}
-		}
-		if valid {
-			continue
-		}
-		for _, x := range components.EnterpriseImages {
-			if img.Image == x.Image {
-				valid = true
-				break
-			}
-		}
-		if valid {
-			continue
-		}
-		if !valid {
+		if _, ok := components.GetComponentByImage(img.Image); !ok {
 			unknownImages = append(unknownImages, img.Image)
 		}
 	}
@@ -123,7 +97,7 @@ func ValidateImageSet(is *operator.ImageSet) error {
 	}
 
 	if len(unknownImages) == 0 && len(invalidDigests) == 0 {
-		return nil
+		return components.ValidateImageSet(is, v)
 	}
 
 	errMsgs := []string{}
@@ -31,6 +31,7 @@ import (
 	operator "github.com/tigera/operator/api/v1"
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/test"
 )
 
 var _ = Describe("imageset tests", func() {
@@ -59,7 +60,9 @@ var _ = Describe("imageset tests", func() {
 			if v == operator.TigeraSecureEnterprise {
 				nm = fmt.Sprintf("enterprise-%s", components.EnterpriseRelease)
 			}
-			c := fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
+			c := fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(test.CompleteImageSet(v)).Build()
+			Expect(ApplyImageSet(context.Background(), c, v)).To(BeNil())
+			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
 				&operator.ImageSet{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: nm,
@@ -73,7 +76,9 @@ var _ = Describe("imageset tests", func() {
 					},
 				},
 			).Build()
-			Expect(ApplyImageSet(context.Background(), c, v)).To(BeNil())
+			err := ApplyImageSet(context.Background(), c, v)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("missing images"))
 			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
 				&operator.ImageSet{
 					ObjectMeta: metav1.ObjectMeta{
@@ -89,7 +94,7 @@ var _ = Describe("imageset tests", func() {
 					},
 				},
 			).Build()
-			err := ApplyImageSet(context.Background(), c, v)
+			err = ApplyImageSet(context.Background(), c, v)
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(ContainSubstring("unexpected images"))
 			c = fake.NewClientBuilder().WithScheme(kscheme.Scheme).WithObjects(
@@ -0,0 +1,252 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
+)
+
+const (
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIImageIndex      = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// ManifestInspector inspects the manifests of images in a registry.
+type ManifestInspector interface {
+	// IsMultiArch returns true if the given image reference, of the form registry/repository@digest, refers to a
+	// manifest list or image index rather than to the image of a single architecture.
+	IsMultiArch(ctx context.Context, ref string) (bool, error)
+}
+
+// SingleArchImages returns the references of the given images of the ImageSet that are not multi-arch, using the
+// registry and image path configured in the Installation. Images that are not in the ImageSet are not inspected.
+func SingleArchImages(ctx context.Context, inspector ManifestInspector, is *operator.ImageSet, installation *operator.InstallationSpec, images ...string) ([]string, error) {
+	if is == nil {
+		return nil, nil
+	}
+	inspect := map[string]bool{}
+	for _, img := range images {
+		inspect[img] = true
+	}
+	var singleArch []string
+	for _, img := range is.Spec.Images {
+		if !inspect[img.Image] {
+			continue
+		}
+		ref, err := imageReference(img.Image, is, installation)
+		if err != nil {
+			return nil, err
+		}
+		multiArch, err := inspector.IsMultiArch(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect image %s: %w", ref, err)
+		}
+		if !multiArch {
+			singleArch = append(singleArch, ref)
+		}
+	}
+	return singleArch, nil
+}
+
+// imageReference returns the reference that the operator renders for the given ImageSet image.
+func imageReference(image string, is *operator.ImageSet, installation *operator.InstallationSpec) (string, error) {
+	c, ok := components.GetComponentByImage(image)
+	if !ok {
+		return "", fmt.Errorf("unexpected image %s", image)
+	}
+	return components.GetInstallationReference(c, installation, is)
+}
+
+// NewRegistryManifestInspector returns a ManifestInspector that reads manifests from the registry API. Registries
+// are accessed anonymously, so only images that can be pulled without credentials can be inspected. Since manifests
+// are referenced by digest and cannot change, the result for each reference is cached.
+func NewRegistryManifestInspector() ManifestInspector {
+	return &registryManifestInspector{
+		client: &http.Client{Timeout: 30 * time.Second},
+		scheme: "https",
+	}
+}
+
+type registryManifestInspector struct {
+	client *http.Client
+	scheme string
+	cache  sync.Map
+}
+
+func (r *registryManifestInspector) IsMultiArch(ctx context.Context, ref string) (bool, error) {
+	if multiArch, ok := r.cache.Load(ref); ok {
+		return multiArch.(bool), nil
+	}
+
+	host, repository, digest, err := parseReference(ref)
+	if err != nil {
+		return false, err
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", r.scheme, host, repository, digest)
+
+	resp, err := r.getManifest(ctx, manifestURL, "")
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// Request an anonymous token for the challenge and retry.
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := r.anonymousToken(ctx, challenge)
+		if err != nil {
+			return false, err
+		}
+		if resp, err = r.getManifest(ctx, manifestURL, token); err != nil {
+			return false, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %s reading manifest", resp.Status)
+	}
+
+	var manifest struct {
+		MediaType string            `json:"mediaType"`
+		Manifests []json.RawMessage `json:"manifests"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(&manifest); err != nil {
+		return false, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	}
+	multiArch := mediaType == mediaTypeDockerManifestList || mediaType == mediaTypeOCIImageIndex || len(manifest.Manifests) > 0
+
+	r.cache.Store(ref, multiArch)
+	return multiArch, nil
+}
+
+func (r *registryManifestInspector) getManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{mediaTypeDockerManifestList, mediaTypeOCIImageIndex, mediaTypeDockerManifest, mediaTypeOCIManifest}, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
+
+// anonymousToken requests a token from the realm of the given bearer challenge without credentials.
+func (r *registryManifestInspector) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	params, ok := parseBearerChallenge(challenge)
+	if !ok || params["realm"] == "" {
+		return "", fmt.Errorf("registry requires unsupported authentication %q", challenge)
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", params["realm"], err)
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s requesting registry token", resp.Status)
+	}
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// parseReference splits a reference of the form registry/repository@digest. References to Docker Hub are read from
+// its registry API host.
+func parseReference(ref string) (host, repository, digest string, err error) {
+	name, digest, ok := strings.Cut(ref, "@")
+	if !ok {
+		return "", "", "", fmt.Errorf("image %s is not referenced by digest", ref)
+	}
+	host, repository, ok = strings.Cut(name, "/")
+	if !ok || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		host, repository = "docker.io", name
+	}
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	return host, repository, digest, nil
+}
+
+// parseBearerChallenge returns the parameters of a WWW-Authenticate header of the form
+// Bearer realm="...",service="...",scope="...".
+func parseBearerChallenge(challenge string) (map[string]string, bool) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, false
+	}
+	params := map[string]string{}
+	for rest != "" {
+		var kv string
+		// Values are quoted and may contain commas, for example in a scope listing several actions.
+		key, value, ok := strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				return nil, false
+			}
+			kv, rest = value[1:end+1], value[end+2:]
+		} else {
+			kv, rest, _ = strings.Cut(value, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = kv
+	}
+	return params, true
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	operator "github.com/tigera/operator/api/v1"
+)
+
+// stubManifestInspector reports the configured references as multi-arch.
+type stubManifestInspector struct {
+	multiArch map[string]bool
+	err       error
+}
+
+func (s *stubManifestInspector) IsMultiArch(ctx context.Context, ref string) (bool, error) {
+	return s.multiArch[ref], s.err
+}
+
+var _ = Describe("multi-arch image verification", func() {
+	var is *operator.ImageSet
+	var installation *operator.InstallationSpec
+
+	BeforeEach(func() {
+		is = &operator.ImageSet{
+			Spec: operator.ImageSetSpec{
+				Images: []operator.Image{
+					{Image: "tigera/cnx-apiserver", Digest: "sha256:apiserverhash"},
+					{Image: "tigera/cnx-queryserver", Digest: "sha256:queryserverhash"},
+				},
+			},
+		}
+		installation = &operator.InstallationSpec{Registry: "registry.example.com/", ImagePath: "mirror"}
+	})
+
+	It("should return the images that are not multi-arch", func() {
+		inspector := &stubManifestInspector{multiArch: map[string]bool{
+			"registry.example.com/mirror/cnx-queryserver@sha256:queryserverhash": true,
+		}}
+		singleArch, err := SingleArchImages(context.Background(), inspector, is, installation, "tigera/cnx-apiserver", "tigera/cnx-queryserver")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(singleArch).To(ConsistOf("registry.example.com/mirror/cnx-apiserver@sha256:apiserverhash"))
+	})
+
+	It("should only inspect the given images", func() {
+		inspector := &stubManifestInspector{}
+		singleArch, err := SingleArchImages(context.Background(), inspector, is, installation, "tigera/cnx-queryserver", "tigera/packetcapture")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(singleArch).To(ConsistOf("registry.example.com/mirror/cnx-queryserver@sha256:queryserverhash"))
+	})
+
+	It("should not inspect anything without an ImageSet", func() {
+		inspector := &stubManifestInspector{err: fmt.Errorf("should not be called")}
+		singleArch, err := SingleArchImages(context.Background(), inspector, nil, installation, "tigera/cnx-apiserver")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(singleArch).To(BeEmpty())
+	})
+
+	It("should return inspection errors", func() {
+		inspector := &stubManifestInspector{err: fmt.Errorf("registry unreachable")}
+		_, err := SingleArchImages(context.Background(), inspector, is, installation, "tigera/cnx-apiserver")
+		Expect(err).To(MatchError(ContainSubstring("registry unreachable")))
+	})
+
+	Context("registry manifest inspector", func() {
+		var server *httptest.Server
+		var inspector *registryManifestInspector
+		var requests int
+
+		BeforeEach(func() {
+			requests = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests++
+				if req.URL.Path == "/token" {
+					Expect(req.URL.Query().Get("scope")).To(Equal("repository:tigera/cnx-apiserver:pull"))
+					_, _ = w.Write([]byte(`{"token": "anonymous"}`))
+					return
+				}
+				if req.Header.Get("Authorization") != "Bearer anonymous" {
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="registry",scope="repository:tigera/cnx-apiserver:pull"`, req.Host))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				switch {
+				case strings.HasSuffix(req.URL.Path, "/manifests/sha256:index"):
+					w.Header().Set("Content-Type", mediaTypeOCIImageIndex)
+					_, _ = w.Write([]byte(`{"schemaVersion": 2, "manifests": [{"digest": "sha256:amd64"}, {"digest": "sha256:arm64"}]}`))
+				case strings.HasSuffix(req.URL.Path, "/manifests/sha256:single"):
+					_, _ = w.Write([]byte(`{"schemaVersion": 2, "mediaType": "` + mediaTypeDockerManifest + `"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			inspector = &registryManifestInspector{client: server.Client(), scheme: "http"}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should detect image indexes and single-arch manifests", func() {
+			host := strings.TrimPrefix(server.URL, "http://")
+			multiArch, err := inspector.IsMultiArch(context.Background(), host+"/tigera/cnx-apiserver@sha256:index")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(multiArch).To(BeTrue())
+
+			multiArch, err = inspector.IsMultiArch(context.Background(), host+"/tigera/cnx-apiserver@sha256:single")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(multiArch).To(BeFalse())
+
+			_, err = inspector.IsMultiArch(context.Background(), host+"/tigera/cnx-apiserver@sha256:missing")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should cache the result for each reference", func() {
+			ref := strings.TrimPrefix(server.URL, "http://") + "/tigera/cnx-apiserver@sha256:index"
+			_, err := inspector.IsMultiArch(context.Background(), ref)
+			Expect(err).NotTo(HaveOccurred())
+			seen := requests
+
+			multiArch, err := inspector.IsMultiArch(context.Background(), ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(multiArch).To(BeTrue())
+			Expect(requests).To(Equal(seen))
+		})
+	})
+})
@@ -54,6 +54,14 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.ImagePrefix = override.ImagePrefix
 	}
 
+	switch compareFields(inst.RegistryOverrides, override.RegistryOverrides) {
+	case BOnlySet, Different:
+		inst.RegistryOverrides = make(map[string]string, len(override.RegistryOverrides))
+		for key, val := range override.RegistryOverrides {
+			inst.RegistryOverrides[key] = val
+		}
+	}
+
 	switch compareFields(inst.ImagePullSecrets, override.ImagePullSecrets) {
 	case BOnlySet, Different:
 		inst.ImagePullSecrets = make([]v1.LocalObjectReference, len(override.ImagePullSecrets))
@@ -209,6 +217,22 @@ func OverrideInstallationSpec(cfg, override operatorv1.InstallationSpec) operato
 		inst.ServiceCIDRs = override.ServiceCIDRs
 	}
 
+	switch compareFields(inst.AdditionalLabels, override.AdditionalLabels) {
+	case BOnlySet, Different:
+		inst.AdditionalLabels = make(map[string]string, len(override.AdditionalLabels))
+		for key, val := range override.AdditionalLabels {
+			inst.AdditionalLabels[key] = val
+		}
+	}
+
+	switch compareFields(inst.AdditionalAnnotations, override.AdditionalAnnotations) {
+	case BOnlySet, Different:
+		inst.AdditionalAnnotations = make(map[string]string, len(override.AdditionalAnnotations))
+		for key, val := range override.AdditionalAnnotations {
+			inst.AdditionalAnnotations[key] = val
+		}
+	}
+
 	return inst
 }
 
@@ -429,6 +429,75 @@ var _ = Describe("Installation merge tests", func() {
 			Entry("Both set equal", map[string]string{"a": "1"}, map[string]string{"a": "1"}, map[string]string{"a": "1"}),
 			Entry("Both set not matching", map[string]string{"a": "1"}, map[string]string{"b": "2"}, map[string]string{"b": "2"}),
 		)
+
+		DescribeTable("merge RegistryOverrides", func(main, second, expect map[string]string) {
+			m := opv1.InstallationSpec{}
+			s := opv1.InstallationSpec{}
+			if main != nil {
+				m.RegistryOverrides = main
+			}
+			if second != nil {
+				s.RegistryOverrides = second
+			}
+			inst := OverrideInstallationSpec(m, s)
+			if expect == nil {
+				Expect(inst.RegistryOverrides).To(BeNil())
+			} else {
+				Expect(inst.RegistryOverrides).To(Equal(expect))
+			}
+		},
+			Entry("Both unset", nil, nil, nil),
+			Entry("Main only set", map[string]string{"calico/node": "a/"}, nil, map[string]string{"calico/node": "a/"}),
+			Entry("Second only set", nil, map[string]string{"calico/typha": "b/"}, map[string]string{"calico/typha": "b/"}),
+			Entry("Both set equal", map[string]string{"calico/node": "a/"}, map[string]string{"calico/node": "a/"}, map[string]string{"calico/node": "a/"}),
+			Entry("Both set not matching", map[string]string{"calico/node": "a/"}, map[string]string{"calico/typha": "b/"}, map[string]string{"calico/typha": "b/"}),
+		)
+
+		DescribeTable("merge AdditionalLabels", func(main, second, expect map[string]string) {
+			m := opv1.InstallationSpec{}
+			s := opv1.InstallationSpec{}
+			if main != nil {
+				m.AdditionalLabels = main
+			}
+			if second != nil {
+				s.AdditionalLabels = second
+			}
+			inst := OverrideInstallationSpec(m, s)
+			if expect == nil {
+				Expect(inst.AdditionalLabels).To(BeNil())
+			} else {
+				Expect(inst.AdditionalLabels).To(Equal(expect))
+			}
+		},
+			Entry("Both unset", nil, nil, nil),
+			Entry("Main only set", map[string]string{"a": "1"}, nil, map[string]string{"a": "1"}),
+			Entry("Second only set", nil, map[string]string{"b": "2"}, map[string]string{"b": "2"}),
+			Entry("Both set equal", map[string]string{"a": "1"}, map[string]string{"a": "1"}, map[string]string{"a": "1"}),
+			Entry("Both set not matching", map[string]string{"a": "1"}, map[string]string{"b": "2"}, map[string]string{"b": "2"}),
+		)
+
+		DescribeTable("merge AdditionalAnnotations", func(main, second, expect map[string]string) {
+			m := opv1.InstallationSpec{}
+			s := opv1.InstallationSpec{}
+			if main != nil {
+				m.AdditionalAnnotations = main
+			}
+			if second != nil {
+				s.AdditionalAnnotations = second
+			}
+			inst := OverrideInstallationSpec(m, s)
+			if expect == nil {
+				Expect(inst.AdditionalAnnotations).To(BeNil())
+			} else {
+				Expect(inst.AdditionalAnnotations).To(Equal(expect))
+			}
+		},
+			Entry("Both unset", nil, nil, nil),
+			Entry("Main only set", map[string]string{"a": "1"}, nil, map[string]string{"a": "1"}),
+			Entry("Second only set", nil, map[string]string{"b": "2"}, map[string]string{"b": "2"}),
+			Entry("Both set equal", map[string]string{"a": "1"}, map[string]string{"a": "1"}, map[string]string{"a": "1"}),
+			Entry("Both set not matching", map[string]string{"a": "1"}, map[string]string{"b": "2"}, map[string]string{"b": "2"}),
+		)
 		//TODO: Have some test that have different fields set and they merge.
 
 		DescribeTable("merge multiple CalicoNetwork fields", func(main, second, expect *opv1.CalicoNetworkSpec) {
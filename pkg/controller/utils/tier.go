@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/controller/status"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+)
+
+// AllowTigeraTierMaxAttempts is the number of consecutive reconciles that requeue while the allow-tigera tier is
+// missing. After that, the controller relies on its Tier watch to reconcile once the tier is created.
+const AllowTigeraTierMaxAttempts = 5
+
+// AllowTigeraTierExists returns whether the allow-tigera tier exists.
+func AllowTigeraTierExists(ctx context.Context, cli client.Client) (bool, error) {
+	if err := cli.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// TierWaiter counts the consecutive reconciles of a controller that found the allow-tigera tier missing. The zero
+// value is ready to use. It is not safe for concurrent use, which matches the single worker of the controllers.
+type TierWaiter struct {
+	attempts int
+}
+
+// EnsureTierReady verifies that the allow-tigera tier exists before a controller renders network policies within it.
+// It returns nil when the tier exists and the reconcile can continue. Otherwise it sets the degraded status and
+// returns the result and error to end the reconcile with. Once the tier has been missing for
+// AllowTigeraTierMaxAttempts reconciles, the degraded status points at its most likely cause, a missing License, and
+// the reconcile is no longer requeued.
+func (w *TierWaiter) EnsureTierReady(ctx context.Context, cli client.Client, status status.StatusManager, requeueInterval time.Duration, log logr.Logger) (*reconcile.Result, error) {
+	err := cli.Get(ctx, client.ObjectKey{Name: networkpolicy.TigeraComponentTierName}, &v3.Tier{})
+	if err == nil {
+		w.attempts = 0
+		return nil, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		status.SetDegraded(operatorv1.ResourceReadError, "Error querying allow-tigera tier", err, log)
+		return &reconcile.Result{}, err
+	}
+
+	w.attempts++
+	if w.attempts >= AllowTigeraTierMaxAttempts {
+		status.SetDegraded(operatorv1.ResourceNotReady, fmt.Sprintf("The allow-tigera tier has not been created after %d attempts, "+
+			"check that a valid License is applied and see the 'tiers' TigeraStatus for more information", w.attempts), err, log)
+		return &reconcile.Result{}, nil
+	}
+	status.SetDegraded(operatorv1.ResourceNotReady, "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information", err, log)
+	return &reconcile.Result{RequeueAfter: RequeueInterval(requeueInterval)}, nil
+}
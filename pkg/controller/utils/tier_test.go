@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/mock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
+
+	opv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/apis"
+	"github.com/tigera/operator/pkg/controller/status"
+	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
+)
+
+var _ = Describe("TierWaiter", func() {
+	const waitMessage = "Waiting for allow-tigera tier to be created, see the 'tiers' TigeraStatus for more information"
+
+	var (
+		ctx        context.Context
+		cli        client.Client
+		mockStatus *status.MockStatus
+		waiter     TierWaiter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		cli = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		mockStatus = &status.MockStatus{}
+		waiter = TierWaiter{}
+	})
+
+	It("continues the reconcile when the tier exists", func() {
+		Expect(cli.Create(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
+		result, err := waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		mockStatus.AssertNotCalled(GinkgoT(), "SetDegraded", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("requeues while the tier is missing", func() {
+		mockStatus.On("SetDegraded", opv1.ResourceNotReady, waitMessage, mock.Anything, mock.Anything).Return()
+		result, err := waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.RequeueAfter).To(Equal(StandardRetry))
+		mockStatus.AssertExpectations(GinkgoT())
+	})
+
+	It("stops requeueing and points at the License once the tier has been missing for too long", func() {
+		mockStatus.On("SetDegraded", opv1.ResourceNotReady, waitMessage, mock.Anything, mock.Anything).Return()
+		for i := 1; i < AllowTigeraTierMaxAttempts; i++ {
+			_, err := waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		escalated := fmt.Sprintf("The allow-tigera tier has not been created after %d attempts, "+
+			"check that a valid License is applied and see the 'tiers' TigeraStatus for more information", AllowTigeraTierMaxAttempts)
+		mockStatus.On("SetDegraded", opv1.ResourceNotReady, escalated, mock.Anything, mock.Anything).Return()
+		result, err := waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&reconcile.Result{}))
+		mockStatus.AssertCalled(GinkgoT(), "SetDegraded", opv1.ResourceNotReady, escalated, mock.Anything, mock.Anything)
+
+		By("starting over once the tier is created")
+		Expect(cli.Create(ctx, &v3.Tier{ObjectMeta: metav1.ObjectMeta{Name: "allow-tigera"}})).NotTo(HaveOccurred())
+		result, err = waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(waiter.attempts).To(BeZero())
+	})
+
+	It("degrades and returns the error when the tier cannot be queried", func() {
+		// A client without the projectcalico.org types registered fails to query the tier.
+		cli = ctrlrfake.DefaultFakeClientBuilder(runtime.NewScheme()).Build()
+		mockStatus.On("SetDegraded", opv1.ResourceReadError, "Error querying allow-tigera tier", mock.Anything, mock.Anything).Return()
+		result, err := waiter.EnsureTierReady(ctx, cli, mockStatus, 0, log)
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(Equal(&reconcile.Result{}))
+		mockStatus.AssertExpectations(GinkgoT())
+	})
+})
@@ -16,6 +16,7 @@ package utils
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"os"
 	"sort"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
@@ -83,6 +85,15 @@ var (
 	}
 )
 
+// RequeueInterval returns how long a controller waits before reconciling again when resources are not yet
+// available: the given interval, or StandardRetry when it is zero.
+func RequeueInterval(interval time.Duration) time.Duration {
+	if interval > 0 {
+		return interval
+	}
+	return StandardRetry
+}
+
 // ContextLoggerForResource provides a logger instance with context set for the provided object.
 func ContextLoggerForResource(log logr.Logger, obj client.Object) logr.Logger {
 	gvk := obj.GetObjectKind().GroupVersionKind()
@@ -324,6 +335,55 @@ func IsFeatureActive(license v3.LicenseKey, featureName string) bool {
 	return false
 }
 
+// LicenseExpiryWarningPeriod is how long before its expiry a license is reported as expiring.
+const LicenseExpiryWarningPeriod = 14 * 24 * time.Hour
+
+// LicenseExpiringConditionType is the type of the status condition that reports whether the license expires within
+// LicenseExpiryWarningPeriod. The condition is informational and does not make a component degraded.
+const LicenseExpiringConditionType = "LicenseExpiring"
+
+// LicenseExpiry returns the time at which the license expires, or the zero time if the license does not report it.
+func LicenseExpiry(license v3.LicenseKey) time.Time {
+	return license.Status.Expiry.Time
+}
+
+// SetLicenseExpiringCondition sets the LicenseExpiring condition for the license on the given CR status conditions,
+// and removes it when the license does not report its expiry. It returns whether the conditions changed.
+func SetLicenseExpiringCondition(conditions *[]metav1.Condition, license v3.LicenseKey, generation int64) bool {
+	expiry := LicenseExpiry(license)
+	if expiry.IsZero() {
+		if meta.FindStatusCondition(*conditions, LicenseExpiringConditionType) == nil {
+			return false
+		}
+		meta.RemoveStatusCondition(conditions, LicenseExpiringConditionType)
+		return true
+	}
+
+	condition := metav1.Condition{
+		Type:               LicenseExpiringConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "LicenseValid",
+		Message:            fmt.Sprintf("The license expires on %s", expiry.UTC().Format(time.RFC3339)),
+		ObservedGeneration: generation,
+	}
+	now := time.Now()
+	if !expiry.After(now) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "LicenseExpired"
+		condition.Message = fmt.Sprintf("The license expired on %s", expiry.UTC().Format(time.RFC3339))
+	} else if expiry.Sub(now) <= LicenseExpiryWarningPeriod {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "LicenseExpiring"
+	}
+
+	if c := meta.FindStatusCondition(*conditions, LicenseExpiringConditionType); c != nil && c.Status == condition.Status &&
+		c.Reason == condition.Reason && c.Message == condition.Message && c.ObservedGeneration == condition.ObservedGeneration {
+		return false
+	}
+	meta.SetStatusCondition(conditions, condition)
+	return true
+}
+
 // ValidateCertPair checks if the given secret exists in the given
 // namespace and if so that it contains key and cert fields. If an
 // empty string is passed for the keyName argument it is skipped.
@@ -438,6 +498,38 @@ func GetManagementClusterConnection(ctx context.Context, c client.Client) (*oper
 	return managementClusterConnection, nil
 }
 
+// ClusterRoleConflictError is returned by ValidateClusterRole when the cluster is configured as both a management
+// cluster and a managed cluster.
+type ClusterRoleConflictError struct{}
+
+func (e *ClusterRoleConflictError) Error() string {
+	return "having both a ManagementCluster and a ManagementClusterConnection is not supported"
+}
+
+// IsClusterRoleConflictError returns whether err is, or wraps, a ClusterRoleConflictError.
+func IsClusterRoleConflictError(err error) bool {
+	var conflict *ClusterRoleConflictError
+	return goerrors.As(err, &conflict)
+}
+
+// ValidateClusterRole returns a ClusterRoleConflictError if both a ManagementCluster and a ManagementClusterConnection
+// exist, since a cluster cannot be a management cluster and a managed cluster at the same time. Errors reading either
+// resource are returned as they are.
+func ValidateClusterRole(ctx context.Context, c client.Client) error {
+	managementCluster, err := GetManagementCluster(ctx, c)
+	if err != nil {
+		return err
+	}
+	managementClusterConnection, err := GetManagementClusterConnection(ctx, c)
+	if err != nil {
+		return err
+	}
+	if managementCluster != nil && managementClusterConnection != nil {
+		return &ClusterRoleConflictError{}
+	}
+	return nil
+}
+
 // GetAuthentication finds the authentication CR in your cluster.
 func GetAuthentication(ctx context.Context, cli client.Client) (*operatorv1.Authentication, error) {
 	authentication := &operatorv1.Authentication{}
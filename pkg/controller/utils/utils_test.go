@@ -19,7 +19,13 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/go-logr/logr"
 
@@ -46,6 +52,7 @@ import (
 	"github.com/tigera/operator/pkg/apis"
 	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/ctrlruntime"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/render"
 )
@@ -181,6 +188,51 @@ var _ = Describe("Utils APIServer type tests", func() {
 	)
 })
 
+var _ = Describe("ValidateClusterRole", func() {
+	var (
+		c   client.Client
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).NotTo(HaveOccurred())
+		c = ctrlrfake.DefaultFakeClientBuilder(scheme).Build()
+		ctx = context.Background()
+	})
+
+	DescribeTable("validating the ManagementCluster and ManagementClusterConnection", func(managementCluster, managementClusterConnection, conflict bool) {
+		if managementCluster {
+			Expect(c.Create(ctx, &opv1.ManagementCluster{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		}
+		if managementClusterConnection {
+			Expect(c.Create(ctx, &opv1.ManagementClusterConnection{ObjectMeta: metav1.ObjectMeta{Name: "tigera-secure"}})).NotTo(HaveOccurred())
+		}
+
+		err := ValidateClusterRole(ctx, c)
+		if conflict {
+			Expect(err).To(HaveOccurred())
+			Expect(IsClusterRoleConflictError(err)).To(BeTrue())
+			Expect(IsClusterRoleConflictError(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("with both present", true, true, true),
+		Entry("with neither present", false, false, false),
+		Entry("with only a ManagementCluster", true, false, false),
+		Entry("with only a ManagementClusterConnection", false, true, false),
+	)
+
+	It("should not report read errors as a conflict", func() {
+		// The client's scheme does not know about the operator types, so reading them fails.
+		c = ctrlrfake.DefaultFakeClientBuilder(runtime.NewScheme()).Build()
+		err := ValidateClusterRole(ctx, c)
+		Expect(err).To(HaveOccurred())
+		Expect(IsClusterRoleConflictError(err)).To(BeFalse())
+	})
+})
+
 var _ = Describe("ValidateResourceNameIsQualified", func() {
 
 	It("returns nil for a compliant kubernetes name.", func() {
@@ -223,6 +275,82 @@ var _ = Describe("AddPeriodicReconcile", func() {
 		// In practice, perfect alignment of the timers is unlikely.
 		Expect(periodicReconciles == numPeriods || periodicReconciles == numPeriods-1).To(BeTrue())
 	})
+
+	It("queues a reconcile request on each periodic tick", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		period := 10 * time.Millisecond
+		c := &periodicTestController{ctx: ctx, queue: queue}
+		Expect(AddPeriodicReconcile(c, period, &handler.EnqueueRequestForObject{})).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			Eventually(queue.Len, time.Second, period).Should(Equal(1))
+			item, _ := queue.Get()
+			Expect(item).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("periodic-%s-reconcile-event", period.String())}}))
+			queue.Forget(item)
+			queue.Done(item)
+		}
+	})
+})
+
+// periodicTestController starts the sources it is asked to watch, feeding their events into a work queue.
+type periodicTestController struct {
+	ctrlruntime.Controller
+	ctx   context.Context
+	queue workqueue.RateLimitingInterface
+}
+
+func (c *periodicTestController) Watch(src source.Source, h handler.EventHandler, predicates ...predicate.Predicate) error {
+	return src.Start(c.ctx, h, c.queue, predicates...)
+}
+
+var _ = Describe("RequeueInterval", func() {
+	It("defaults to the standard retry", func() {
+		Expect(RequeueInterval(0)).To(Equal(StandardRetry))
+	})
+
+	It("uses the configured interval", func() {
+		Expect(RequeueInterval(2 * time.Minute)).To(Equal(2 * time.Minute))
+	})
+})
+
+var _ = Describe("SetLicenseExpiringCondition", func() {
+	licenseExpiringIn := func(d time.Duration) v3.LicenseKey {
+		return v3.LicenseKey{Status: v3.LicenseKeyStatus{Expiry: metav1.NewTime(time.Now().Add(d))}}
+	}
+
+	DescribeTable("reports the license expiry",
+		func(d time.Duration, status metav1.ConditionStatus, reason string) {
+			var conditions []metav1.Condition
+			Expect(SetLicenseExpiringCondition(&conditions, licenseExpiringIn(d), 1)).To(BeTrue())
+			Expect(conditions).To(HaveLen(1))
+			Expect(conditions[0].Type).To(Equal(LicenseExpiringConditionType))
+			Expect(conditions[0].Status).To(Equal(status))
+			Expect(conditions[0].Reason).To(Equal(reason))
+			Expect(conditions[0].ObservedGeneration).To(Equal(int64(1)))
+		},
+		Entry("far from expiry", 30*24*time.Hour, metav1.ConditionFalse, "LicenseValid"),
+		Entry("within the warning period", 7*24*time.Hour, metav1.ConditionTrue, "LicenseExpiring"),
+		Entry("expired", -time.Hour, metav1.ConditionTrue, "LicenseExpired"),
+	)
+
+	It("does not report a change when the condition is unchanged", func() {
+		var conditions []metav1.Condition
+		license := licenseExpiringIn(7 * 24 * time.Hour)
+		Expect(SetLicenseExpiringCondition(&conditions, license, 1)).To(BeTrue())
+		Expect(SetLicenseExpiringCondition(&conditions, license, 1)).To(BeFalse())
+	})
+
+	It("removes the condition when the license does not report its expiry", func() {
+		var conditions []metav1.Condition
+		Expect(SetLicenseExpiringCondition(&conditions, v3.LicenseKey{}, 1)).To(BeFalse())
+		Expect(SetLicenseExpiringCondition(&conditions, licenseExpiringIn(time.Hour), 1)).To(BeTrue())
+		Expect(SetLicenseExpiringCondition(&conditions, v3.LicenseKey{}, 1)).To(BeTrue())
+		Expect(conditions).To(BeEmpty())
+	})
 })
 
 var _ = Describe("PopulateK8sServiceEndPoint", func() {
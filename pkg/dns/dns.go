@@ -16,9 +16,14 @@ package dns
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -27,8 +32,59 @@ const (
 
 	// Default cluster domain value for k8s clusters.
 	DefaultClusterDomain = "cluster.local"
+
+	// kubernetesServiceName is the partially qualified name of the kubernetes API service, used to probe the
+	// resolver for the cluster domain.
+	kubernetesServiceName = "kubernetes.default.svc"
 )
 
+// Resolver is the subset of net.Resolver used to probe for the cluster domain.
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// DetectClusterDomain returns the cluster domain. An explicit override takes precedence. Otherwise the cluster
+// domain is read from the search path in resolv.conf and, failing that, from the canonical name the resolver
+// returns for the kubernetes API service. An error is returned if the override is not a valid domain or if
+// neither detection method succeeds.
+func DetectClusterDomain(override, resolvConfPath string, resolver Resolver) (string, error) {
+	if override != "" {
+		if errs := validation.IsDNS1123Subdomain(override); len(errs) > 0 {
+			return "", fmt.Errorf("invalid cluster domain %q: %s", override, strings.Join(errs, ", "))
+		}
+		return override, nil
+	}
+
+	clusterDomain, resolvErr := GetClusterDomain(resolvConfPath)
+	if resolvErr == nil {
+		return clusterDomain, nil
+	}
+
+	clusterDomain, err := GetClusterDomainFromResolver(resolver)
+	if err != nil {
+		return "", fmt.Errorf("%v; %v", resolvErr, err)
+	}
+	return clusterDomain, nil
+}
+
+// GetClusterDomainFromResolver looks up the kubernetes API service through the resolver's search path and returns
+// the cluster domain from the fully qualified name it resolves to.
+func GetClusterDomainFromResolver(resolver Resolver) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cname, err := resolver.LookupCNAME(ctx, kubernetesServiceName)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", kubernetesServiceName, err)
+	}
+
+	clusterDomain := strings.TrimPrefix(strings.TrimSuffix(cname, "."), kubernetesServiceName+".")
+	if clusterDomain == "" || clusterDomain == strings.TrimSuffix(cname, ".") {
+		return "", fmt.Errorf("failed to find cluster domain in resolved name %q", cname)
+	}
+	return clusterDomain, nil
+}
+
 // GetClusterDomain parses the path to resolv.conf to find the cluster domain.
 func GetClusterDomain(resolvConfPath string) (string, error) {
 	var clusterDomain string
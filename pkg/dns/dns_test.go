@@ -15,6 +15,8 @@
 package dns_test
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	. "github.com/onsi/ginkgo"
@@ -24,6 +26,15 @@ import (
 	"github.com/tigera/operator/pkg/dns"
 )
 
+type fakeResolver struct {
+	cname string
+	err   error
+}
+
+func (r fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	return r.cname, r.err
+}
+
 var _ = Describe("Common Tests", func() {
 	Context("Get cluster domain", func() {
 
@@ -44,6 +55,47 @@ var _ = Describe("Common Tests", func() {
 		})
 	})
 
+	Context("Detect cluster domain", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should prefer an explicit override", func() {
+			clusterDomain, err := dns.DetectClusterDomain("example.internal", dir+"/testdata/resolv.conf", fakeResolver{cname: "kubernetes.default.svc.other.local."})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("example.internal"))
+		})
+
+		It("Should reject an invalid override", func() {
+			_, err := dns.DetectClusterDomain("Not_A_Domain", dir+"/testdata/resolv.conf", fakeResolver{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should read the cluster domain from resolv.conf", func() {
+			clusterDomain, err := dns.DetectClusterDomain("", dir+"/testdata/resolv.conf", fakeResolver{cname: "kubernetes.default.svc.other.local."})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("othername.local"))
+		})
+
+		It("Should fall back to the resolver when resolv.conf has no cluster search path", func() {
+			clusterDomain, err := dns.DetectClusterDomain("", dir+"/testdata/resolv-no-search.conf", fakeResolver{cname: "kubernetes.default.svc.example.internal."})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterDomain).To(Equal("example.internal"))
+			Expect(dns.GetServiceDNSNames("calico-api", "calico-apiserver", clusterDomain)).To(ContainElement("calico-api.calico-apiserver.svc.example.internal"))
+		})
+
+		It("Should return an error when neither resolv.conf nor the resolver yield a cluster domain", func() {
+			_, err := dns.DetectClusterDomain("", "does-not.exist", fakeResolver{err: fmt.Errorf("no such host")})
+			Expect(err).To(HaveOccurred())
+			_, err = dns.DetectClusterDomain("", dir+"/testdata/resolv-no-search.conf", fakeResolver{cname: "kubernetes.default.svc."})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("Get all DNS names for a service", func() {
 		DescribeTable("Should return the correct services names", func(service, namespace, clusterDomain string, expectedDNSNames []string) {
 			names := dns.GetServiceDNSNames(service, namespace, clusterDomain)
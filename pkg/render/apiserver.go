@@ -16,6 +16,7 @@ package render
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,9 +36,11 @@ import (
 	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	rcomp "github.com/tigera/operator/pkg/render/common/components"
+	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	"github.com/tigera/operator/pkg/render/common/podsecuritypolicy"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
@@ -50,6 +53,22 @@ const (
 
 	auditLogsVolumeName   = "tigera-audit-logs"
 	auditPolicyVolumeName = "tigera-audit-policy"
+
+	egressSelectorVolumeName     = "egress-selector-config"
+	egressSelectorMountPath      = "/etc/tigera/egress-selector"
+	egressSelectorConfigFileName = "egress-selector-config.yaml"
+	egressSelectorAnnotation     = "hash.operator.tigera.io/egress-selector-config"
+
+	tracingVolumeName     = "tracing-config"
+	tracingMountPath      = "/etc/tigera/tracing"
+	tracingConfigFileName = "tracing-config.yaml"
+	tracingAnnotation     = "hash.operator.tigera.io/tracing-config"
+
+	clientCAVolumeName    = "client-ca"
+	clientCAMountPath     = "/etc/tigera/client-ca"
+	clientCAFileName      = "client-ca.crt"
+	clientCAAnnotation    = "hash.operator.tigera.io/client-ca"
+	APIServerClientCAName = "calico-apiserver-client-ca"
 )
 
 const (
@@ -121,6 +140,27 @@ type APIServerConfiguration struct {
 	TrustedBundle               certificatemanagement.TrustedBundle
 	MultiTenant                 bool
 
+	// EgressSelectorConfig is the ConfigMap referenced by APIServer.EgressSelectorConfigMap, if any.
+	EgressSelectorConfig *corev1.ConfigMap
+
+	// TracingConfig is the ConfigMap referenced by APIServer.TracingConfigMap, if any.
+	TracingConfig *corev1.ConfigMap
+
+	// ClientCABundle is the PEM bundle of the CAs whose clients the API server authenticates with mutual TLS: the
+	// cluster client CA merged with the CAs referenced by APIServer.ClientCAConfigMap, if any.
+	ClientCABundle string
+
+	// TLSMinVersion is the minimum TLS version the API server accepts, for example VersionTLS12. The API server default
+	// is used when empty.
+	TLSMinVersion string
+
+	// TLSCipherSuites is the list of cipher suites the API server accepts. The API server default is used when empty.
+	TLSCipherSuites []string
+
+	// GoAwayChance is the probability that the API server sends a GOAWAY to an HTTP/2 client, for example 0.001. The
+	// API server default is used when empty.
+	GoAwayChance string
+
 	// Whether the cluster supports pod security policies.
 	UsePSP bool
 }
@@ -132,29 +172,26 @@ type apiServerComponent struct {
 }
 
 func (c *apiServerComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
 	errMsgs := []string{}
 
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.apiServerImage, err = components.GetReference(components.ComponentAPIServer, reg, path, prefix, is)
+		c.apiServerImage, err = components.GetInstallationReference(components.ComponentAPIServer, c.cfg.Installation, is)
 		if err != nil {
 			errMsgs = append(errMsgs, err.Error())
 		}
-		c.queryServerImage, err = components.GetReference(components.ComponentQueryServer, reg, path, prefix, is)
+		c.queryServerImage, err = components.GetInstallationReference(components.ComponentQueryServer, c.cfg.Installation, is)
 		if err != nil {
 			errMsgs = append(errMsgs, err.Error())
 		}
 	} else {
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServerFIPS, reg, path, prefix, is)
+			c.apiServerImage, err = components.GetInstallationReference(components.ComponentCalicoAPIServerFIPS, c.cfg.Installation, is)
 			if err != nil {
 				errMsgs = append(errMsgs, err.Error())
 			}
 		} else {
-			c.apiServerImage, err = components.GetReference(components.ComponentCalicoAPIServer, reg, path, prefix, is)
+			c.apiServerImage, err = components.GetInstallationReference(components.ComponentCalicoAPIServer, c.cfg.Installation, is)
 			if err != nil {
 				errMsgs = append(errMsgs, err.Error())
 			}
@@ -205,6 +242,17 @@ func (c *apiServerComponent) Objects() ([]client.Object, []client.Object) {
 	// Add in image pull secrets.
 	secrets := secret.CopyToNamespace(rmeta.APIServerNamespace(c.cfg.Installation.Variant), c.cfg.PullSecrets...)
 	namespacedObjects = append(namespacedObjects, secret.ToRuntimeObjects(secrets...)...)
+	if c.egressSelectorConfigured() {
+		configMaps := configmap.CopyToNamespace(rmeta.APIServerNamespace(c.cfg.Installation.Variant), c.cfg.EgressSelectorConfig)
+		namespacedObjects = append(namespacedObjects, configmap.ToRuntimeObjects(configMaps...)...)
+	}
+	if c.tracingConfigured() {
+		configMaps := configmap.CopyToNamespace(rmeta.APIServerNamespace(c.cfg.Installation.Variant), c.cfg.TracingConfig)
+		namespacedObjects = append(namespacedObjects, configmap.ToRuntimeObjects(configMaps...)...)
+	}
+	if c.clientCAConfigured() {
+		namespacedObjects = append(namespacedObjects, c.clientCAConfigMap())
+	}
 
 	namespacedObjects = append(namespacedObjects,
 		c.apiServerServiceAccount(),
@@ -326,19 +374,8 @@ func (c *apiServerComponent) resourceNameBasedOnVariant(enterpriseName, ossName
 }
 
 func (c *apiServerComponent) apiServerPodDisruptionBudget() *policyv1.PodDisruptionBudget {
-	maxUnavailable := intstr.FromInt(1)
 	name, _ := c.resourceNameBasedOnVariant("tigera-apiserver", "calico-apiserver")
-	return &policyv1.PodDisruptionBudget{
-		TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: rmeta.APIServerNamespace(c.cfg.Installation.Variant),
-		},
-		Spec: policyv1.PodDisruptionBudgetSpec{
-			MaxUnavailable: &maxUnavailable,
-			Selector:       c.deploymentSelector(),
-		},
-	}
+	return poddisruptionbudget.New(name, rmeta.APIServerNamespace(c.cfg.Installation.Variant), c.deploymentSelector(), c.cfg.APIServer.PodDisruptionBudget)
 }
 
 // apiServiceRegistration creates an API service that registers Tigera Secure APIs (and API server).
@@ -937,6 +974,15 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 	annotations := map[string]string{
 		c.cfg.TLSKeyPair.HashAnnotationKey(): c.cfg.TLSKeyPair.HashAnnotationValue(),
 	}
+	if c.egressSelectorConfigured() {
+		annotations[egressSelectorAnnotation] = rmeta.AnnotationHash(c.cfg.EgressSelectorConfig.Data)
+	}
+	if c.tracingConfigured() {
+		annotations[tracingAnnotation] = rmeta.AnnotationHash(c.cfg.TracingConfig.Data)
+	}
+	if c.clientCAConfigured() {
+		annotations[clientCAAnnotation] = rmeta.AnnotationHash(c.cfg.ClientCABundle)
+	}
 
 	d := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
@@ -952,7 +998,8 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 			Strategy: appsv1.DeploymentStrategy{
 				Type: appsv1.RecreateDeploymentStrategyType,
 			},
-			Selector: c.deploymentSelector(),
+			RevisionHistoryLimit: c.cfg.APIServer.RevisionHistoryLimit,
+			Selector:             c.deploymentSelector(),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      name,
@@ -983,6 +1030,10 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 		d.Spec.Template.Spec.Affinity = podaffinity.NewPodAntiAffinity(name, rmeta.APIServerNamespace(c.cfg.Installation.Variant))
 	}
 
+	if gracePeriod := c.terminationGracePeriodSeconds(); gracePeriod != nil {
+		d.Spec.Template.Spec.TerminationGracePeriodSeconds = gracePeriod
+	}
+
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
 		d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, c.queryServerContainer())
 
@@ -1001,6 +1052,44 @@ func (c *apiServerComponent) apiServerDeployment() *appsv1.Deployment {
 	return d
 }
 
+// egressSelectorConfigured returns whether the API server should be started with an egress selector configuration.
+func (c *apiServerComponent) egressSelectorConfigured() bool {
+	return c.cfg.EgressSelectorConfig != nil && c.cfg.APIServer.EgressSelectorConfigMap != nil
+}
+
+// tracingConfigured returns whether the API server should be started with a tracing configuration.
+func (c *apiServerComponent) tracingConfigured() bool {
+	return c.cfg.TracingConfig != nil && c.cfg.APIServer.TracingConfigMap != nil
+}
+
+// clientCAConfigured returns whether the API server should be started with a client CA bundle.
+func (c *apiServerComponent) clientCAConfigured() bool {
+	return c.cfg.ClientCABundle != "" && c.cfg.APIServer.ClientCAConfigMap != nil
+}
+
+// clientCAConfigMap returns the ConfigMap holding the merged client CA bundle of the API server.
+func (c *apiServerComponent) clientCAConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      APIServerClientCAName,
+			Namespace: rmeta.APIServerNamespace(c.cfg.Installation.Variant),
+		},
+		Data: map[string]string{clientCAFileName: c.cfg.ClientCABundle},
+	}
+}
+
+// terminationGracePeriodSeconds returns the termination grace period needed to cover the configured shutdown delay,
+// rounded up to the nearest second, on top of the default grace period, or nil if no shutdown delay is configured.
+func (c *apiServerComponent) terminationGracePeriodSeconds() *int64 {
+	d := c.cfg.APIServer.ShutdownDelayDuration
+	if d == nil {
+		return nil
+	}
+	gracePeriod := int64(corev1.DefaultTerminationGracePeriodSeconds) + int64(math.Ceil(d.Duration.Seconds()))
+	return &gracePeriod
+}
+
 func (c *apiServerComponent) hostNetwork() bool {
 	hostNetwork := c.cfg.ForceHostNetwork
 	if (c.cfg.Installation.KubernetesProvider == operatorv1.ProviderEKS || c.cfg.Installation.KubernetesProvider == operatorv1.ProviderTKG) &&
@@ -1025,6 +1114,15 @@ func (c *apiServerComponent) apiServerContainer() corev1.Container {
 			corev1.VolumeMount{Name: auditPolicyVolumeName, MountPath: "/etc/tigera/audit"},
 		)
 	}
+	if c.egressSelectorConfigured() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: egressSelectorVolumeName, MountPath: egressSelectorMountPath, ReadOnly: true})
+	}
+	if c.tracingConfigured() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: tracingVolumeName, MountPath: tracingMountPath, ReadOnly: true})
+	}
+	if c.clientCAConfigured() {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: clientCAVolumeName, MountPath: clientCAMountPath, ReadOnly: true})
+	}
 
 	env := []corev1.EnvVar{
 		{Name: "DATASTORE_TYPE", Value: "kubernetes"},
@@ -1078,11 +1176,29 @@ func (c *apiServerComponent) startUpArgs() []string {
 		fmt.Sprintf("--tls-cert-file=%s", c.cfg.TLSKeyPair.VolumeMountCertificateFilePath()),
 	}
 
+	if c.cfg.TLSMinVersion != "" {
+		args = append(args, fmt.Sprintf("--tls-min-version=%s", c.cfg.TLSMinVersion))
+	}
+	if len(c.cfg.TLSCipherSuites) > 0 {
+		args = append(args, fmt.Sprintf("--tls-cipher-suites=%s", strings.Join(c.cfg.TLSCipherSuites, ",")))
+	}
+
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
 		args = append(args,
 			"--audit-policy-file=/etc/tigera/audit/policy.conf",
 			"--audit-log-path=/var/log/calico/audit/tsee-audit.log",
 		)
+		if rotation := c.cfg.APIServer.AuditLogRotation; rotation != nil {
+			if rotation.MaxAge != nil {
+				args = append(args, fmt.Sprintf("--audit-log-maxage=%d", *rotation.MaxAge))
+			}
+			if rotation.MaxBackup != nil {
+				args = append(args, fmt.Sprintf("--audit-log-maxbackup=%d", *rotation.MaxBackup))
+			}
+			if rotation.MaxSize != nil {
+				args = append(args, fmt.Sprintf("--audit-log-maxsize=%d", *rotation.MaxSize))
+			}
+		}
 	}
 
 	if c.cfg.ManagementCluster != nil {
@@ -1096,6 +1212,60 @@ func (c *apiServerComponent) startUpArgs() []string {
 		}
 	}
 
+	if c.cfg.APIServer.WatchProgressNotifyInterval != nil {
+		args = append(args, fmt.Sprintf("--watch-progress-notify-interval=%s", c.cfg.APIServer.WatchProgressNotifyInterval.Duration))
+	}
+
+	if c.egressSelectorConfigured() {
+		args = append(args, fmt.Sprintf("--egress-selector-config-file=%s/%s", egressSelectorMountPath, egressSelectorConfigFileName))
+	}
+
+	if c.tracingConfigured() {
+		args = append(args, fmt.Sprintf("--tracing-config-file=%s/%s", tracingMountPath, tracingConfigFileName))
+	}
+
+	if c.clientCAConfigured() {
+		args = append(args, fmt.Sprintf("--client-ca-file=%s/%s", clientCAMountPath, clientCAFileName))
+	}
+
+	if c.cfg.APIServer.ShutdownDelayDuration != nil {
+		args = append(args, fmt.Sprintf("--shutdown-delay-duration=%s", c.cfg.APIServer.ShutdownDelayDuration.Duration))
+	}
+
+	for _, check := range c.cfg.APIServer.LivezExcludedChecks {
+		args = append(args, fmt.Sprintf("--livez-exclude=%s", check))
+	}
+	for _, check := range c.cfg.APIServer.ReadyzExcludedChecks {
+		args = append(args, fmt.Sprintf("--readyz-exclude=%s", check))
+	}
+	if c.cfg.APIServer.ContentionProfiling != nil && *c.cfg.APIServer.ContentionProfiling {
+		args = append(args, "--contention-profiling=true")
+	}
+	if c.cfg.APIServer.ServiceAccountLookup != nil {
+		args = append(args, fmt.Sprintf("--service-account-lookup=%t", *c.cfg.APIServer.ServiceAccountLookup))
+	}
+	if c.cfg.APIServer.EnableAggregatorRouting != nil {
+		args = append(args, fmt.Sprintf("--enable-aggregator-routing=%t", *c.cfg.APIServer.EnableAggregatorRouting))
+	}
+	if len(c.cfg.APIServer.CORSAllowedOrigins) > 0 {
+		args = append(args, fmt.Sprintf("--cors-allowed-origins=%s", strings.Join(c.cfg.APIServer.CORSAllowedOrigins, ",")))
+	}
+	if len(c.cfg.APIServer.APIAudiences) > 0 {
+		args = append(args, fmt.Sprintf("--api-audiences=%s", strings.Join(c.cfg.APIServer.APIAudiences, ",")))
+	}
+	if t := c.cfg.APIServer.MinRequestTimeout; t != nil {
+		args = append(args, fmt.Sprintf("--min-request-timeout=%d", int64(t.Seconds())))
+	}
+	if ttl := c.cfg.APIServer.EventTTL; ttl != nil {
+		args = append(args, fmt.Sprintf("--event-ttl=%s", ttl.Duration))
+	}
+	if n := c.cfg.APIServer.HTTP2MaxStreamsPerConnection; n != nil {
+		args = append(args, fmt.Sprintf("--http2-max-streams-per-connection=%d", *n))
+	}
+	if c.cfg.GoAwayChance != "" {
+		args = append(args, fmt.Sprintf("--goaway-chance=%s", c.cfg.GoAwayChance))
+	}
+
 	return args
 }
 
@@ -1186,6 +1356,51 @@ func (c *apiServerComponent) apiServerVolumes() []corev1.Volume {
 		}
 	}
 
+	if c.egressSelectorConfigured() {
+		volumes = append(volumes, corev1.Volume{
+			Name: egressSelectorVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: c.cfg.EgressSelectorConfig.Name},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  c.cfg.APIServer.EgressSelectorConfigMap.Key,
+							Path: egressSelectorConfigFileName,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if c.tracingConfigured() {
+		volumes = append(volumes, corev1.Volume{
+			Name: tracingVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: c.cfg.TracingConfig.Name},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  c.cfg.APIServer.TracingConfigMap.Key,
+							Path: tracingConfigFileName,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if c.clientCAConfigured() {
+		volumes = append(volumes, corev1.Volume{
+			Name: clientCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: APIServerClientCAName},
+				},
+			},
+		})
+	}
+
 	return volumes
 }
 
@@ -36,6 +36,7 @@ import (
 	"github.com/tigera/operator/pkg/controller/k8sapi"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/podaffinity"
@@ -54,6 +55,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -373,6 +375,24 @@ var _ = Describe("API server rendering tests (Calico Enterprise)", func() {
 		Expect(d.Spec.Template.Spec.Containers[1].Env).To(ContainElement(corev1.EnvVar{Name: "FIPS_MODE_ENABLED", Value: "true"}))
 	})
 
+	It("should render the images of components with a registry override from that registry", func() {
+		cfg.Installation.RegistryOverrides = map[string]string{
+			components.ComponentQueryServer.Image: "mirror.example.com/",
+		}
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Image).To(Equal(
+			fmt.Sprintf("testregistry.com/%s:%s", components.ComponentAPIServer.Image, components.ComponentAPIServer.Version),
+		))
+		Expect(d.Spec.Template.Spec.Containers[1].Image).To(Equal(
+			fmt.Sprintf("mirror.example.com/%s:%s", components.ComponentQueryServer.Image, components.ComponentQueryServer.Version),
+		))
+	})
+
 	It("should render an API server with custom configuration", func() {
 		expectedResources := []struct {
 			name    string
@@ -428,6 +448,474 @@ var _ = Describe("API server rendering tests (Calico Enterprise)", func() {
 		Expect(d.Spec.Template.Spec.Volumes).To(HaveLen(4))
 	})
 
+	It("should render the watch progress notify interval when specified", func() {
+		cfg.APIServer.WatchProgressNotifyInterval = &metav1.Duration{Duration: 5 * time.Second}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--watch-progress-notify-interval=5s"))
+	})
+
+	It("should render the health check exclusions when specified", func() {
+		cfg.APIServer.LivezExcludedChecks = []string{"etcd"}
+		cfg.APIServer.ReadyzExcludedChecks = []string{"etcd", "poststarthook/start-informers"}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+			"--livez-exclude=etcd",
+			"--readyz-exclude=etcd",
+			"--readyz-exclude=poststarthook/start-informers",
+		))
+	})
+
+	It("should render the CORS allowed origins as a single list when specified", func() {
+		cfg.APIServer.CORSAllowedOrigins = []string{`//dashboard\.example\.com$`, `//localhost(:[0-9]+)?$`}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement(`--cors-allowed-origins=//dashboard\.example\.com$,//localhost(:[0-9]+)?$`))
+	})
+
+	It("should render the API audiences as a single list when specified", func() {
+		cfg.APIServer.APIAudiences = []string{"https://kubernetes.default.svc", "vault"}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--api-audiences=https://kubernetes.default.svc,vault"))
+	})
+
+	It("should not render the API audiences by default", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, arg := range d.Spec.Template.Spec.Containers[0].Args {
+			Expect(arg).NotTo(HavePrefix("--api-audiences"))
+		}
+	})
+
+	It("should render the minimum request timeout when specified", func() {
+		cfg.APIServer.MinRequestTimeout = &metav1.Duration{Duration: time.Hour}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--min-request-timeout=3600"))
+	})
+
+	It("should render the event TTL when specified", func() {
+		cfg.APIServer.EventTTL = &metav1.Duration{Duration: 24 * time.Hour}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--event-ttl=24h0m0s"))
+	})
+
+	It("should not render the event TTL by default", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, arg := range d.Spec.Template.Spec.Containers[0].Args {
+			Expect(arg).NotTo(HavePrefix("--event-ttl"))
+		}
+	})
+
+	It("should render the HTTP/2 max streams per connection when specified", func() {
+		var maxStreams int32 = 2000
+		cfg.APIServer.HTTP2MaxStreamsPerConnection = &maxStreams
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Name).To(Equal("calico-apiserver"))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--http2-max-streams-per-connection=2000"))
+	})
+
+	It("should render the GOAWAY chance when specified", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--goaway-chance")))
+
+		cfg.GoAwayChance = "0.001"
+		component, err = render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ = component.Objects()
+		d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--goaway-chance=0.001"))
+	})
+
+	It("should render the configured revision history limit", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.RevisionHistoryLimit).To(BeNil())
+
+		cfg.APIServer.RevisionHistoryLimit = ptr.Int32ToPtr(2)
+		component, err = render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ = component.Objects()
+		d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.RevisionHistoryLimit).To(Equal(ptr.Int32ToPtr(2)))
+	})
+
+	It("should render the audit log rotation args when specified", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, arg := range d.Spec.Template.Spec.Containers[0].Args {
+			Expect(arg).NotTo(HavePrefix("--audit-log-max"))
+		}
+
+		cfg.APIServer.AuditLogRotation = &operatorv1.APIServerAuditLogRotation{
+			MaxAge:  ptr.Int32ToPtr(30),
+			MaxSize: ptr.Int32ToPtr(100),
+		}
+		component, err = render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ = component.Objects()
+		d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+			"--audit-log-path=/var/log/calico/audit/tsee-audit.log",
+			"--audit-log-maxage=30",
+			"--audit-log-maxsize=100",
+		))
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--audit-log-maxbackup")))
+
+		cfg.APIServer.AuditLogRotation.MaxBackup = ptr.Int32ToPtr(5)
+		component, err = render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ = component.Objects()
+		d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--audit-log-maxbackup=5"))
+	})
+
+	It("should render contention profiling only when enabled", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--contention-profiling")))
+
+		enabled := true
+		cfg.APIServer.ContentionProfiling = &enabled
+		component, err = render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ = component.Objects()
+
+		d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--contention-profiling=true"))
+	})
+
+	It("should render the service account lookup flag when specified", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--service-account-lookup")))
+
+		for _, lookup := range []bool{true, false} {
+			cfg.APIServer.ServiceAccountLookup = &lookup
+			component, err = render.APIServer(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			resources, _ = component.Objects()
+
+			d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement(fmt.Sprintf("--service-account-lookup=%t", lookup)))
+		}
+	})
+
+	It("should render the aggregator routing flag when specified", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--enable-aggregator-routing")))
+
+		for _, routing := range []bool{true, false} {
+			cfg.APIServer.EnableAggregatorRouting = &routing
+			component, err = render.APIServer(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(component.ResolveImages(nil)).To(BeNil())
+			resources, _ = component.Objects()
+
+			d = rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Args).To(ContainElement(fmt.Sprintf("--enable-aggregator-routing=%t", routing)))
+		}
+	})
+
+	It("should render the shutdown delay when specified", func() {
+		cfg.APIServer.ShutdownDelayDuration = &metav1.Duration{Duration: 14500 * time.Millisecond}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		apiServer := d.Spec.Template.Spec.Containers[0]
+		Expect(apiServer.Name).To(Equal("calico-apiserver"))
+		Expect(apiServer.Args).To(ContainElement("--shutdown-delay-duration=14.5s"))
+		Expect(*d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeEquivalentTo(45))
+	})
+
+	It("should render the egress selector configuration when specified", func() {
+		cfg.APIServer.EgressSelectorConfigMap = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "egress-selector"},
+			Key:                  "config.yaml",
+		}
+		cfg.EgressSelectorConfig = &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "egress-selector", Namespace: common.OperatorNamespace()},
+			Data:       map[string]string{"config.yaml": "kind: EgressSelectorConfiguration"},
+		}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		cm := rtest.GetResource(resources, "egress-selector", "tigera-system", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(cm.Data).To(Equal(cfg.EgressSelectorConfig.Data))
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/egress-selector-config"))
+		Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "egress-selector-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "egress-selector"},
+					Items:                []corev1.KeyToPath{{Key: "config.yaml", Path: "egress-selector-config.yaml"}},
+				},
+			},
+		}))
+
+		apiServer := d.Spec.Template.Spec.Containers[0]
+		Expect(apiServer.Name).To(Equal("calico-apiserver"))
+		Expect(apiServer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "egress-selector-config",
+			MountPath: "/etc/tigera/egress-selector",
+			ReadOnly:  true,
+		}))
+		Expect(apiServer.Args).To(ContainElement("--egress-selector-config-file=/etc/tigera/egress-selector/egress-selector-config.yaml"))
+	})
+
+	It("should render the PodDisruptionBudget with the configured minAvailable", func() {
+		minAvailable := intstr.FromString("50%")
+		cfg.APIServer.PodDisruptionBudget = &operatorv1.PodDisruptionBudgetConfig{MinAvailable: &minAvailable}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		pdb := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+		Expect(pdb.Spec.MinAvailable).To(Equal(&minAvailable))
+		Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+		Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{"apiserver": "true"}))
+	})
+
+	It("should default the PodDisruptionBudget to a single unavailable pod", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		maxUnavailable := intstr.FromInt(1)
+		pdb := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+		Expect(pdb.Spec.MaxUnavailable).To(Equal(&maxUnavailable))
+		Expect(pdb.Spec.MinAvailable).To(BeNil())
+	})
+
+	It("should render the tracing configuration when specified", func() {
+		cfg.APIServer.TracingConfigMap = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-tracing"},
+			Key:                  "tracing.yaml",
+		}
+		cfg.TracingConfig = &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "apiserver-tracing", Namespace: common.OperatorNamespace()},
+			Data:       map[string]string{"tracing.yaml": "kind: TracingConfiguration"},
+		}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		cm := rtest.GetResource(resources, "apiserver-tracing", "tigera-system", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(cm.Data).To(Equal(cfg.TracingConfig.Data))
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/tracing-config"))
+		Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "tracing-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-tracing"},
+					Items:                []corev1.KeyToPath{{Key: "tracing.yaml", Path: "tracing-config.yaml"}},
+				},
+			},
+		}))
+
+		apiServer := d.Spec.Template.Spec.Containers[0]
+		Expect(apiServer.Name).To(Equal("calico-apiserver"))
+		Expect(apiServer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "tracing-config",
+			MountPath: "/etc/tigera/tracing",
+			ReadOnly:  true,
+		}))
+		Expect(apiServer.Args).To(ContainElement("--tracing-config-file=/etc/tigera/tracing/tracing-config.yaml"))
+	})
+
+	It("should render the client CA bundle when specified", func() {
+		cfg.APIServer.ClientCAConfigMap = &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "apiserver-client-ca"},
+			Key:                  "ca.crt",
+		}
+		cfg.ClientCABundle = "cluster-ca\nextra-ca\n"
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		cm := rtest.GetResource(resources, "calico-apiserver-client-ca", "tigera-system", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(cm.Data).To(Equal(map[string]string{"client-ca.crt": "cluster-ca\nextra-ca\n"}))
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/client-ca"))
+		Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "client-ca",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "calico-apiserver-client-ca"},
+				},
+			},
+		}))
+
+		apiServer := d.Spec.Template.Spec.Containers[0]
+		Expect(apiServer.Name).To(Equal("calico-apiserver"))
+		Expect(apiServer.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "client-ca",
+			MountPath: "/etc/tigera/client-ca",
+			ReadOnly:  true,
+		}))
+		Expect(apiServer.Args).To(ContainElement("--client-ca-file=/etc/tigera/client-ca/client-ca.crt"))
+	})
+
+	It("should not pass a client CA file by default", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		Expect(rtest.GetResource(resources, "calico-apiserver-client-ca", "tigera-system", "", "v1", "ConfigMap")).To(BeNil())
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, arg := range d.Spec.Template.Spec.Containers[0].Args {
+			Expect(arg).NotTo(HavePrefix("--client-ca-file"))
+		}
+	})
+
+	It("should render the TLS minimum version and cipher suites when specified", func() {
+		cfg.TLSMinVersion = "VersionTLS12"
+		cfg.TLSCipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		apiServer := d.Spec.Template.Spec.Containers[0]
+		Expect(apiServer.Name).To(Equal("calico-apiserver"))
+		Expect(apiServer.Args).To(ContainElements(
+			"--tls-min-version=VersionTLS12",
+			"--tls-cipher-suites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		))
+	})
+
+	It("should not pass TLS version or cipher suite flags by default", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, arg := range d.Spec.Template.Spec.Containers[0].Args {
+			Expect(arg).NotTo(HavePrefix("--tls-min-version"))
+			Expect(arg).NotTo(HavePrefix("--tls-cipher-suites"))
+		}
+	})
+
+	It("should not render a shutdown delay by default", func() {
+		component, err := render.APIServer(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "tigera-apiserver", "tigera-system", "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Lifecycle).To(BeNil())
+		Expect(d.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement(HavePrefix("--shutdown-delay-duration")))
+		Expect(d.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+	})
+
 	It("should render needed resources for k8s kube-controller", func() {
 		expectedResources := []struct {
 			name    string
@@ -48,11 +48,8 @@ func (c *awsSGSetupComponent) SupportedOSType() rmeta.OSType {
 }
 
 func (c *awsSGSetupComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
-	c.image, err = components.GetReference(components.ComponentOperatorInit, reg, path, prefix, is)
+	c.image, err = components.GetInstallationReference(components.ComponentOperatorInit, c.cfg.Installation, is)
 	return err
 }
 
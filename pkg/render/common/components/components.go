@@ -16,6 +16,7 @@ package components
 
 import (
 	"fmt"
+	"reflect"
 
 	kbv1 "github.com/elastic/cloud-on-k8s/v2/pkg/apis/kibana/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -255,8 +256,9 @@ func ApplyPrometheusOverrides(prom *monitoringv1.Prometheus, overrides *operator
 		mergeContainers(prometheusFields.Containers, containers)
 	}
 
-	// Define resources requests and limits for prometheus Pods.
-	if resources := overrides.GetPrometheusResource(); resources != nil {
+	// Define resources requests and limits for prometheus Pods. Empty resources keep the defaults, so that overriding
+	// only the containers does not drop the default requests of Prometheus.
+	if resources := overrides.GetPrometheusResource(); resources != nil && !reflect.DeepEqual(*resources, corev1.ResourceRequirements{}) {
 		prometheusFields.Resources = *resources
 	}
 
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+)
+
+// New returns a PodDisruptionBudget for the pods matching the given selector. The budget is taken from cfg;
+// when cfg sets neither minAvailable nor maxUnavailable, a single pod is allowed to be unavailable.
+func New(name, namespace string, selector *metav1.LabelSelector, cfg *operatorv1.PodDisruptionBudgetConfig) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: selector,
+		},
+	}
+
+	switch {
+	case cfg != nil && cfg.MinAvailable != nil:
+		minAvailable := *cfg.MinAvailable
+		pdb.Spec.MinAvailable = &minAvailable
+	case cfg != nil && cfg.MaxUnavailable != nil:
+		maxUnavailable := *cfg.MaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return pdb
+}
+
+// Validate returns an error if cfg is not a usable budget for a component running the given number of replicas.
+// A nil cfg is always valid.
+func Validate(cfg *operatorv1.PodDisruptionBudgetConfig, replicas int32) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MinAvailable != nil && cfg.MaxUnavailable != nil {
+		return fmt.Errorf("minAvailable and maxUnavailable cannot both be set")
+	}
+	if replicas <= 1 {
+		return fmt.Errorf("a PodDisruptionBudget requires more than one replica, the component runs %d", replicas)
+	}
+
+	if cfg.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(cfg.MinAvailable, int(replicas), true)
+		if err != nil {
+			return fmt.Errorf("invalid minAvailable: %w", err)
+		}
+		if minAvailable < 0 {
+			return fmt.Errorf("minAvailable %s must not be negative", cfg.MinAvailable.String())
+		}
+		if minAvailable >= int(replicas) {
+			return fmt.Errorf("minAvailable %s must be less than the replica count %d", cfg.MinAvailable.String(), replicas)
+		}
+	}
+	if cfg.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(cfg.MaxUnavailable, int(replicas), true)
+		if err != nil {
+			return fmt.Errorf("invalid maxUnavailable: %w", err)
+		}
+		if maxUnavailable < 1 {
+			return fmt.Errorf("maxUnavailable %s must allow at least one pod to be unavailable", cfg.MaxUnavailable.String())
+		}
+		if maxUnavailable > int(replicas) {
+			return fmt.Errorf("maxUnavailable %s must not exceed the replica count %d", cfg.MaxUnavailable.String(), replicas)
+		}
+	}
+	return nil
+}
@@ -17,14 +17,18 @@ package render
 import (
 	"crypto/x509"
 	"fmt"
+	"path"
 	"strings"
 
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 
 	ocsv1 "github.com/openshift/api/security/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,12 +37,15 @@ import (
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
 	"github.com/tigera/operator/pkg/components"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render/common/authentication"
 	rcomponents "github.com/tigera/operator/pkg/render/common/components"
 	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	"github.com/tigera/operator/pkg/render/common/podsecuritypolicy"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
@@ -76,6 +83,24 @@ const (
 	ComplianceReporterSecret    = "tigera-compliance-reporter-tls"
 )
 
+const (
+	// ComplianceMetricsPort is the port on which the compliance components serve Prometheus metrics.
+	ComplianceMetricsPort     = 9081
+	complianceMetricsPortName = "metrics-port"
+)
+
+// complianceMetricsComponents are the long running compliance components that serve Prometheus metrics.
+var complianceMetricsComponents = []string{ComplianceControllerName, ComplianceSnapshotterName, ComplianceBenchmarkerName}
+
+const (
+	benchmarkerProfileVolumeName = "benchmark-profile"
+	benchmarkerProfileMountPath  = "/etc/tigera/benchmark"
+	benchmarkerProfileFileName   = "profile.yaml"
+	benchmarkerProfileAnnotation = "hash.operator.tigera.io/benchmark-profile"
+
+	benchmarkerResultsVolumeName = "benchmark-results"
+)
+
 // Register secret/certs that need Server and Client Key usage
 func init() {
 	certkeyusage.SetCertKeyUsage(ComplianceServerCertSecret, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth})
@@ -84,7 +109,33 @@ func init() {
 	certkeyusage.SetCertKeyUsage(ComplianceReporterSecret, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth})
 }
 
+// BenchmarkerHostMount is a host path that is mounted read only into the compliance benchmarker, so that the CIS
+// benchmark can inspect the node configuration stored there.
+type BenchmarkerHostMount struct {
+	// Name of the volume that holds the host path.
+	Name string
+
+	// HostPath is the absolute path on the node.
+	HostPath string
+
+	// MountPath is the absolute path the host path is mounted at in the benchmarker. It defaults to HostPath.
+	MountPath string
+}
+
+// DefaultBenchmarkerHostMounts are the host paths mounted into the compliance benchmarker when
+// ComplianceConfiguration.BenchmarkerHostMounts is not set.
+var DefaultBenchmarkerHostMounts = []BenchmarkerHostMount{
+	{Name: "var-lib-etcd", HostPath: "/var/lib/etcd"},
+	{Name: "var-lib-kubelet", HostPath: "/var/lib/kubelet"},
+	{Name: "etc-systemd", HostPath: "/etc/systemd"},
+	{Name: "etc-kubernetes", HostPath: "/etc/kubernetes"},
+	{Name: "usr-bin", HostPath: "/usr/bin", MountPath: "/usr/local/bin"},
+}
+
 func Compliance(cfg *ComplianceConfiguration) (Component, error) {
+	if err := validateBenchmarkerHostMounts(cfg.BenchmarkerHostMounts); err != nil {
+		return nil, err
+	}
 	return &complianceComponent{
 		cfg: cfg,
 	}, nil
@@ -120,6 +171,60 @@ type ComplianceConfiguration struct {
 	Tenant          *operatorv1.Tenant
 	ExternalElastic bool
 	Compliance      *operatorv1.Compliance
+
+	// BenchmarkerProfile is the ConfigMap referenced by Compliance.BenchmarkerProfileConfigMap, if any.
+	BenchmarkerProfile *corev1.ConfigMap
+
+	// NodeAffinity, if set, is applied to the compliance controller, reporter, server and snapshotter pods, for example
+	// to prefer the zone where Elasticsearch runs. The benchmarker runs on every node and is not affected.
+	NodeAffinity *corev1.NodeAffinity
+
+	// BenchmarkerHostMounts are the host paths mounted into the compliance benchmarker, as the paths the CIS
+	// benchmark needs to read vary by distribution. If nil, DefaultBenchmarkerHostMounts are mounted.
+	BenchmarkerHostMounts []BenchmarkerHostMount
+
+	// Whether the Monitor is installed, which provides the Prometheus instance that scrapes the compliance metrics.
+	MonitorEnabled bool
+}
+
+// ComplianceMetricsEnabled returns whether the compliance components serve Prometheus metrics for the Tigera
+// Prometheus instance to scrape.
+func ComplianceMetricsEnabled(compliance *operatorv1.Compliance, monitorEnabled bool) bool {
+	return monitorEnabled && compliance != nil && compliance.Spec.PrometheusMetrics != nil &&
+		*compliance.Spec.PrometheusMetrics == operatorv1.ComplianceMetricsEnabled
+}
+
+// ComplianceServerClientCertificateVerificationEnabled returns whether the Compliance requires the clients of the
+// compliance server to present a certificate signed by a trusted CA.
+func ComplianceServerClientCertificateVerificationEnabled(compliance *operatorv1.Compliance) bool {
+	return compliance != nil && compliance.Spec.ServerClientCertificateVerification != nil &&
+		*compliance.Spec.ServerClientCertificateVerification == operatorv1.ComplianceTLSVerificationEnabled
+}
+
+// ComplianceMetricsServiceName returns the name of the Service that exposes the metrics of the given compliance component.
+func ComplianceMetricsServiceName(component string) string {
+	return component + "-metrics"
+}
+
+// validateBenchmarkerHostMounts verifies that each host mount has a unique name and absolute paths.
+func validateBenchmarkerHostMounts(mounts []BenchmarkerHostMount) error {
+	names := map[string]bool{}
+	for _, m := range mounts {
+		if m.Name == "" {
+			return fmt.Errorf("benchmarker host mount for %q must have a name", m.HostPath)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("duplicate benchmarker host mount name %q", m.Name)
+		}
+		names[m.Name] = true
+		if !path.IsAbs(m.HostPath) {
+			return fmt.Errorf("benchmarker host mount %q host path %q must be absolute", m.Name, m.HostPath)
+		}
+		if m.MountPath != "" && !path.IsAbs(m.MountPath) {
+			return fmt.Errorf("benchmarker host mount %q mount path %q must be absolute", m.Name, m.MountPath)
+		}
+	}
+	return nil
 }
 
 type complianceComponent struct {
@@ -132,33 +237,30 @@ type complianceComponent struct {
 }
 
 func (c *complianceComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
-	c.benchmarkerImage, err = components.GetReference(components.ComponentComplianceBenchmarker, reg, path, prefix, is)
+	c.benchmarkerImage, err = components.GetInstallationReference(components.ComponentComplianceBenchmarker, c.cfg.Installation, is)
 
 	errMsgs := []string{}
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	c.snapshotterImage, err = components.GetReference(components.ComponentComplianceSnapshotter, reg, path, prefix, is)
+	c.snapshotterImage, err = components.GetInstallationReference(components.ComponentComplianceSnapshotter, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	c.serverImage, err = components.GetReference(components.ComponentComplianceServer, reg, path, prefix, is)
+	c.serverImage, err = components.GetInstallationReference(components.ComponentComplianceServer, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	c.controllerImage, err = components.GetReference(components.ComponentComplianceController, reg, path, prefix, is)
+	c.controllerImage, err = components.GetInstallationReference(components.ComponentComplianceController, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	c.reporterImage, err = components.GetReference(components.ComponentComplianceReporter, reg, path, prefix, is)
+	c.reporterImage, err = components.GetInstallationReference(components.ComponentComplianceReporter, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -227,7 +329,12 @@ func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 			c.complianceBenchmarkerServiceAccount(),
 			c.complianceBenchmarkerClusterRole(),
 			c.complianceBenchmarkerClusterRoleBinding(),
-			c.complianceBenchmarkerDaemonSet(),
+			c.complianceBenchmarker(),
+		)
+		if c.benchmarkerProfileConfigured() {
+			complianceObjs = append(complianceObjs, configmap.ToRuntimeObjects(configmap.CopyToNamespace(c.cfg.Namespace, c.cfg.BenchmarkerProfile)...)...)
+		}
+		complianceObjs = append(complianceObjs,
 
 			c.complianceGlobalReportInventory(),
 			c.complianceGlobalReportNetworkAccess(),
@@ -247,6 +354,27 @@ func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 	}
 
 	var objsToDelete []client.Object
+	if !c.cfg.Tenant.MultiTenant() {
+		// Clean up the benchmarker workload of the run mode that is not in use.
+		if c.benchmarkerRunMode() == operatorv1.BenchmarkerRunModeCronJob {
+			objsToDelete = append(objsToDelete, &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"}, ObjectMeta: metav1.ObjectMeta{Name: ComplianceBenchmarkerName, Namespace: c.cfg.Namespace}})
+		} else {
+			objsToDelete = append(objsToDelete, &batchv1.CronJob{TypeMeta: metav1.TypeMeta{Kind: "CronJob", APIVersion: "batch/v1"}, ObjectMeta: metav1.ObjectMeta{Name: ComplianceBenchmarkerName, Namespace: c.cfg.Namespace}})
+		}
+
+		for _, name := range complianceMetricsComponents {
+			if c.metricsEnabled() {
+				complianceObjs = append(complianceObjs, c.metricsService(name), c.metricsServiceMonitor(name))
+			} else {
+				objsToDelete = append(objsToDelete, c.metricsService(name))
+				// ServiceMonitors can only be cleaned up while the Monitor, which installs their CRD, is installed.
+				if c.cfg.MonitorEnabled {
+					objsToDelete = append(objsToDelete, c.metricsServiceMonitor(name))
+				}
+			}
+		}
+	}
+
 	if c.cfg.ManagementClusterConnection == nil {
 		complianceObjs = append(complianceObjs,
 			c.complianceServerAllowTigeraNetworkPolicy(),
@@ -254,6 +382,11 @@ func (c *complianceComponent) Objects() ([]client.Object, []client.Object) {
 			c.complianceServerService(),
 			c.complianceServerDeployment(),
 		)
+		if pdb := c.complianceServerPodDisruptionBudget(); pdb != nil {
+			complianceObjs = append(complianceObjs, pdb)
+		} else {
+			objsToDelete = append(objsToDelete, &policyv1.PodDisruptionBudget{TypeMeta: metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"}, ObjectMeta: metav1.ObjectMeta{Name: ComplianceServerName, Namespace: c.cfg.Namespace}})
+		}
 	} else {
 		// Compliance server is only for Standalone or Management clusters
 		objsToDelete = append(objsToDelete, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: ComplianceServerName, Namespace: c.cfg.Namespace}})
@@ -294,6 +427,21 @@ func (c *complianceComponent) Ready() bool {
 	return true
 }
 
+// Dependencies returns the objects that must exist before the compliance objects are created: the namespace they are
+// created in and, when allow-tigera policies are rendered, the tier those policies belong to.
+func (c *complianceComponent) Dependencies() []client.Object {
+	deps := []client.Object{
+		&corev1.Namespace{TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: c.cfg.Namespace}},
+	}
+	if !c.cfg.Tenant.MultiTenant() || c.cfg.ManagementClusterConnection == nil {
+		deps = append(deps, &v3.Tier{
+			TypeMeta:   metav1.TypeMeta{Kind: "Tier", APIVersion: "projectcalico.org/v3"},
+			ObjectMeta: metav1.ObjectMeta{Name: networkpolicy.TigeraComponentTierName},
+		})
+	}
+	return deps
+}
+
 var (
 	complianceBoolTrue       = true
 	complianceReplicas int32 = 1
@@ -301,6 +449,17 @@ var (
 
 const complianceServerPort = 5443
 
+// ComplianceServerReplicas returns the number of replicas the compliance server runs.
+func ComplianceServerReplicas() int32 {
+	return complianceReplicas
+}
+
+// defaultBenchmarkerSchedule is the schedule used for the benchmarker CronJob when none is configured.
+const defaultBenchmarkerSchedule = "0 * * * *"
+
+// defaultComplianceJobBackoffLimit is the number of retries of a failed compliance job when none is configured.
+const defaultComplianceJobBackoffLimit int32 = 6
+
 func (c *complianceComponent) complianceControllerServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta:   metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
@@ -470,7 +629,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 		{Name: "LOG_LEVEL", Value: "info"},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "TIGERA_COMPLIANCE_MAX_FAILED_JOBS_HISTORY", Value: "3"},
-		{Name: "TIGERA_COMPLIANCE_MAX_JOB_RETRIES", Value: "6"},
+		{Name: "TIGERA_COMPLIANCE_MAX_JOB_RETRIES", Value: fmt.Sprint(c.jobBackoffLimit())},
 		{Name: "LINSEED_CLIENT_CERT", Value: certPath},
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
@@ -482,6 +641,10 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 			envVars = append(envVars, corev1.EnvVar{Name: "TENANT_ID", Value: c.cfg.Tenant.Spec.ID})
 		}
 	}
+	envVars = append(envVars, c.timezoneEnv()...)
+	envVars = append(envVars, c.noProxyEnv()...)
+	envVars = append(envVars, c.metricsEnv(c.cfg.ControllerKeyPair)...)
+	envVars = append(envVars, c.reportRetentionEnv()...)
 
 	var initContainers []corev1.Container
 	if c.cfg.ControllerKeyPair != nil && c.cfg.ControllerKeyPair.UseCertificateManagement() {
@@ -496,6 +659,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 			ServiceAccountName: ComplianceControllerServiceAccount,
 			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+			Affinity:           c.affinity(),
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
 			Containers: []corev1.Container{
@@ -504,6 +668,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 					Image:           c.controllerImage,
 					ImagePullPolicy: ImagePullPolicy(),
 					Env:             envVars,
+					Ports:           c.metricsPorts(),
 					LivenessProbe: &corev1.Probe{
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
@@ -543,6 +708,7 @@ func (c *complianceComponent) complianceControllerDeployment() *appsv1.Deploymen
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
 		}
 	}
+	c.setSoftMemoryLimit(&d.Spec.Template.Spec)
 	return d
 }
 
@@ -652,6 +818,11 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 			envVars = append(envVars, corev1.EnvVar{Name: "TENANT_ID", Value: c.cfg.Tenant.Spec.ID})
 		}
 	}
+	envVars = append(envVars, c.timezoneEnv()...)
+	envVars = append(envVars, c.noProxyEnv()...)
+	if formats := c.reportOutputFormats(); formats != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_OUTPUT_FORMATS", Value: formats})
+	}
 
 	volumes := []corev1.Volume{
 		{
@@ -716,6 +887,7 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 				ServiceAccountName: ComplianceReporterServiceAccount,
 				Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
 				NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+				Affinity:           c.affinity(),
 				ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 				InitContainers:     initContainers,
 				Containers: []corev1.Container{
@@ -750,6 +922,7 @@ func (c *complianceComponent) complianceReporterPodTemplate() *corev1.PodTemplat
 			rcomponents.ApplyPodTemplateOverrides(podtemplate, overrides)
 		}
 	}
+	c.setSoftMemoryLimit(&podtemplate.Template.Spec)
 
 	return podtemplate
 }
@@ -914,6 +1087,196 @@ func (c *complianceComponent) complianceServerService() *corev1.Service {
 	}
 }
 
+// complianceServerPodDisruptionBudget returns the configured PodDisruptionBudget for the compliance server, or nil if
+// none is configured or the compliance server does not run more than one replica.
+func (c *complianceComponent) complianceServerPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	if c.cfg.Compliance == nil || c.cfg.Compliance.Spec.ComplianceServerPodDisruptionBudget == nil || complianceReplicas <= 1 {
+		return nil
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": ComplianceServerName}}
+	return poddisruptionbudget.New(ComplianceServerName, c.cfg.Namespace, selector, c.cfg.Compliance.Spec.ComplianceServerPodDisruptionBudget)
+}
+
+// timezoneEnv returns the TZ environment variable for the compliance containers, if a timezone is configured.
+func (c *complianceComponent) timezoneEnv() []corev1.EnvVar {
+	if c.cfg.Compliance == nil || c.cfg.Compliance.Spec.Timezone == "" {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: "TZ", Value: c.cfg.Compliance.Spec.Timezone}}
+}
+
+// defaultSoftMemoryLimitPercent is the percentage of their memory limit that the compliance containers use as their
+// soft memory limit when the Compliance does not specify one.
+const defaultSoftMemoryLimitPercent int32 = 90
+
+// setSoftMemoryLimit sets the GOMEMLIMIT env on each container of the pod spec that has a memory limit, so that the
+// Go runtime collects garbage more aggressively before the container is OOM killed. It must be called after the
+// overrides are applied, since they set the memory limits.
+func (c *complianceComponent) setSoftMemoryLimit(spec *corev1.PodSpec) {
+	percent := defaultSoftMemoryLimitPercent
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.SoftMemoryLimitPercent != nil {
+		percent = *c.cfg.Compliance.Spec.SoftMemoryLimitPercent
+	}
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		limit, ok := container.Resources.Limits[corev1.ResourceMemory]
+		if !ok || limit.IsZero() {
+			continue
+		}
+		container.Env = append(container.Env, corev1.EnvVar{Name: "GOMEMLIMIT", Value: fmt.Sprint(limit.Value() / 100 * int64(percent))})
+	}
+}
+
+// reportRetentionEnv returns the environment variables that configure the compliance controller to delete the
+// reports that fall outside of the Compliance ReportRetention.
+func (c *complianceComponent) reportRetentionEnv() []corev1.EnvVar {
+	if c.cfg.Compliance == nil || c.cfg.Compliance.Spec.ReportRetention == nil {
+		return nil
+	}
+	var env []corev1.EnvVar
+	retention := c.cfg.Compliance.Spec.ReportRetention
+	if retention.MaxAge != nil {
+		env = append(env, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION_MAX_AGE", Value: retention.MaxAge.Duration.String()})
+	}
+	if retention.MaxReports != nil {
+		env = append(env, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION_MAX_REPORTS", Value: fmt.Sprint(*retention.MaxReports)})
+	}
+	return env
+}
+
+// noProxyEnv returns the NO_PROXY environment variable for the compliance containers, so that connections to services
+// inside the cluster, such as Linseed and the Kubernetes API server, do not go through an HTTP proxy set in their
+// environment. It lists the service domains of the cluster, its service and IP pool CIDRs and the entries of the
+// Compliance NoProxy field.
+func (c *complianceComponent) noProxyEnv() []corev1.EnvVar {
+	entries := []string{".svc", "." + c.cfg.ClusterDomain}
+	entries = append(entries, c.cfg.Installation.ServiceCIDRs...)
+	if c.cfg.Installation.CalicoNetwork != nil {
+		for _, pool := range c.cfg.Installation.CalicoNetwork.IPPools {
+			entries = append(entries, pool.CIDR)
+		}
+	}
+	if c.cfg.Compliance != nil {
+		entries = append(entries, c.cfg.Compliance.Spec.NoProxy...)
+	}
+
+	var noProxy []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		noProxy = append(noProxy, entry)
+	}
+	return []corev1.EnvVar{{Name: "NO_PROXY", Value: strings.Join(noProxy, ",")}}
+}
+
+// metricsEnabled returns whether the compliance components serve Prometheus metrics.
+func (c *complianceComponent) metricsEnabled() bool {
+	return ComplianceMetricsEnabled(c.cfg.Compliance, c.cfg.MonitorEnabled)
+}
+
+// metricsEnv returns the environment variables that make a compliance container serve Prometheus metrics over TLS
+// with the given key pair, if metrics are enabled.
+func (c *complianceComponent) metricsEnv(keyPair certificatemanagement.KeyPairInterface) []corev1.EnvVar {
+	if !c.metricsEnabled() {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_ENABLED", Value: "true"},
+		{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_PORT", Value: fmt.Sprint(ComplianceMetricsPort)},
+		{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_CERT_FILE", Value: keyPair.VolumeMountCertificateFilePath()},
+		{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_KEY_FILE", Value: keyPair.VolumeMountKeyFilePath()},
+	}
+}
+
+// metricsPorts returns the container ports of a compliance container, which only has a port if metrics are enabled.
+func (c *complianceComponent) metricsPorts() []corev1.ContainerPort {
+	if !c.metricsEnabled() {
+		return nil
+	}
+	return []corev1.ContainerPort{{Name: complianceMetricsPortName, ContainerPort: ComplianceMetricsPort, Protocol: corev1.ProtocolTCP}}
+}
+
+// metricsService returns the headless Service that exposes the metrics port of the given compliance component.
+func (c *complianceComponent) metricsService(component string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ComplianceMetricsServiceName(component),
+			Namespace: c.cfg.Namespace,
+			Labels:    map[string]string{"k8s-app": component},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"k8s-app": component},
+			// The Service is only intended for Prometheus to scrape, so there is no need for a cluster IP.
+			ClusterIP: "None",
+			Ports: []corev1.ServicePort{
+				{
+					Name:       complianceMetricsPortName,
+					Port:       ComplianceMetricsPort,
+					TargetPort: intstr.FromInt(ComplianceMetricsPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// metricsServiceMonitor returns the ServiceMonitor that makes the Tigera Prometheus instance scrape the metrics of the
+// given compliance component.
+func (c *complianceComponent) metricsServiceMonitor(component string) *monitoringv1.ServiceMonitor {
+	return &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: "monitoring.coreos.com/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ComplianceMetricsServiceName(component),
+			Namespace: common.TigeraPrometheusNamespace,
+			Labels:    map[string]string{"team": "network-operators"},
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector:          metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": component}},
+			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{c.cfg.Namespace}},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					HonorLabels:   true,
+					Interval:      "30s",
+					Port:          complianceMetricsPortName,
+					ScrapeTimeout: "10s",
+					Scheme:        "https",
+					TLSConfig: &monitoringv1.TLSConfig{
+						CAFile: c.cfg.TrustedBundle.MountPath(),
+						SafeTLSConfig: monitoringv1.SafeTLSConfig{
+							ServerName: ComplianceMetricsServiceName(component),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// affinity returns the affinity for the compliance pods, if a node affinity is configured.
+func (c *complianceComponent) affinity() *corev1.Affinity {
+	if c.cfg.NodeAffinity == nil {
+		return nil
+	}
+	return &corev1.Affinity{NodeAffinity: c.cfg.NodeAffinity}
+}
+
+// reportOutputFormats returns the configured report output formats as a comma separated, lower case list, or an
+// empty string if the reporter should use its default.
+func (c *complianceComponent) reportOutputFormats() string {
+	if c.cfg.Compliance == nil {
+		return ""
+	}
+	var formats []string
+	for _, f := range c.cfg.Compliance.Spec.ReportOutputFormats {
+		formats = append(formats, strings.ToLower(string(f)))
+	}
+	return strings.Join(formats, ",")
+}
+
 func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 	var keyPath, certPath string
 	if c.cfg.ServerKeyPair != nil {
@@ -943,6 +1306,18 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 		}
 	}
 
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.ServerWorkerPoolSize != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "WORKER_POOL_SIZE", Value: fmt.Sprint(*c.cfg.Compliance.Spec.ServerWorkerPoolSize)})
+	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.ServerMaxRequestBodySize != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "MAX_REQUEST_BODY_BYTES", Value: fmt.Sprint(c.cfg.Compliance.Spec.ServerMaxRequestBodySize.Value())})
+	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.ServerIdleTimeout != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "IDLE_TIMEOUT", Value: c.cfg.Compliance.Spec.ServerIdleTimeout.Duration.String()})
+	}
+	envVars = append(envVars, c.timezoneEnv()...)
+	envVars = append(envVars, c.noProxyEnv()...)
+
 	if c.cfg.KeyValidatorConfig != nil {
 		envVars = append(envVars, c.cfg.KeyValidatorConfig.RequiredEnv("TIGERA_COMPLIANCE_")...)
 	}
@@ -951,6 +1326,25 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 		initContainers = append(initContainers, c.cfg.ServerKeyPair.InitContainer(c.cfg.Namespace))
 	}
 
+	args := []string{
+		fmt.Sprintf("-certpath=%s", c.cfg.ServerKeyPair.VolumeMountCertificateFilePath()),
+		fmt.Sprintf("-keypath=%s", c.cfg.ServerKeyPair.VolumeMountKeyFilePath()),
+	}
+	probeHandler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   "/compliance/version",
+			Port:   intstr.FromInt(complianceServerPort),
+			Scheme: corev1.URISchemeHTTPS,
+		},
+	}
+	if ComplianceServerClientCertificateVerificationEnabled(c.cfg.Compliance) {
+		args = append(args, fmt.Sprintf("-client-ca=%s", c.cfg.TrustedBundle.MountPath()))
+		// The kubelet cannot present a client certificate, so the probes fall back to checking the port.
+		probeHandler = corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(complianceServerPort)},
+		}
+	}
+
 	podTemplate := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ComplianceServerName,
@@ -961,6 +1355,7 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 			ServiceAccountName: ComplianceServerServiceAccount,
 			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+			Affinity:           c.affinity(),
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
 			Containers: []corev1.Container{
@@ -970,31 +1365,16 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 					ImagePullPolicy: ImagePullPolicy(),
 					Env:             envVars,
 					LivenessProbe: &corev1.Probe{
-						ProbeHandler: corev1.ProbeHandler{
-							HTTPGet: &corev1.HTTPGetAction{
-								Path:   "/compliance/version",
-								Port:   intstr.FromInt(complianceServerPort),
-								Scheme: corev1.URISchemeHTTPS,
-							},
-						},
+						ProbeHandler:        probeHandler,
 						FailureThreshold:    5,
 						InitialDelaySeconds: 5,
 					},
 					ReadinessProbe: &corev1.Probe{
-						ProbeHandler: corev1.ProbeHandler{
-							HTTPGet: &corev1.HTTPGetAction{
-								Path:   "/compliance/version",
-								Port:   intstr.FromInt(complianceServerPort),
-								Scheme: corev1.URISchemeHTTPS,
-							},
-						},
+						ProbeHandler:        *probeHandler.DeepCopy(),
 						FailureThreshold:    5,
 						InitialDelaySeconds: 5,
 					},
-					Args: []string{
-						fmt.Sprintf("-certpath=%s", c.cfg.ServerKeyPair.VolumeMountCertificateFilePath()),
-						fmt.Sprintf("-keypath=%s", c.cfg.ServerKeyPair.VolumeMountKeyFilePath()),
-					},
+					Args:            args,
 					SecurityContext: securitycontext.NewNonRootContext(),
 					VolumeMounts: append(
 						c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType()),
@@ -1029,6 +1409,7 @@ func (c *complianceComponent) complianceServerDeployment() *appsv1.Deployment {
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
 		}
 	}
+	c.setSoftMemoryLimit(&d.Spec.Template.Spec)
 	return d
 }
 
@@ -1134,11 +1515,15 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 		{Name: "LOG_LEVEL", Value: "info"},
 		{Name: "TIGERA_COMPLIANCE_JOB_NAMESPACE", Value: c.cfg.Namespace},
 		{Name: "TIGERA_COMPLIANCE_MAX_FAILED_JOBS_HISTORY", Value: "3"},
-		{Name: "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", Value: "0"},
 		{Name: "LINSEED_CLIENT_CERT", Value: certPath},
 		{Name: "LINSEED_CLIENT_KEY", Value: keyPath},
 		{Name: "LINSEED_TOKEN", Value: GetLinseedTokenPath(c.cfg.ManagementClusterConnection != nil)},
 	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.SnapshotSchedule != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_SCHEDULE", Value: c.cfg.Compliance.Spec.SnapshotSchedule})
+	} else {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", Value: "0"})
+	}
 	if c.cfg.Tenant != nil {
 		// Configure the tenant id in order to read /write linseed data using the correct tenant ID
 		// Multi-tenant and single tenant with external elastic needs this variable set
@@ -1146,6 +1531,15 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 			envVars = append(envVars, corev1.EnvVar{Name: "TENANT_ID", Value: c.cfg.Tenant.Spec.ID})
 		}
 	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.SnapshotterBatchSize != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_BATCH_SIZE", Value: fmt.Sprint(*c.cfg.Compliance.Spec.SnapshotterBatchSize)})
+	}
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.SnapshotterFlushInterval != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_FLUSH_INTERVAL", Value: c.cfg.Compliance.Spec.SnapshotterFlushInterval.Duration.String()})
+	}
+	envVars = append(envVars, c.timezoneEnv()...)
+	envVars = append(envVars, c.noProxyEnv()...)
+	envVars = append(envVars, c.metricsEnv(c.cfg.SnapshotterKeyPair)...)
 
 	volumes := []corev1.Volume{
 		c.cfg.TrustedBundle.Volume(),
@@ -1184,6 +1578,7 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 			ServiceAccountName: ComplianceSnapshotterServiceAccount,
 			Tolerations:        append(c.cfg.Installation.ControlPlaneTolerations, rmeta.TolerateControlPlane...),
 			NodeSelector:       c.cfg.Installation.ControlPlaneNodeSelector,
+			Affinity:           c.affinity(),
 			ImagePullSecrets:   secret.GetReferenceList(c.cfg.PullSecrets),
 			InitContainers:     initContainers,
 			Containers: []corev1.Container{
@@ -1192,6 +1587,7 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 					Image:           c.snapshotterImage,
 					ImagePullPolicy: ImagePullPolicy(),
 					Env:             envVars,
+					Ports:           c.metricsPorts(),
 					LivenessProbe: &corev1.Probe{
 						ProbeHandler: corev1.ProbeHandler{
 							HTTPGet: &corev1.HTTPGetAction{
@@ -1228,6 +1624,7 @@ func (c *complianceComponent) complianceSnapshotterDeployment() *appsv1.Deployme
 			rcomponents.ApplyDeploymentOverrides(d, overrides)
 		}
 	}
+	c.setSoftMemoryLimit(&d.Spec.Template.Spec)
 	return d
 }
 
@@ -1302,6 +1699,84 @@ func (c *complianceComponent) complianceBenchmarkerClusterRoleBinding() *rbacv1.
 	}
 }
 
+func (c *complianceComponent) benchmarkerRunMode() operatorv1.BenchmarkerRunMode {
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.BenchmarkerRunMode != nil {
+		return *c.cfg.Compliance.Spec.BenchmarkerRunMode
+	}
+	return operatorv1.BenchmarkerRunModeDaemonSet
+}
+
+// jobBackoffLimit returns the number of times a failed compliance job is retried before it is marked as failed.
+func (c *complianceComponent) jobBackoffLimit() int32 {
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.JobBackoffLimit != nil {
+		return *c.cfg.Compliance.Spec.JobBackoffLimit
+	}
+	return defaultComplianceJobBackoffLimit
+}
+
+// complianceBenchmarker returns the benchmarker workload for the configured run mode.
+func (c *complianceComponent) complianceBenchmarker() client.Object {
+	if c.benchmarkerRunMode() == operatorv1.BenchmarkerRunModeCronJob {
+		return c.complianceBenchmarkerCronJob()
+	}
+	return c.complianceBenchmarkerDaemonSet()
+}
+
+func (c *complianceComponent) complianceBenchmarkerCronJob() *batchv1.CronJob {
+	// Build the pod template from the DaemonSet so that the benchmarker pods are the same, and the
+	// ComplianceBenchmarkerDaemonSet overrides apply, in either run mode.
+	podTemplate := c.complianceBenchmarkerDaemonSet().Spec.Template
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	for i := range podTemplate.Spec.Containers {
+		// The benchmarker exits once its run completes, so a liveness probe does not apply.
+		podTemplate.Spec.Containers[i].LivenessProbe = nil
+	}
+
+	schedule := defaultBenchmarkerSchedule
+	if c.cfg.Compliance != nil && c.cfg.Compliance.Spec.BenchmarkerSchedule != "" {
+		schedule = c.cfg.Compliance.Spec.BenchmarkerSchedule
+	}
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{Kind: "CronJob", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ComplianceBenchmarkerName,
+			Namespace: c.cfg.Namespace,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.ToPtr(c.jobBackoffLimit()),
+					Template:     podTemplate,
+				},
+			},
+		},
+	}
+}
+
+// benchmarkerProfileConfigured returns whether the benchmarker should run a custom benchmark profile.
+func (c *complianceComponent) benchmarkerProfileConfigured() bool {
+	return c.cfg.BenchmarkerProfile != nil && c.cfg.Compliance != nil && c.cfg.Compliance.Spec.BenchmarkerProfileConfigMap != nil
+}
+
+// benchmarkerResultOutput returns the volume that the benchmarker writes its results to, if one is configured.
+func (c *complianceComponent) benchmarkerResultOutput() *operatorv1.ComplianceBenchmarkerResultOutput {
+	if c.cfg.Compliance == nil {
+		return nil
+	}
+	return c.cfg.Compliance.Spec.BenchmarkerResultOutput
+}
+
+// benchmarkerHostMounts returns the host paths to mount into the benchmarker.
+func (c *complianceComponent) benchmarkerHostMounts() []BenchmarkerHostMount {
+	if c.cfg.BenchmarkerHostMounts != nil {
+		return c.cfg.BenchmarkerHostMounts
+	}
+	return DefaultBenchmarkerHostMounts
+}
+
 func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet {
 	var keyPath, certPath string
 	if c.cfg.BenchmarkerKeyPair != nil {
@@ -1324,40 +1799,60 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 			envVars = append(envVars, corev1.EnvVar{Name: "TENANT_ID", Value: c.cfg.Tenant.Spec.ID})
 		}
 	}
+	envVars = append(envVars, c.timezoneEnv()...)
+	envVars = append(envVars, c.noProxyEnv()...)
+	envVars = append(envVars, c.metricsEnv(c.cfg.BenchmarkerKeyPair)...)
 
-	volMounts := []corev1.VolumeMount{
-		{Name: "var-lib-etcd", MountPath: "/var/lib/etcd", ReadOnly: true},
-		{Name: "var-lib-kubelet", MountPath: "/var/lib/kubelet", ReadOnly: true},
-		{Name: "etc-systemd", MountPath: "/etc/systemd", ReadOnly: true},
-		{Name: "etc-kubernetes", MountPath: "/etc/kubernetes", ReadOnly: true},
-		{Name: "usr-bin", MountPath: "/usr/local/bin", ReadOnly: true},
+	var volMounts []corev1.VolumeMount
+	var vols []corev1.Volume
+	for _, m := range c.benchmarkerHostMounts() {
+		mountPath := m.MountPath
+		if mountPath == "" {
+			mountPath = m.HostPath
+		}
+		volMounts = append(volMounts, corev1.VolumeMount{Name: m.Name, MountPath: mountPath, ReadOnly: true})
+		vols = append(vols, corev1.Volume{
+			Name:         m.Name,
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: m.HostPath}},
+		})
 	}
 	volMounts = append(volMounts, c.cfg.TrustedBundle.VolumeMounts(c.SupportedOSType())...)
 	volMounts = append(volMounts, c.cfg.BenchmarkerKeyPair.VolumeMount(c.SupportedOSType()))
+	vols = append(vols, c.cfg.TrustedBundle.Volume(), c.cfg.BenchmarkerKeyPair.Volume())
 
-	vols := []corev1.Volume{
-		{
-			Name:         "var-lib-etcd",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/etcd"}},
-		},
-		{
-			Name:         "var-lib-kubelet",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/kubelet"}},
-		},
-		{
-			Name:         "etc-systemd",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/etc/systemd"}},
-		},
-		{
-			Name:         "etc-kubernetes",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/etc/kubernetes"}},
-		},
-		{
-			Name:         "usr-bin",
-			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/usr/bin"}},
-		},
-		c.cfg.TrustedBundle.Volume(),
-		c.cfg.BenchmarkerKeyPair.Volume(),
+	var annotations map[string]string
+	if c.benchmarkerProfileConfigured() {
+		envVars = append(envVars, corev1.EnvVar{Name: "BENCHMARK_PROFILE", Value: fmt.Sprintf("%s/%s", benchmarkerProfileMountPath, benchmarkerProfileFileName)})
+		volMounts = append(volMounts, corev1.VolumeMount{Name: benchmarkerProfileVolumeName, MountPath: benchmarkerProfileMountPath, ReadOnly: true})
+		vols = append(vols, corev1.Volume{
+			Name: benchmarkerProfileVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: c.cfg.BenchmarkerProfile.Name},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  c.cfg.Compliance.Spec.BenchmarkerProfileConfigMap.Key,
+							Path: benchmarkerProfileFileName,
+						},
+					},
+				},
+			},
+		})
+		annotations = map[string]string{benchmarkerProfileAnnotation: rmeta.AnnotationHash(c.cfg.BenchmarkerProfile.Data)}
+	}
+
+	if output := c.benchmarkerResultOutput(); output != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "BENCHMARK_RESULT_OUTPUT_PATH", Value: output.Path})
+		volMounts = append(volMounts, corev1.VolumeMount{Name: benchmarkerResultsVolumeName, MountPath: output.Path})
+		source := corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: output.PersistentVolumeClaimName},
+		}
+		if output.HostPath != "" {
+			source = corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: output.HostPath, Type: ptr.ToPtr(corev1.HostPathDirectoryOrCreate)},
+			}
+		}
+		vols = append(vols, corev1.Volume{Name: benchmarkerResultsVolumeName, VolumeSource: source})
 	}
 
 	// benchmarker needs an extra host path volume mount for GKE for CIS benchmarks
@@ -1395,8 +1890,9 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 	}
 	podTemplate := &corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ComplianceBenchmarkerName,
-			Namespace: c.cfg.Namespace,
+			Name:        ComplianceBenchmarkerName,
+			Namespace:   c.cfg.Namespace,
+			Annotations: annotations,
 		},
 		Spec: corev1.PodSpec{
 			ServiceAccountName: ComplianceBenchmarkerServiceAccount,
@@ -1410,6 +1906,7 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 					Image:           c.benchmarkerImage,
 					ImagePullPolicy: ImagePullPolicy(),
 					Env:             envVars,
+					Ports:           c.metricsPorts(),
 					SecurityContext: securitycontext.NewRootContext(false),
 					VolumeMounts:    volMounts,
 					LivenessProbe: &corev1.Probe{
@@ -1445,6 +1942,7 @@ func (c *complianceComponent) complianceBenchmarkerDaemonSet() *appsv1.DaemonSet
 			rcomponents.ApplyDaemonSetOverrides(ds, overrides)
 		}
 	}
+	c.setSoftMemoryLimit(&ds.Spec.Template.Spec)
 	return ds
 }
 
@@ -1474,27 +1972,11 @@ func (c *complianceComponent) complianceBenchmarkerSecurityContextConstraints()
 func (c *complianceComponent) complianceBenchmarkerPodSecurityPolicy() *policyv1beta1.PodSecurityPolicy {
 	psp := podsecuritypolicy.NewBasePolicy("compliance-benchmarker")
 	psp.Spec.Volumes = append(psp.Spec.Volumes, policyv1beta1.HostPath)
-	psp.Spec.AllowedHostPaths = []policyv1beta1.AllowedHostPath{
-		{
-			PathPrefix: "/var/lib/etcd",
-			ReadOnly:   true,
-		},
-		{
-			PathPrefix: "/etc/systemd",
-			ReadOnly:   true,
-		},
-		{
-			PathPrefix: "/etc/kubernetes",
-			ReadOnly:   true,
-		},
-		{
-			PathPrefix: "/usr/bin",
-			ReadOnly:   true,
-		},
-		{
-			PathPrefix: "/var/lib/kubelet",
-			ReadOnly:   true,
-		},
+	for _, m := range c.benchmarkerHostMounts() {
+		psp.Spec.AllowedHostPaths = append(psp.Spec.AllowedHostPaths, policyv1beta1.AllowedHostPath{PathPrefix: m.HostPath, ReadOnly: true})
+	}
+	if output := c.benchmarkerResultOutput(); output != nil && output.HostPath != "" {
+		psp.Spec.AllowedHostPaths = append(psp.Spec.AllowedHostPaths, policyv1beta1.AllowedHostPath{PathPrefix: output.HostPath})
 	}
 	psp.Spec.RunAsUser.Rule = policyv1beta1.RunAsUserStrategyRunAsAny
 	psp.Spec.HostPID = true
@@ -1805,6 +2287,20 @@ func (c *complianceComponent) complianceAccessAllowTigeraNetworkPolicy() *v3.Net
 		})
 	}
 
+	policyTypes := []v3.PolicyType{v3.PolicyTypeEgress}
+	var ingressRules []v3.Rule
+	if c.metricsEnabled() {
+		policyTypes = []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress}
+		ingressRules = append(ingressRules, v3.Rule{
+			Action:   v3.Allow,
+			Protocol: &networkpolicy.TCPProtocol,
+			Source:   networkpolicy.PrometheusSourceEntityRule,
+			Destination: v3.EntityRule{
+				Ports: networkpolicy.Ports(ComplianceMetricsPort),
+			},
+		})
+	}
+
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -1815,7 +2311,8 @@ func (c *complianceComponent) complianceAccessAllowTigeraNetworkPolicy() *v3.Net
 			Order:    &networkpolicy.HighPrecedenceOrder,
 			Tier:     networkpolicy.TigeraComponentTierName,
 			Selector: networkpolicy.KubernetesAppSelector(ComplianceBenchmarkerName, ComplianceControllerName, ComplianceSnapshotterName, ComplianceReporterName),
-			Types:    []v3.PolicyType{v3.PolicyTypeEgress},
+			Types:    policyTypes,
+			Ingress:  ingressRules,
 			Egress:   egressRules,
 		},
 	}
@@ -16,6 +16,7 @@ package render_test
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 
@@ -23,7 +24,9 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -31,6 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
@@ -40,8 +44,10 @@ import (
 	"github.com/tigera/operator/pkg/controller/certificatemanager"
 	ctrlrfake "github.com/tigera/operator/pkg/ctrlruntime/client/fake"
 	"github.com/tigera/operator/pkg/dns"
+	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/testutils"
 	"github.com/tigera/operator/pkg/tls"
@@ -949,6 +955,601 @@ var _ = Describe("compliance rendering tests", func() {
 		})
 	})
 
+	It("should set the compliance server worker pool size when specified", func() {
+		poolSize := int32(8)
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{ServerWorkerPoolSize: &poolSize}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-server")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "WORKER_POOL_SIZE", Value: "8"}))
+	})
+
+	It("should set the compliance server max request body size when specified", func() {
+		size := resource.MustParse("16Mi")
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{ServerMaxRequestBodySize: &size}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-server")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "MAX_REQUEST_BODY_BYTES", Value: "16777216"}))
+	})
+
+	It("should set the compliance server idle timeout only when specified", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-server")
+		Expect(container).NotTo(BeNil())
+		for _, env := range container.Env {
+			Expect(env.Name).NotTo(Equal("IDLE_TIMEOUT"))
+		}
+
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{ServerIdleTimeout: &metav1.Duration{Duration: 50 * time.Second}}}
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ = component.Objects()
+
+		d = rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container = test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-server")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "IDLE_TIMEOUT", Value: "50s"}))
+	})
+
+	It("should set the compliance snapshotter batch configuration only when specified", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-snapshotter", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-snapshotter")
+		Expect(container).NotTo(BeNil())
+		for _, env := range container.Env {
+			Expect(env.Name).NotTo(BeElementOf("TIGERA_COMPLIANCE_SNAPSHOT_BATCH_SIZE", "TIGERA_COMPLIANCE_SNAPSHOT_FLUSH_INTERVAL"))
+		}
+
+		batchSize := int32(500)
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			SnapshotterBatchSize:     &batchSize,
+			SnapshotterFlushInterval: &metav1.Duration{Duration: 30 * time.Second},
+		}}
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ = component.Objects()
+
+		d = rtest.GetResource(resources, "compliance-snapshotter", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container = test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-snapshotter")
+		Expect(container).NotTo(BeNil())
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_BATCH_SIZE", Value: "500"},
+			corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_FLUSH_INTERVAL", Value: "30s"},
+		))
+	})
+
+	It("should apply the configured node affinity to the compliance pods", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+		for _, name := range []string{"compliance-controller", "compliance-server", "compliance-snapshotter"} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Affinity).To(BeNil())
+		}
+
+		nodeAffinity := &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+				Weight: 100,
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"us-west-2a"},
+					}},
+				},
+			}},
+		}
+		cfg.NodeAffinity = nodeAffinity
+		component, err = render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ = component.Objects()
+
+		for _, name := range []string{"compliance-controller", "compliance-server", "compliance-snapshotter"} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Affinity).To(Equal(&corev1.Affinity{NodeAffinity: nodeAffinity}))
+		}
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.Affinity).To(Equal(&corev1.Affinity{NodeAffinity: nodeAffinity}))
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Affinity).To(BeNil())
+	})
+
+	It("should mount the default host paths into the benchmarker", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Volumes).To(ContainElements(
+			corev1.Volume{Name: "var-lib-etcd", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/etcd"}}},
+			corev1.Volume{Name: "usr-bin", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/usr/bin"}}},
+		))
+		Expect(benchmarker.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+			corev1.VolumeMount{Name: "var-lib-etcd", MountPath: "/var/lib/etcd", ReadOnly: true},
+			corev1.VolumeMount{Name: "usr-bin", MountPath: "/usr/local/bin", ReadOnly: true},
+		))
+	})
+
+	It("should mount the configured host paths into the benchmarker", func() {
+		cfg.UsePSP = true
+		cfg.BenchmarkerHostMounts = []render.BenchmarkerHostMount{
+			{Name: "var-lib-rancher", HostPath: "/var/lib/rancher"},
+			{Name: "opt-bin", HostPath: "/opt/bin", MountPath: "/usr/local/bin"},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		var hostPaths []string
+		for _, v := range benchmarker.Spec.Template.Spec.Volumes {
+			if v.HostPath != nil {
+				hostPaths = append(hostPaths, v.HostPath.Path)
+			}
+		}
+		Expect(hostPaths).To(ConsistOf("/var/lib/rancher", "/opt/bin"))
+		Expect(benchmarker.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+			corev1.VolumeMount{Name: "var-lib-rancher", MountPath: "/var/lib/rancher", ReadOnly: true},
+			corev1.VolumeMount{Name: "opt-bin", MountPath: "/usr/local/bin", ReadOnly: true},
+		))
+
+		psp := rtest.GetResource(resources, "compliance-benchmarker", "", "policy", "v1beta1", "PodSecurityPolicy").(*v1beta1.PodSecurityPolicy)
+		Expect(psp.Spec.AllowedHostPaths).To(ConsistOf(
+			v1beta1.AllowedHostPath{PathPrefix: "/var/lib/rancher", ReadOnly: true},
+			v1beta1.AllowedHostPath{PathPrefix: "/opt/bin", ReadOnly: true},
+		))
+	})
+
+	DescribeTable("should reject invalid benchmarker host mounts",
+		func(mounts ...render.BenchmarkerHostMount) {
+			cfg.BenchmarkerHostMounts = mounts
+			_, err := render.Compliance(cfg)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("relative host path", render.BenchmarkerHostMount{Name: "etc", HostPath: "etc/kubernetes"}),
+		Entry("relative mount path", render.BenchmarkerHostMount{Name: "etc", HostPath: "/etc/kubernetes", MountPath: "etc"}),
+		Entry("missing name", render.BenchmarkerHostMount{HostPath: "/etc/kubernetes"}),
+		Entry("duplicate name", render.BenchmarkerHostMount{Name: "etc", HostPath: "/etc/kubernetes"}, render.BenchmarkerHostMount{Name: "etc", HostPath: "/etc/systemd"}),
+	)
+
+	It("should not set the compliance server worker pool size by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		container := test.GetContainer(d.Spec.Template.Spec.Containers, "compliance-server")
+		Expect(container).NotTo(BeNil())
+		for _, env := range container.Env {
+			Expect(env.Name).NotTo(Equal("WORKER_POOL_SIZE"))
+		}
+	})
+
+	It("should set the TZ env on the compliance containers when a timezone is specified", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{Timezone: "Europe/Dublin"}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		tz := corev1.EnvVar{Name: "TZ", Value: "Europe/Dublin"}
+		for _, name := range []string{"compliance-controller", "compliance-server", "compliance-snapshotter"} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(tz), name)
+		}
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElement(tz))
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Containers[0].Env).To(ContainElement(tz))
+	})
+
+	It("should set the NO_PROXY env on the compliance containers to the cluster domain and the configured entries", func() {
+		cfg.Installation.ServiceCIDRs = []string{"10.96.0.0/12"}
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{NoProxy: []string{"es.example.com", "10.96.0.0/12", "192.168.0.0/16"}}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		noProxy := corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local,10.96.0.0/12,es.example.com,192.168.0.0/16"}
+		for _, name := range []string{"compliance-controller", "compliance-server", "compliance-snapshotter"} {
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(noProxy), name)
+		}
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElement(noProxy))
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Containers[0].Env).To(ContainElement(noProxy))
+	})
+
+	It("should set the NO_PROXY env on the compliance containers to the cluster domain by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local"}))
+	})
+
+	It("should require client certificates on the compliance server only when enabled", func() {
+		server := func() corev1.Container {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+			d := rtest.GetResource(resources, "compliance-server", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			return d.Spec.Template.Spec.Containers[0]
+		}
+
+		c := server()
+		Expect(c.Args).NotTo(ContainElement(HavePrefix("-client-ca=")))
+		Expect(c.LivenessProbe.HTTPGet).NotTo(BeNil())
+		Expect(c.ReadinessProbe.HTTPGet).NotTo(BeNil())
+
+		enabled := operatorv1.ComplianceTLSVerificationEnabled
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{ServerClientCertificateVerification: &enabled}}
+		c = server()
+		Expect(c.Args).To(ContainElement("-client-ca=/etc/pki/tls/certs/tigera-ca-bundle.crt"))
+		for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe} {
+			Expect(probe.HTTPGet).To(BeNil())
+			Expect(probe.TCPSocket).To(Equal(&corev1.TCPSocketAction{Port: intstr.FromInt(5443)}))
+		}
+	})
+
+	It("should not set the TZ env on the compliance containers by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, env := range d.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(Equal("TZ"))
+		}
+	})
+
+	It("should write the benchmark results to a host path when specified", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			BenchmarkerResultOutput: &operatorv1.ComplianceBenchmarkerResultOutput{
+				Path:     "/var/log/calico/benchmarks",
+				HostPath: "/var/log/benchmarks",
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "benchmark-results",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: "/var/log/benchmarks",
+					Type: ptr.ToPtr(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		}))
+		container := benchmarker.Spec.Template.Spec.Containers[0]
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "benchmark-results", MountPath: "/var/log/calico/benchmarks"}))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "BENCHMARK_RESULT_OUTPUT_PATH", Value: "/var/log/calico/benchmarks"}))
+	})
+
+	It("should write the benchmark results to a persistent volume claim when specified", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			BenchmarkerResultOutput: &operatorv1.ComplianceBenchmarkerResultOutput{
+				Path:                      "/var/log/calico/benchmarks",
+				PersistentVolumeClaimName: "benchmark-results",
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "benchmark-results",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "benchmark-results"},
+			},
+		}))
+		container := benchmarker.Spec.Template.Spec.Containers[0]
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "benchmark-results", MountPath: "/var/log/calico/benchmarks"}))
+	})
+
+	It("should mount the custom benchmark profile into the benchmarker when specified", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			BenchmarkerProfileConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "hardened-cis"},
+				Key:                  "hardened.yaml",
+			},
+		}}
+		cfg.BenchmarkerProfile = &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "hardened-cis", Namespace: common.OperatorNamespace()},
+			Data:       map[string]string{"hardened.yaml": "controls: []"},
+		}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		cm := rtest.GetResource(resources, "hardened-cis", ns, "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		Expect(cm.Data).To(Equal(map[string]string{"hardened.yaml": "controls: []"}))
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		Expect(benchmarker.Spec.Template.Annotations).To(HaveKey("hash.operator.tigera.io/benchmark-profile"))
+		Expect(benchmarker.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "benchmark-profile",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "hardened-cis"},
+					Items:                []corev1.KeyToPath{{Key: "hardened.yaml", Path: "profile.yaml"}},
+				},
+			},
+		}))
+		container := benchmarker.Spec.Template.Spec.Containers[0]
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "benchmark-profile", MountPath: "/etc/tigera/benchmark", ReadOnly: true}))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "BENCHMARK_PROFILE", Value: "/etc/tigera/benchmark/profile.yaml"}))
+	})
+
+	It("should not mount a custom benchmark profile by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		benchmarker := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+		for _, v := range benchmarker.Spec.Template.Spec.Volumes {
+			Expect(v.Name).NotTo(Equal("benchmark-profile"))
+		}
+		for _, env := range benchmarker.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(Equal("BENCHMARK_PROFILE"))
+		}
+	})
+
+	It("should set the soft memory limit on the compliance containers that have a memory limit", func() {
+		serverOverrides := &operatorv1.ComplianceServerDeployment{
+			Spec: &operatorv1.ComplianceServerDeploymentSpec{
+				Template: &operatorv1.ComplianceServerDeploymentPodTemplateSpec{
+					Spec: &operatorv1.ComplianceServerDeploymentPodSpec{
+						Containers: []operatorv1.ComplianceServerDeploymentContainer{{
+							Name:      "compliance-server",
+							Resources: &complianceResources,
+						}},
+					},
+				},
+			},
+		}
+		containerEnv := func(name string) []corev1.EnvVar {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+			d := rtest.GetResource(resources, name, ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			return d.Spec.Template.Spec.Containers[0].Env
+		}
+
+		Expect(containerEnv("compliance-server")).NotTo(ContainElement(HaveField("Name", "GOMEMLIMIT")))
+
+		// 90% of the 300Mi memory limit by default.
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{ComplianceServerDeployment: serverOverrides}}
+		Expect(containerEnv("compliance-server")).To(ContainElement(corev1.EnvVar{Name: "GOMEMLIMIT", Value: "283115520"}))
+		Expect(containerEnv("compliance-controller")).NotTo(ContainElement(HaveField("Name", "GOMEMLIMIT")))
+
+		percent := int32(50)
+		cfg.Compliance.Spec.SoftMemoryLimitPercent = &percent
+		Expect(containerEnv("compliance-server")).To(ContainElement(corev1.EnvVar{Name: "GOMEMLIMIT", Value: "157286400"}))
+	})
+
+	It("should set the snapshot schedule on the snapshotter when specified", func() {
+		snapshotterEnv := func() []corev1.EnvVar {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+			d := rtest.GetResource(resources, "compliance-snapshotter", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			return d.Spec.Template.Spec.Containers[0].Env
+		}
+
+		env := snapshotterEnv()
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_HOUR", Value: "0"}))
+		Expect(env).NotTo(ContainElement(HaveField("Name", "TIGERA_COMPLIANCE_SNAPSHOT_SCHEDULE")))
+
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{SnapshotSchedule: "0 * * * *"}}
+		env = snapshotterEnv()
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_SNAPSHOT_SCHEDULE", Value: "0 * * * *"}))
+		Expect(env).NotTo(ContainElement(HaveField("Name", "TIGERA_COMPLIANCE_SNAPSHOT_HOUR")))
+	})
+
+	It("should set the report retention on the compliance controller when specified", func() {
+		maxReports := int32(10)
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ReportRetention: &operatorv1.ComplianceReportRetention{
+				MaxAge:     &metav1.Duration{Duration: 720 * time.Hour},
+				MaxReports: &maxReports,
+			},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION_MAX_AGE", Value: "720h0m0s"},
+			corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_RETENTION_MAX_REPORTS", Value: "10"},
+		))
+	})
+
+	It("should not set the report retention on the compliance controller by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		for _, env := range d.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(HavePrefix("TIGERA_COMPLIANCE_REPORT_RETENTION_"))
+		}
+	})
+
+	It("should set the report output formats on the reporter when specified", func() {
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ReportOutputFormats: []operatorv1.ComplianceReportOutputFormat{operatorv1.ComplianceReportOutputFormatJSON, operatorv1.ComplianceReportOutputFormatCSV},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		Expect(reporter.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_REPORT_OUTPUT_FORMATS", Value: "json,csv"}))
+	})
+
+	It("should not set the report output formats on the reporter by default", func() {
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, _ := component.Objects()
+
+		reporter := rtest.GetResource(resources, "tigera.io.report", ns, "", "v1", "PodTemplate").(*corev1.PodTemplate)
+		for _, env := range reporter.Template.Spec.Containers[0].Env {
+			Expect(env.Name).NotTo(Equal("TIGERA_COMPLIANCE_REPORT_OUTPUT_FORMATS"))
+		}
+	})
+
+	Context("Prometheus metrics", func() {
+		var metricsEnabled = operatorv1.ComplianceMetricsEnabled
+		var metricsComponents = []string{"compliance-controller", "compliance-snapshotter", "compliance-benchmarker"}
+
+		It("should render the metrics Services and ServiceMonitors when enabled and the Monitor is installed", func() {
+			cfg.MonitorEnabled = true
+			cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{PrometheusMetrics: &metricsEnabled}}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			for _, name := range metricsComponents {
+				svc := rtest.GetResource(resources, name+"-metrics", ns, "", "v1", "Service").(*corev1.Service)
+				Expect(svc.Labels).To(Equal(map[string]string{"k8s-app": name}))
+				Expect(svc.Spec.Selector).To(Equal(map[string]string{"k8s-app": name}))
+				Expect(svc.Spec.Ports).To(ConsistOf(corev1.ServicePort{
+					Name:       "metrics-port",
+					Port:       render.ComplianceMetricsPort,
+					TargetPort: intstr.FromInt(render.ComplianceMetricsPort),
+					Protocol:   corev1.ProtocolTCP,
+				}))
+
+				sm := rtest.GetResource(resources, name+"-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", "ServiceMonitor").(*monitoringv1.ServiceMonitor)
+				Expect(sm.Labels).To(Equal(map[string]string{"team": "network-operators"}))
+				Expect(sm.Spec.Selector.MatchLabels).To(Equal(map[string]string{"k8s-app": name}))
+				Expect(sm.Spec.NamespaceSelector.MatchNames).To(ConsistOf(ns))
+				Expect(sm.Spec.Endpoints).To(HaveLen(1))
+				Expect(sm.Spec.Endpoints[0].Port).To(Equal("metrics-port"))
+				Expect(sm.Spec.Endpoints[0].Scheme).To(Equal("https"))
+				Expect(sm.Spec.Endpoints[0].TLSConfig.CAFile).To(Equal(cfg.TrustedBundle.MountPath()))
+				Expect(sm.Spec.Endpoints[0].TLSConfig.ServerName).To(Equal(name + "-metrics"))
+			}
+
+			containers := []corev1.Container{
+				rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment).Spec.Template.Spec.Containers[0],
+				rtest.GetResource(resources, "compliance-snapshotter", ns, "apps", "v1", "Deployment").(*appsv1.Deployment).Spec.Template.Spec.Containers[0],
+				rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet).Spec.Template.Spec.Containers[0],
+			}
+			for _, c := range containers {
+				Expect(c.Env).To(ContainElements(
+					corev1.EnvVar{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_ENABLED", Value: "true"},
+					corev1.EnvVar{Name: "TIGERA_COMPLIANCE_PROMETHEUS_METRICS_PORT", Value: "9081"},
+				), c.Name)
+				Expect(c.Ports).To(ConsistOf(corev1.ContainerPort{Name: "metrics-port", ContainerPort: render.ComplianceMetricsPort, Protocol: corev1.ProtocolTCP}), c.Name)
+			}
+
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: "allow-tigera.compliance-access", Namespace: ns}, resources)
+			Expect(policy.Spec.Types).To(ConsistOf(v3.PolicyTypeIngress, v3.PolicyTypeEgress))
+			Expect(policy.Spec.Ingress).To(ConsistOf(v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Source:      networkpolicy.PrometheusSourceEntityRule,
+				Destination: v3.EntityRule{Ports: networkpolicy.Ports(render.ComplianceMetricsPort)},
+			}))
+		})
+
+		It("should not render the metrics Services and ServiceMonitors when the Monitor is not installed", func() {
+			cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{PrometheusMetrics: &metricsEnabled}}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, toDelete := component.Objects()
+
+			for _, name := range metricsComponents {
+				Expect(rtest.GetResource(resources, name+"-metrics", ns, "", "v1", "Service")).To(BeNil())
+				Expect(rtest.GetResource(resources, name+"-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", "ServiceMonitor")).To(BeNil())
+				Expect(rtest.GetResource(toDelete, name+"-metrics", ns, "", "v1", "Service")).NotTo(BeNil())
+				// Without the Monitor, the ServiceMonitor CRD may not exist so they are not cleaned up either.
+				Expect(rtest.GetResource(toDelete, name+"-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", "ServiceMonitor")).To(BeNil())
+			}
+			d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Ports).To(BeEmpty())
+		})
+
+		It("should delete the metrics Services and ServiceMonitors when disabled", func() {
+			cfg.MonitorEnabled = true
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, toDelete := component.Objects()
+
+			for _, name := range metricsComponents {
+				Expect(rtest.GetResource(resources, name+"-metrics", ns, "", "v1", "Service")).To(BeNil())
+				Expect(rtest.GetResource(toDelete, name+"-metrics", ns, "", "v1", "Service")).NotTo(BeNil())
+				Expect(rtest.GetResource(toDelete, name+"-metrics", common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", "ServiceMonitor")).NotTo(BeNil())
+			}
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: "allow-tigera.compliance-access", Namespace: ns}, resources)
+			Expect(policy.Spec.Types).To(ConsistOf(v3.PolicyTypeEgress))
+			Expect(policy.Spec.Ingress).To(BeEmpty())
+		})
+	})
+
+	It("should not render a compliance server PodDisruptionBudget while it runs a single replica", func() {
+		maxUnavailable := intstr.FromInt(1)
+		cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+			ComplianceServerPodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable},
+		}}
+		component, err := render.Compliance(cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		resources, objsToDelete := component.Objects()
+
+		Expect(rtest.GetResource(resources, "compliance-server", ns, "policy", "v1", "PodDisruptionBudget")).To(BeNil())
+		Expect(rtest.GetResource(objsToDelete, "compliance-server", ns, "policy", "v1", "PodDisruptionBudget")).NotTo(BeNil())
+	})
+
+	Context("dependencies", func() {
+		It("should depend on the compliance namespace and the allow-tigera tier", func() {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			dependent, ok := component.(render.ComponentWithDependencies)
+			Expect(ok).To(BeTrue())
+
+			deps := dependent.Dependencies()
+			Expect(deps).To(HaveLen(2))
+			Expect(rtest.GetResource(deps, ns, "", "", "v1", "Namespace")).NotTo(BeNil())
+			Expect(rtest.GetResource(deps, networkpolicy.TigeraComponentTierName, "", "projectcalico.org", "v3", "Tier")).NotTo(BeNil())
+		})
+
+		It("should not depend on the allow-tigera tier for a multi-tenant managed cluster", func() {
+			cfg.Tenant = &operatorv1.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: ns}, Spec: operatorv1.TenantSpec{ID: "tenant-id"}}
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			deps := component.(render.ComponentWithDependencies).Dependencies()
+			Expect(deps).To(HaveLen(1))
+			Expect(rtest.GetResource(deps, ns, "", "", "v1", "Namespace")).NotTo(BeNil())
+		})
+	})
+
 	Context("Render Benchmarker", func() {
 		It("should render benchmarker properly for non GKE environments", func() {
 			cfg.Installation.KubernetesProvider = operatorv1.ProviderNone
@@ -1005,6 +1606,85 @@ var _ = Describe("compliance rendering tests", func() {
 			Expect(volumeMounts[7].Name).To(Equal("home-kubernetes"))
 			Expect(volumeMounts[7].MountPath).To(Equal("/home/kubernetes"))
 		})
+
+		It("should render the benchmarker as a DaemonSet by default", func() {
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, objsToDelete := component.Objects()
+
+			ds := rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet").(*appsv1.DaemonSet)
+			Expect(ds.Spec.Template.Spec.Containers[0].LivenessProbe).NotTo(BeNil())
+			Expect(rtest.GetResource(resources, "compliance-benchmarker", ns, "batch", "v1", "CronJob")).To(BeNil())
+			Expect(rtest.GetResource(objsToDelete, "compliance-benchmarker", ns, "batch", "v1", "CronJob")).NotTo(BeNil())
+		})
+
+		It("should render the benchmarker as a CronJob when configured", func() {
+			runMode := operatorv1.BenchmarkerRunModeCronJob
+			cfg.Compliance = &operatorv1.Compliance{
+				Spec: operatorv1.ComplianceSpec{
+					BenchmarkerRunMode:  &runMode,
+					BenchmarkerSchedule: "30 2 * * *",
+					ComplianceBenchmarkerDaemonSet: &operatorv1.ComplianceBenchmarkerDaemonSet{
+						Spec: &operatorv1.ComplianceBenchmarkerDaemonSetSpec{
+							Template: &operatorv1.ComplianceBenchmarkerDaemonSetPodTemplateSpec{
+								Spec: &operatorv1.ComplianceBenchmarkerDaemonSetPodSpec{
+									Containers: []operatorv1.ComplianceBenchmarkerDaemonSetContainer{
+										{Name: "compliance-benchmarker", Resources: &complianceResources},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, objsToDelete := component.Objects()
+
+			Expect(rtest.GetResource(resources, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet")).To(BeNil())
+			Expect(rtest.GetResource(objsToDelete, "compliance-benchmarker", ns, "apps", "v1", "DaemonSet")).NotTo(BeNil())
+
+			cj := rtest.GetResource(resources, "compliance-benchmarker", ns, "batch", "v1", "CronJob").(*batchv1.CronJob)
+			Expect(cj.Spec.Schedule).To(Equal("30 2 * * *"))
+			Expect(cj.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+
+			podSpec := cj.Spec.JobTemplate.Spec.Template.Spec
+			Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyOnFailure))
+			Expect(podSpec.HostPID).To(BeTrue())
+			Expect(podSpec.Containers).To(HaveLen(1))
+			Expect(podSpec.Containers[0].Resources).To(Equal(complianceResources))
+			Expect(podSpec.Containers[0].LivenessProbe).To(BeNil())
+			Expect(podSpec.Containers[0].VolumeMounts).To(HaveLen(7))
+		})
+
+		It("should use the default schedule for the benchmarker CronJob", func() {
+			runMode := operatorv1.BenchmarkerRunModeCronJob
+			cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{BenchmarkerRunMode: &runMode}}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			cj := rtest.GetResource(resources, "compliance-benchmarker", ns, "batch", "v1", "CronJob").(*batchv1.CronJob)
+			Expect(cj.Spec.Schedule).To(Equal("0 * * * *"))
+			Expect(cj.Spec.JobTemplate.Spec.BackoffLimit).To(Equal(ptr.ToPtr(int32(6))))
+		})
+
+		It("should apply the configured job backoff limit", func() {
+			runMode := operatorv1.BenchmarkerRunModeCronJob
+			cfg.Compliance = &operatorv1.Compliance{Spec: operatorv1.ComplianceSpec{
+				BenchmarkerRunMode: &runMode,
+				JobBackoffLimit:    ptr.ToPtr(int32(2)),
+			}}
+			component, err := render.Compliance(cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+			resources, _ := component.Objects()
+
+			cj := rtest.GetResource(resources, "compliance-benchmarker", ns, "batch", "v1", "CronJob").(*batchv1.CronJob)
+			Expect(cj.Spec.JobTemplate.Spec.BackoffLimit).To(Equal(ptr.ToPtr(int32(2))))
+
+			d := rtest.GetResource(resources, "compliance-controller", ns, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			Expect(d.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "TIGERA_COMPLIANCE_MAX_JOB_RETRIES", Value: "2"}))
+		})
 	})
 
 	Context("allow-tigera rendering", func() {
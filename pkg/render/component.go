@@ -39,3 +39,13 @@ type Component interface {
 	// that create pods. Return OSTypeAny means that no node selector should be set for the "kubernetes.io/os" label.
 	SupportedOSType() rmeta.OSType
 }
+
+// ComponentWithDependencies is implemented by components whose objects must not be created until other objects
+// exist, for example a component rendering policies that requires the tier they belong to.
+type ComponentWithDependencies interface {
+	Component
+
+	// Dependencies returns the objects that must exist before the objects of this component are created. Only the
+	// type, namespace and name of each object are used.
+	Dependencies() []client.Object
+}
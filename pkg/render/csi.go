@@ -389,32 +389,29 @@ func (c *csiComponent) roleBinding() *rbacv1.RoleBinding {
 }
 
 func (c *csiComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
 
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.csiImage, err = components.GetReference(components.ComponentTigeraCSI, reg, path, prefix, is)
+		c.csiImage, err = components.GetInstallationReference(components.ComponentTigeraCSI, c.cfg.Installation, is)
 		if err != nil {
 			return err
 		}
 
-		c.csiRegistrarImage, err = components.GetReference(components.ComponentTigeraCSINodeDriverRegistrar, reg, path, prefix, is)
+		c.csiRegistrarImage, err = components.GetInstallationReference(components.ComponentTigeraCSINodeDriverRegistrar, c.cfg.Installation, is)
 	} else {
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.csiImage, err = components.GetReference(components.ComponentCalicoCSIFIPS, reg, path, prefix, is)
+			c.csiImage, err = components.GetInstallationReference(components.ComponentCalicoCSIFIPS, c.cfg.Installation, is)
 			if err != nil {
 				return err
 			}
-			c.csiRegistrarImage, err = components.GetReference(components.ComponentCalicoCSIRegistrarFIPS, reg, path, prefix, is)
+			c.csiRegistrarImage, err = components.GetInstallationReference(components.ComponentCalicoCSIRegistrarFIPS, c.cfg.Installation, is)
 		} else {
-			c.csiImage, err = components.GetReference(components.ComponentCalicoCSI, reg, path, prefix, is)
+			c.csiImage, err = components.GetInstallationReference(components.ComponentCalicoCSI, c.cfg.Installation, is)
 			if err != nil {
 				return err
 			}
 
-			c.csiRegistrarImage, err = components.GetReference(components.ComponentCalicoCSIRegistrar, reg, path, prefix, is)
+			c.csiRegistrarImage, err = components.GetInstallationReference(components.ComponentCalicoCSIRegistrar, c.cfg.Installation, is)
 		}
 	}
 
@@ -88,11 +88,8 @@ type dexComponent struct {
 }
 
 func (c *dexComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
-	c.image, err = components.GetReference(components.ComponentDex, reg, path, prefix, is)
+	c.image, err = components.GetInstallationReference(components.ComponentDex, c.cfg.Installation, is)
 
 	var errMsgs []string
 	if err != nil {
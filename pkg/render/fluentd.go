@@ -188,18 +188,15 @@ type fluentdComponent struct {
 }
 
 func (c *fluentdComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 
 	if c.cfg.OSType == rmeta.OSTypeWindows {
 		var err error
-		c.image, err = components.GetReference(components.ComponentFluentdWindows, reg, path, prefix, is)
+		c.image, err = components.GetInstallationReference(components.ComponentFluentdWindows, c.cfg.Installation, is)
 		return err
 	}
 
 	var err error
-	c.image, err = components.GetReference(components.ComponentFluentd, reg, path, prefix, is)
+	c.image, err = components.GetInstallationReference(components.ComponentFluentd, c.cfg.Installation, is)
 	if err != nil {
 		return err
 	}
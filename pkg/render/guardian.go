@@ -18,6 +18,7 @@ package render
 
 import (
 	"net"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -82,7 +83,8 @@ func GuardianPolicy(cfg *GuardianConfiguration) (Component, error) {
 
 // GuardianConfiguration contains all the config information needed to render the component.
 type GuardianConfiguration struct {
-	URL               string
+	// URLs are the addresses of the management cluster, in the order that Guardian tries them.
+	URLs              []string
 	PullSecrets       []*corev1.Secret
 	Openshift         bool
 	Installation      *operatorv1.InstallationSpec
@@ -101,11 +103,8 @@ type GuardianComponent struct {
 }
 
 func (c *GuardianComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
-	c.image, err = components.GetReference(components.ComponentGuardian, reg, path, prefix, is)
+	c.image, err = components.GetInstallationReference(components.ComponentGuardian, c.cfg.Installation, is)
 	return err
 }
 
@@ -309,23 +308,57 @@ func (c *GuardianComponent) volumes() []corev1.Volume {
 	}
 }
 
+func (c *GuardianComponent) env() []corev1.EnvVar {
+	var voltronURL string
+	if len(c.cfg.URLs) > 0 {
+		voltronURL = c.cfg.URLs[0]
+	}
+	env := []corev1.EnvVar{
+		{Name: "GUARDIAN_PORT", Value: "9443"},
+		{Name: "GUARDIAN_LOGLEVEL", Value: "INFO"},
+		{Name: "GUARDIAN_VOLTRON_URL", Value: voltronURL},
+		{Name: "GUARDIAN_VOLTRON_CA_TYPE", Value: string(c.cfg.TunnelCAType)},
+		{Name: "GUARDIAN_PACKET_CAPTURE_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_PROMETHEUS_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_QUERYSERVER_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
+		{Name: "GUARDIAN_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
+	}
+
+	// With more than one management cluster address, Guardian fails over through the ordered list. A single address
+	// is only passed in GUARDIAN_VOLTRON_URL, as it always has been.
+	if len(c.cfg.URLs) > 1 {
+		env = append(env, corev1.EnvVar{Name: "GUARDIAN_VOLTRON_URLS", Value: strings.Join(c.cfg.URLs, ",")})
+	}
+
+	if mcc := c.cfg.ManagementClusterConnection; mcc != nil {
+		if t := mcc.Spec.ReconnectTimeout; t != nil {
+			env = append(env, corev1.EnvVar{Name: "GUARDIAN_TUNNEL_DIAL_TIMEOUT", Value: t.Duration.String()})
+		}
+		if i := mcc.Spec.KeepAliveInterval; i != nil {
+			env = append(env, corev1.EnvVar{Name: "GUARDIAN_KEEP_ALIVE_INTERVAL", Value: i.Duration.String()})
+		}
+	}
+
+	// The custom tunnel CA bundle, if any, is added to the trusted bundle by the controller.
+	if mcc := c.cfg.ManagementClusterConnection; mcc != nil && mcc.Spec.TLS != nil {
+		if mcc.Spec.TLS.CABundleSecret != nil {
+			env = append(env, corev1.EnvVar{Name: "GUARDIAN_VOLTRON_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()})
+		}
+		if mcc.Spec.TLS.ServerName != "" {
+			env = append(env, corev1.EnvVar{Name: "GUARDIAN_VOLTRON_SERVER_NAME", Value: mcc.Spec.TLS.ServerName})
+		}
+	}
+	return env
+}
+
 func (c *GuardianComponent) container() []corev1.Container {
 	return []corev1.Container{
 		{
 			Name:            GuardianDeploymentName,
 			Image:           c.image,
 			ImagePullPolicy: ImagePullPolicy(),
-			Env: []corev1.EnvVar{
-				{Name: "GUARDIAN_PORT", Value: "9443"},
-				{Name: "GUARDIAN_LOGLEVEL", Value: "INFO"},
-				{Name: "GUARDIAN_VOLTRON_URL", Value: c.cfg.URL},
-				{Name: "GUARDIAN_VOLTRON_CA_TYPE", Value: string(c.cfg.TunnelCAType)},
-				{Name: "GUARDIAN_PACKET_CAPTURE_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_PROMETHEUS_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_QUERYSERVER_CA_BUNDLE_PATH", Value: c.cfg.TrustedCertBundle.MountPath()},
-				{Name: "GUARDIAN_FIPS_MODE_ENABLED", Value: operatorv1.IsFIPSModeEnabledString(c.cfg.Installation.FIPSMode)},
-			},
-			VolumeMounts: c.volumeMounts(),
+			Env:             c.env(),
+			VolumeMounts:    c.volumeMounts(),
 			LivenessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
@@ -389,42 +422,45 @@ func guardianAllowTigeraPolicy(cfg *GuardianConfiguration) (*v3.NetworkPolicy, e
 		},
 	}...)
 
-	// Assumes address has the form "host:port", required by net.Dial for TCP.
-	host, port, err := net.SplitHostPort(cfg.URL)
-	if err != nil {
-		return nil, err
-	}
-	parsedPort, err := numorstring.PortFromString(port)
-	if err != nil {
-		return nil, err
-	}
-	parsedIp := net.ParseIP(host)
-	if parsedIp == nil {
-		// Assume host is a valid hostname.
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Allow,
-			Protocol: &networkpolicy.TCPProtocol,
-			Destination: v3.EntityRule{
-				Domains: []string{host},
-				Ports:   []numorstring.Port{parsedPort},
-			},
-		})
-	} else {
-		var netSuffix string
-		if parsedIp.To4() != nil {
-			netSuffix = "/32"
+	// Allow egress to each management cluster address that Guardian may fail over to.
+	for _, addr := range cfg.URLs {
+		// Assumes address has the form "host:port", required by net.Dial for TCP.
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		parsedPort, err := numorstring.PortFromString(port)
+		if err != nil {
+			return nil, err
+		}
+		parsedIp := net.ParseIP(host)
+		if parsedIp == nil {
+			// Assume host is a valid hostname.
+			egressRules = append(egressRules, v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					Domains: []string{host},
+					Ports:   []numorstring.Port{parsedPort},
+				},
+			})
 		} else {
-			netSuffix = "/128"
+			var netSuffix string
+			if parsedIp.To4() != nil {
+				netSuffix = "/32"
+			} else {
+				netSuffix = "/128"
+			}
+
+			egressRules = append(egressRules, v3.Rule{
+				Action:   v3.Allow,
+				Protocol: &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{
+					Nets:  []string{parsedIp.String() + netSuffix},
+					Ports: []numorstring.Port{parsedPort},
+				},
+			})
 		}
-
-		egressRules = append(egressRules, v3.Rule{
-			Action:   v3.Allow,
-			Protocol: &networkpolicy.TCPProtocol,
-			Destination: v3.EntityRule{
-				Nets:  []string{parsedIp.String() + netSuffix},
-				Ports: []numorstring.Port{parsedPort},
-			},
-		})
 	}
 
 	egressRules = append(egressRules, v3.Rule{Action: v3.Pass})
@@ -15,6 +15,8 @@
 package render_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -70,7 +72,7 @@ var _ = Describe("Rendering tests", func() {
 		bundle := certificateManager.CreateTrustedBundle()
 
 		return &render.GuardianConfiguration{
-			URL: addr,
+			URLs: []string{addr},
 			PullSecrets: []*corev1.Secret{{
 				TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
 				ObjectMeta: metav1.ObjectMeta{
@@ -160,6 +162,25 @@ var _ = Describe("Rendering tests", func() {
 			}}))
 		})
 
+		It("should only pass a single management cluster address in GUARDIAN_VOLTRON_URL", func() {
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_URL", "127.0.0.1:1234")
+			Expect(container.Env).NotTo(ContainElement(HaveField("Name", "GUARDIAN_VOLTRON_URLS")))
+		})
+
+		It("should pass multiple management cluster addresses in order", func() {
+			cfg.URLs = []string{"10.0.0.1:9449", "mgmt-b.example.com:9449"}
+			g = render.Guardian(cfg)
+			Expect(g.ResolveImages(nil)).To(BeNil())
+			resources, _ = g.Objects()
+
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_URL", "10.0.0.1:9449")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_URLS", "10.0.0.1:9449,mgmt-b.example.com:9449")
+		})
+
 		It("should render controlPlaneTolerations", func() {
 			t := corev1.Toleration{
 				Key:      "foo",
@@ -237,6 +258,21 @@ var _ = Describe("Rendering tests", func() {
 				Expect(managementClusterEgressRule.Destination.Domains).To(Equal([]string{"mydomain.io"}))
 				Expect(managementClusterEgressRule.Destination.Ports).To(Equal(networkpolicy.Ports(8080)))
 			})
+
+			It("should allow egress to every management cluster address", func() {
+				cfg := createGuardianConfig(operatorv1.InstallationSpec{Registry: "my-reg/"}, "10.0.0.1:9449", false)
+				cfg.URLs = append(cfg.URLs, "mgmt-b.example.com:9450")
+				g, err := render.GuardianPolicy(cfg)
+				Expect(err).NotTo(HaveOccurred())
+				resources, _ = g.Objects()
+
+				policy := testutils.GetAllowTigeraPolicyFromResources(policyName, resources)
+				Expect(policy.Spec.Egress[5].Destination.Nets).To(Equal([]string{"10.0.0.1/32"}))
+				Expect(policy.Spec.Egress[5].Destination.Ports).To(Equal(networkpolicy.Ports(9449)))
+				Expect(policy.Spec.Egress[6].Destination.Domains).To(Equal([]string{"mgmt-b.example.com"}))
+				Expect(policy.Spec.Egress[6].Destination.Ports).To(Equal(networkpolicy.Ports(9450)))
+				Expect(policy.Spec.Egress[7].Action).To(Equal(v3.Action(v3.Pass)))
+			})
 		})
 	})
 })
@@ -291,6 +327,52 @@ var _ = Describe("guardian", func() {
 			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
 			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_CA_TYPE", "Public")
 		})
+
+		It("should render the tunnel CA bundle and server name when configured", func() {
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{
+				Spec: operatorv1.ManagementClusterConnectionSpec{
+					TLS: &operatorv1.ManagementClusterTLS{
+						CA:             operatorv1.CATypeTigera,
+						CABundleSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tunnel-ca"}, Key: "ca.crt"},
+						ServerName:     "voltron.example.com",
+					},
+				},
+			}
+			g := render.Guardian(cfg)
+			resources, _ := g.Objects()
+
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_CA_BUNDLE_PATH", cfg.TrustedCertBundle.MountPath())
+			rtest.ExpectEnv(container.Env, "GUARDIAN_VOLTRON_SERVER_NAME", "voltron.example.com")
+		})
+
+		It("should render the tunnel timings when configured", func() {
+			cfg.ManagementClusterConnection = &operatorv1.ManagementClusterConnection{
+				Spec: operatorv1.ManagementClusterConnectionSpec{
+					ReconnectTimeout:  &metav1.Duration{Duration: 2 * time.Minute},
+					KeepAliveInterval: &metav1.Duration{Duration: 15 * time.Second},
+				},
+			}
+			g := render.Guardian(cfg)
+			resources, _ := g.Objects()
+
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_TUNNEL_DIAL_TIMEOUT", "2m0s")
+			rtest.ExpectEnv(container.Env, "GUARDIAN_KEEP_ALIVE_INTERVAL", "15s")
+		})
+
+		It("should not render the tunnel TLS and timing settings by default", func() {
+			g := render.Guardian(cfg)
+			resources, _ := g.Objects()
+
+			deployment := rtest.GetResource(resources, render.GuardianDeploymentName, render.GuardianNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+			container := rtest.GetContainer(deployment.Spec.Template.Spec.Containers, "tigera-guardian")
+			for _, env := range container.Env {
+				Expect(env.Name).NotTo(BeElementOf("GUARDIAN_VOLTRON_CA_BUNDLE_PATH", "GUARDIAN_VOLTRON_SERVER_NAME", "GUARDIAN_TUNNEL_DIAL_TIMEOUT", "GUARDIAN_KEEP_ALIVE_INTERVAL"))
+			}
+		})
 		It("should render guardian with resource requests and limits when configured", func() {
 
 			guardianResources := corev1.ResourceRequirements{
@@ -121,18 +121,15 @@ type intrusionDetectionComponent struct {
 }
 
 func (c *intrusionDetectionComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var errMsgs []string
 	var err error
 
-	c.controllerImage, err = components.GetReference(components.ComponentIntrusionDetectionController, reg, path, prefix, is)
+	c.controllerImage, err = components.GetInstallationReference(components.ComponentIntrusionDetectionController, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	c.webhooksProcessorImage, err = components.GetReference(components.ComponentSecurityEventWebhooksProcessor, reg, path, prefix, is)
+	c.webhooksProcessorImage, err = components.GetInstallationReference(components.ComponentSecurityEventWebhooksProcessor, c.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -73,12 +73,7 @@ type dpiComponent struct {
 
 func (d *dpiComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	var err error
-	d.dpiImage, err = components.GetReference(
-		components.ComponentDeepPacketInspection,
-		d.cfg.Installation.Registry,
-		d.cfg.Installation.ImagePath,
-		d.cfg.Installation.ImagePrefix,
-		is)
+	d.dpiImage, err = components.GetInstallationReference(components.ComponentDeepPacketInspection, d.cfg.Installation, is)
 	if err != nil {
 		return err
 	}
@@ -251,17 +251,14 @@ type kubeControllersComponent struct {
 }
 
 func (c *kubeControllersComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.image, err = components.GetReference(components.ComponentTigeraKubeControllers, reg, path, prefix, is)
+		c.image, err = components.GetInstallationReference(components.ComponentTigeraKubeControllers, c.cfg.Installation, is)
 	} else {
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.image, err = components.GetReference(components.ComponentCalicoKubeControllersFIPS, reg, path, prefix, is)
+			c.image, err = components.GetInstallationReference(components.ComponentCalicoKubeControllersFIPS, c.cfg.Installation, is)
 		} else {
-			c.image, err = components.GetReference(components.ComponentCalicoKubeControllers, reg, path, prefix, is)
+			c.image, err = components.GetInstallationReference(components.ComponentCalicoKubeControllers, c.cfg.Installation, is)
 		}
 	}
 	return err
@@ -232,26 +232,23 @@ type elasticsearchComponent struct {
 }
 
 func (es *elasticsearchComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := es.cfg.Installation.Registry
-	path := es.cfg.Installation.ImagePath
-	prefix := es.cfg.Installation.ImagePrefix
 	var err error
 	if operatorv1.IsFIPSModeEnabled(es.cfg.Installation.FIPSMode) {
-		es.esImage, err = components.GetReference(components.ComponentElasticsearchFIPS, reg, path, prefix, is)
+		es.esImage, err = components.GetInstallationReference(components.ComponentElasticsearchFIPS, es.cfg.Installation, is)
 	} else {
-		es.esImage, err = components.GetReference(components.ComponentElasticsearch, reg, path, prefix, is)
+		es.esImage, err = components.GetInstallationReference(components.ComponentElasticsearch, es.cfg.Installation, is)
 	}
 	errMsgs := make([]string, 0)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	es.esOperatorImage, err = components.GetReference(components.ComponentElasticsearchOperator, reg, path, prefix, is)
+	es.esOperatorImage, err = components.GetInstallationReference(components.ComponentElasticsearchOperator, es.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	es.kibanaImage, err = components.GetReference(components.ComponentKibana, reg, path, prefix, is)
+	es.kibanaImage, err = components.GetInstallationReference(components.ComponentKibana, es.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
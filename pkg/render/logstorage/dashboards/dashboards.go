@@ -98,14 +98,11 @@ type Config struct {
 }
 
 func (d *dashboards) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := d.cfg.Installation.Registry
-	path := d.cfg.Installation.ImagePath
-	prefix := d.cfg.Installation.ImagePrefix
 	var err error
 	errMsgs := []string{}
 
 	// Calculate the image(s) to use for Dashboards, given user registry configuration.
-	d.image, err = components.GetReference(components.ComponentElasticTseeInstaller, reg, path, prefix, is)
+	d.image, err = components.GetInstallationReference(components.ComponentElasticTseeInstaller, d.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
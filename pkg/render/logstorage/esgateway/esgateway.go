@@ -89,13 +89,10 @@ type Config struct {
 }
 
 func (e *esGateway) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := e.cfg.Installation.Registry
-	path := e.cfg.Installation.ImagePath
-	prefix := e.cfg.Installation.ImagePrefix
 	var err error
 	errMsgs := []string{}
 
-	e.esGatewayImage, err = components.GetReference(components.ComponentESGateway, reg, path, prefix, is)
+	e.esGatewayImage, err = components.GetInstallationReference(components.ComponentESGateway, e.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -84,11 +84,7 @@ type elasticsearchMetrics struct {
 func (e *elasticsearchMetrics) ResolveImages(is *operatorv1.ImageSet) error {
 	var err error
 
-	reg := e.cfg.Installation.Registry
-	path := e.cfg.Installation.ImagePath
-	prefix := e.cfg.Installation.ImagePrefix
-
-	e.esMetricsImage, err = components.GetReference(components.ComponentElasticsearchMetrics, reg, path, prefix, is)
+	e.esMetricsImage, err = components.GetInstallationReference(components.ComponentElasticsearchMetrics, e.cfg.Installation, is)
 	if err != nil {
 		return err
 	}
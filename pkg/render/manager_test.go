@@ -413,6 +413,21 @@ var _ = Describe("Tigera Secure Manager rendering tests", func() {
 		Expect(d.Spec.Template.Spec.Containers[2].Env).To(ContainElement(oidcEnvVar))
 	})
 
+	It("should query Prometheus under its route prefix", func() {
+		resources := renderObjects(renderConfig{
+			installation:          installation,
+			compliance:            compliance,
+			ns:                    render.ManagerNamespace,
+			prometheusRoutePrefix: "/prometheus",
+		})
+		d := rtest.GetResource(resources, "tigera-manager", render.ManagerNamespace, "apps", "v1", "Deployment").(*appsv1.Deployment)
+		Expect(d).NotTo(BeNil())
+		Expect(d.Spec.Template.Spec.Containers[2].Env).To(ContainElement(corev1.EnvVar{
+			Name:  "CNX_PROMETHEUS_API_URL",
+			Value: "/api/v1/namespaces/tigera-prometheus/services/calico-node-prometheus:9090/proxy/prometheus/api/v1",
+		}))
+	})
+
 	Describe("public ca bundle", func() {
 		var cfg *render.ManagerConfiguration
 		BeforeEach(func() {
@@ -1294,6 +1309,7 @@ type renderConfig struct {
 	tenant                  *operatorv1.Tenant
 	manager                 *operatorv1.Manager
 	externalElastic         bool
+	prometheusRoutePrefix   string
 }
 
 func renderObjects(roc renderConfig) []client.Object {
@@ -1366,6 +1382,7 @@ func renderObjects(roc renderConfig) []client.Object {
 		Tenant:                  roc.tenant,
 		Manager:                 roc.manager,
 		ExternalElastic:         roc.externalElastic,
+		PrometheusRoutePrefix:   roc.prometheusRoutePrefix,
 	}
 	component, err := render.Manager(cfg)
 	Expect(err).To(BeNil(), "Expected Manager to create successfully %s", err)
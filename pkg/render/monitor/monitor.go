@@ -18,12 +18,17 @@ import (
 	"crypto/x509"
 	_ "embed"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"gopkg.in/yaml.v2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -42,6 +47,8 @@ import (
 	"github.com/tigera/operator/pkg/render/common/configmap"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
 	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
+	"github.com/tigera/operator/pkg/render/common/poddisruptionbudget"
 	"github.com/tigera/operator/pkg/render/common/podsecuritypolicy"
 	"github.com/tigera/operator/pkg/render/common/secret"
 	"github.com/tigera/operator/pkg/render/common/securitycontext"
@@ -81,18 +88,53 @@ const (
 	AlertManagerPolicyName     = networkpolicy.TigeraComponentPolicyPrefix + CalicoNodeAlertmanager
 	AlertmanagerConfigSecret   = "alertmanager-calico-node-alertmanager"
 	AlertmanagerPort           = 9093
+	AlertmanagerProxyPort      = 9096
 	MeshAlertManagerPolicyName = AlertManagerPolicyName + "-mesh"
 
+	// AlertmanagerExternalServiceName is the name of the Service that exposes the Alertmanager authenticating proxy.
+	AlertmanagerExternalServiceName = "alertmanager-http-api"
+
+	// PrometheusFederationSecretName is the name of the Secret holding the additional scrape configs that federate
+	// metrics from the sources in the Monitor spec.
+	PrometheusFederationSecretName = "calico-node-prometheus-federation"
+	prometheusFederationSecretKey  = "federation.yaml"
+
+	// PrometheusAlertmanagersSecretName is the name of the Secret holding the Alertmanager configs that point
+	// Prometheus at the external Alertmanagers in the Monitor spec.
+	PrometheusAlertmanagersSecretName = "calico-node-prometheus-alertmanagers"
+	prometheusAlertmanagersSecretKey  = "alertmanagers.yaml"
+
+	// AlertmanagerStatefulSetName is the name of the StatefulSet that the prometheus operator creates for Alertmanager.
+	AlertmanagerStatefulSetName = "alertmanager-" + CalicoNodeAlertmanager
+
+	// RemoteStorageCAKey is the key of the optional CA certificate in a remote write or remote read TLS Secret.
+	RemoteStorageCAKey = "ca.crt"
+
+	// AdditionalServiceMonitorLabel labels the ServiceMonitors that the operator creates for the additional
+	// ServiceMonitors in the Monitor spec, so that the ones that are no longer configured can be found and removed.
+	AdditionalServiceMonitorLabel = "operator.tigera.io/additional-service-monitor"
+
+	// RemoteStorageSecretLabel labels the copies of the remote write and remote read Secrets in the tigera-prometheus
+	// namespace, so that the copies of the Secrets that are no longer referenced can be found and removed.
+	RemoteStorageSecretLabel = "operator.tigera.io/remote-storage-secret"
+
 	ElasticsearchMetrics = "elasticsearch-metrics"
 	FluentdMetrics       = "fluentd-metrics"
 
 	calicoNodePrometheusServiceName       = "calico-node-prometheus"
 	tigeraPrometheusServiceHealthEndpoint = "/health"
 
+	defaultScrapeInterval = "5s"
+	defaultScrapeTimeout  = "5s"
+
 	bearerTokenFile       = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	KubeControllerMetrics = "calico-kube-controllers-metrics"
 )
 
+// PrometheusStorageClaimPrefix is the name prefix of the PersistentVolumeClaims of the Prometheus replicas, which are
+// created from the volume claim template named after the Prometheus CR when the Monitor configures a storage size.
+var PrometheusStorageClaimPrefix = fmt.Sprintf("prometheus-%[1]s-db-prometheus-%[1]s-", CalicoNodePrometheus)
+
 var alertManagerSelector = fmt.Sprintf(
 	"(app == 'alertmanager' && alertmanager == '%[1]s') || (app.kubernetes.io/name == 'alertmanager' && alertmanager == '%[1]s')",
 	CalicoNodeAlertmanager,
@@ -110,14 +152,68 @@ func Monitor(cfg *Config) render.Component {
 }
 
 func MonitorPolicy(cfg *Config) render.Component {
-	return render.NewPassthrough(
-		allowTigeraAlertManagerPolicy(cfg),
-		allowTigeraAlertManagerMeshPolicy(cfg),
-		allowTigeraPrometheusPolicy(cfg),
-		allowTigeraPrometheusAPIPolicy(cfg),
-		allowTigeraPrometheusOperatorPolicy(cfg),
+	return &monitorPolicyComponent{cfg: cfg}
+}
+
+// AlertmanagerMeshPolicyEnabled returns whether the Alertmanager mesh policy should be rendered for the given Monitor.
+func AlertmanagerMeshPolicyEnabled(spec operatorv1.MonitorSpec) bool {
+	return spec.AlertmanagerMeshPolicy == nil || *spec.AlertmanagerMeshPolicy == operatorv1.AlertmanagerMeshPolicyEnabled
+}
+
+// UsesExternalAlertmanager returns whether Prometheus sends its alerts to external Alertmanagers instead of the
+// Alertmanager deployed by the operator.
+func UsesExternalAlertmanager(spec operatorv1.MonitorSpec) bool {
+	return len(spec.ExternalAlertmanager) > 0
+}
+
+// AlertmanagerEnabled returns whether Alertmanager is enabled for the given Monitor.
+func AlertmanagerEnabled(spec operatorv1.MonitorSpec) bool {
+	return spec.AlertmanagerMode == nil || *spec.AlertmanagerMode == operatorv1.AlertmanagerModeEnabled
+}
+
+// DeploysAlertmanager returns whether the operator deploys its own Alertmanager for the given Monitor.
+func DeploysAlertmanager(spec operatorv1.MonitorSpec) bool {
+	return AlertmanagerEnabled(spec) && !UsesExternalAlertmanager(spec)
+}
+
+// PrometheusRoutePrefix returns the path prefix under which Prometheus serves its API for the given Monitor, without a
+// trailing slash. It is empty if Prometheus is served from the root path.
+func PrometheusRoutePrefix(spec operatorv1.MonitorSpec) string {
+	return strings.TrimSuffix(spec.RoutePrefix, "/")
+}
+
+// monitorPolicyComponent renders the allow-tigera policies of the tigera-prometheus namespace.
+type monitorPolicyComponent struct {
+	cfg *Config
+}
+
+func (c *monitorPolicyComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *monitorPolicyComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *monitorPolicyComponent) Objects() ([]client.Object, []client.Object) {
+	toCreate := []client.Object{
+		allowTigeraAlertManagerPolicy(c.cfg),
+		allowTigeraPrometheusPolicy(c.cfg),
+		allowTigeraPrometheusAPIPolicy(c.cfg),
+		allowTigeraPrometheusOperatorPolicy(c.cfg),
 		networkpolicy.AllowTigeraDefaultDeny(common.TigeraPrometheusNamespace),
-	)
+	}
+	var toDelete []client.Object
+	if AlertmanagerMeshPolicyEnabled(c.cfg.Monitor) {
+		toCreate = append(toCreate, allowTigeraAlertManagerMeshPolicy(c.cfg))
+	} else {
+		toDelete = append(toDelete, allowTigeraAlertManagerMeshPolicy(c.cfg))
+	}
+	return toCreate, toDelete
+}
+
+func (c *monitorPolicyComponent) Ready() bool {
+	return true
 }
 
 // Config contains all the config information needed to render the Monitor component.
@@ -134,6 +230,18 @@ type Config struct {
 	Openshift                bool
 	KubeControllerPort       int
 	UsePSP                   bool
+
+	// RemoteStorageSecrets are the Secrets referenced by Monitor.RemoteWrite and Monitor.RemoteRead, read from the
+	// tigera-operator namespace. They are copied into the tigera-prometheus namespace for Prometheus to use.
+	RemoteStorageSecrets []*corev1.Secret
+
+	// StaleAdditionalServiceMonitors are the names of the ServiceMonitors that the operator created for
+	// Monitor.AdditionalServiceMonitors, but which are no longer configured. They are deleted.
+	StaleAdditionalServiceMonitors []string
+
+	// StaleRemoteStorageSecrets are the names of the copies of remote write and remote read Secrets in the
+	// tigera-prometheus namespace that are no longer referenced by the Monitor. They are deleted.
+	StaleRemoteStorageSecrets []string
 }
 
 type monitorComponent struct {
@@ -144,24 +252,21 @@ type monitorComponent struct {
 }
 
 func (mc *monitorComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := mc.cfg.Installation.Registry
-	path := mc.cfg.Installation.ImagePath
-	prefix := mc.cfg.Installation.ImagePrefix
 
 	errMsgs := []string{}
 	var err error
 
-	mc.alertmanagerImage, err = components.GetReference(components.ComponentPrometheusAlertmanager, reg, path, prefix, is)
+	mc.alertmanagerImage, err = components.GetInstallationReference(components.ComponentPrometheusAlertmanager, mc.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	mc.prometheusImage, err = components.GetReference(components.ComponentPrometheus, reg, path, prefix, is)
+	mc.prometheusImage, err = components.GetInstallationReference(components.ComponentPrometheus, mc.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
 
-	mc.prometheusServiceImage, err = components.GetReference(components.ComponentTigeraPrometheusService, reg, path, prefix, is)
+	mc.prometheusServiceImage, err = components.GetInstallationReference(components.ComponentTigeraPrometheusService, mc.cfg.Installation, is)
 	if err != nil {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -195,7 +300,13 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 	)
 
 	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.PullSecrets...)...)...)
-	toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.AlertmanagerConfigSecret)...)...)
+	if AlertmanagerEnabled(mc.cfg.Monitor) {
+		toCreate = append(toCreate, secret.ToRuntimeObjects(secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.AlertmanagerConfigSecret)...)...)
+	}
+	for _, s := range secret.CopyToNamespace(common.TigeraPrometheusNamespace, mc.cfg.RemoteStorageSecrets...) {
+		s.Labels = map[string]string{RemoteStorageSecretLabel: "true"}
+		toCreate = append(toCreate, s)
+	}
 
 	toCreate = append(toCreate,
 		mc.prometheusOperatorServiceAccount(),
@@ -205,17 +316,20 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 		mc.prometheusClusterRole(),
 		mc.prometheusClusterRoleBinding(),
 		mc.prometheus(),
-		mc.alertmanagerService(),
-		mc.alertmanager(),
+	)
+	if DeploysAlertmanager(mc.cfg.Monitor) {
+		toCreate = append(toCreate, mc.alertmanagerService(), mc.alertmanager())
+	}
+	toCreate = append(toCreate,
 		mc.prometheusServiceService(),
 		mc.prometheusServiceClusterRole(),
 		mc.prometheusServiceClusterRoleBinding(),
 		mc.prometheusRule(),
-		mc.serviceMonitorCalicoNode(),
-		mc.serviceMonitorElasticsearch(),
-		mc.serviceMonitorFluentd(),
-		mc.serviceMonitorQueryServer(),
-		mc.serviceMonitorCalicoKubeControllers(),
+		mc.withEndpointConfig(mc.serviceMonitorCalicoNode()),
+		mc.withEndpointConfig(mc.serviceMonitorElasticsearch()),
+		mc.withEndpointConfig(mc.serviceMonitorFluentd()),
+		mc.withEndpointConfig(mc.serviceMonitorQueryServer()),
+		mc.withEndpointConfig(mc.serviceMonitorCalicoKubeControllers()),
 	)
 
 	if mc.cfg.KeyValidatorConfig != nil {
@@ -238,13 +352,86 @@ func (mc *monitorComponent) Objects() ([]client.Object, []client.Object) {
 		}
 	}
 
+	for _, asm := range mc.cfg.Monitor.AdditionalServiceMonitors {
+		toCreate = append(toCreate, mc.additionalServiceMonitor(asm))
+	}
+
 	var toDelete []client.Object
+	for _, name := range mc.cfg.StaleAdditionalServiceMonitors {
+		toDelete = append(toDelete, &monitoringv1.ServiceMonitor{
+			TypeMeta:   metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: MonitoringAPIVersion},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
+	for _, name := range mc.cfg.StaleRemoteStorageSecrets {
+		toDelete = append(toDelete, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
 	if mc.cfg.Installation.TyphaMetricsPort != nil {
-		toCreate = append(toCreate, mc.typhaServiceMonitor())
+		toCreate = append(toCreate, mc.withEndpointConfig(mc.typhaServiceMonitor()))
 	} else {
 		toDelete = append(toDelete, mc.typhaServiceMonitor())
 	}
 
+	if UsesExternalAlertmanager(mc.cfg.Monitor) {
+		toCreate = append(toCreate, mc.prometheusAlertmanagersSecret())
+	} else {
+		toDelete = append(toDelete, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: PrometheusAlertmanagersSecretName, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
+	if !DeploysAlertmanager(mc.cfg.Monitor) {
+		// Deleting the Alertmanager resource makes the prometheus operator remove its StatefulSet. The StatefulSet is
+		// deleted as well so that it is no longer monitored.
+		toDelete = append(toDelete,
+			mc.alertmanagerService(),
+			mc.alertmanager(),
+			&appsv1.StatefulSet{
+				TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: AlertmanagerStatefulSetName, Namespace: common.TigeraPrometheusNamespace},
+			},
+		)
+	}
+	if !AlertmanagerEnabled(mc.cfg.Monitor) {
+		// Remove the copy of the Alertmanager configuration left behind from when Alertmanager was enabled.
+		toDelete = append(toDelete, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: AlertmanagerConfigSecret, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
+
+	if mc.cfg.Monitor.AlertmanagerExternalAccess != nil {
+		toCreate = append(toCreate, mc.alertmanagerExternalService())
+	} else {
+		toDelete = append(toDelete, &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: AlertmanagerExternalServiceName, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
+
+	if len(mc.cfg.Monitor.Federation) > 0 {
+		toCreate = append(toCreate, mc.prometheusFederationSecret())
+	} else {
+		toDelete = append(toDelete, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: PrometheusFederationSecretName, Namespace: common.TigeraPrometheusNamespace},
+		})
+	}
+
+	if pdb := mc.prometheusPodDisruptionBudget(); pdb != nil {
+		toCreate = append(toCreate, pdb)
+	} else {
+		toDelete = append(toDelete, podDisruptionBudgetToDelete(CalicoNodePrometheus))
+	}
+	if pdb := mc.alertmanagerPodDisruptionBudget(); pdb != nil {
+		toCreate = append(toCreate, pdb)
+	} else {
+		toDelete = append(toDelete, podDisruptionBudgetToDelete(CalicoNodeAlertmanager))
+	}
+
 	toDelete = append(toDelete,
 		// Remove the pod monitor that existed prior to v1.25.
 		&monitoringv1.PodMonitor{ObjectMeta: metav1.ObjectMeta{Name: FluentdMetrics, Namespace: common.TigeraPrometheusNamespace}},
@@ -425,7 +612,7 @@ func (mc *monitorComponent) alertmanager() *monitoringv1.Alertmanager {
 			ImagePullPolicy:    render.ImagePullPolicy(),
 			ImagePullSecrets:   secret.GetReferenceList(mc.cfg.PullSecrets),
 			NodeSelector:       mc.cfg.Installation.ControlPlaneNodeSelector,
-			Replicas:           mc.cfg.Installation.ControlPlaneReplicas,
+			Replicas:           alertmanagerReplicas(mc.cfg.Monitor, mc.cfg.Installation),
 			SecurityContext:    securitycontext.NewNonRootPodContext(),
 			ServiceAccountName: PrometheusServiceAccountName,
 			Tolerations:        mc.cfg.Installation.ControlPlaneTolerations,
@@ -433,9 +620,184 @@ func (mc *monitorComponent) alertmanager() *monitoringv1.Alertmanager {
 			Resources:          resources,
 		},
 	}
+
+	if level := mc.cfg.Monitor.AlertmanagerLogLevel; level != nil {
+		// Alertmanager expects lower case log levels.
+		am.Spec.LogLevel = strings.ToLower(string(*level))
+	}
+
+	if mc.cfg.Monitor.AlertmanagerExternalAccess != nil {
+		// Put an authenticating proxy in front of Alertmanager, in the same way as it is done for Prometheus.
+		if mc.cfg.ServerTLSSecret.UseCertificateManagement() {
+			am.Spec.InitContainers = append(am.Spec.InitContainers, mc.cfg.ServerTLSSecret.InitContainer(common.TigeraPrometheusNamespace))
+		}
+		am.Spec.Containers = append(am.Spec.Containers, mc.alertmanagerAuthnProxyContainer())
+		am.Spec.Volumes = append(am.Spec.Volumes, mc.cfg.ServerTLSSecret.Volume(), mc.cfg.TrustedCertBundle.Volume())
+	}
 	return am
 }
 
+// alertmanagerAuthnProxyContainer returns the authn-proxy sidecar that authenticates requests before forwarding them
+// to Alertmanager.
+func (mc *monitorComponent) alertmanagerAuthnProxyContainer() corev1.Container {
+	env := []corev1.EnvVar{
+		{
+			Name:  "PROMETHEUS_ENDPOINT_URL",
+			Value: fmt.Sprintf("http://localhost:%d", AlertmanagerPort),
+		},
+		{
+			Name:  "LISTEN_ADDR",
+			Value: fmt.Sprintf(":%d", AlertmanagerProxyPort),
+		},
+		{
+			Name:  "TLS_KEY",
+			Value: mc.cfg.ServerTLSSecret.VolumeMountKeyFilePath(),
+		},
+		{
+			Name:  "TLS_CERT",
+			Value: mc.cfg.ServerTLSSecret.VolumeMountCertificateFilePath(),
+		},
+		{
+			// No other way to annotate this pod.
+			Name:  "TLS_SERVER_SECRET_HASH_ANNOTATION",
+			Value: mc.cfg.ServerTLSSecret.HashAnnotationValue(),
+		},
+		{
+			// No other way to annotate this pod.
+			Name:  "TLS_CA_BUNDLE_HASH_ANNOTATION",
+			Value: rmeta.AnnotationHash(mc.cfg.TrustedCertBundle.HashAnnotations()),
+		},
+		{
+			Name:  "FIPS_MODE_ENABLED",
+			Value: operatorv1.IsFIPSModeEnabledString(mc.cfg.Installation.FIPSMode),
+		},
+	}
+	if mc.cfg.KeyValidatorConfig != nil {
+		env = append(env, mc.cfg.KeyValidatorConfig.RequiredEnv("")...)
+	}
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   tigeraPrometheusServiceHealthEndpoint,
+				Port:   intstr.FromInt(AlertmanagerProxyPort),
+				Scheme: "HTTPS",
+			},
+		},
+	}
+	return corev1.Container{
+		Name:            "authn-proxy",
+		Image:           mc.prometheusServiceImage,
+		ImagePullPolicy: render.ImagePullPolicy(),
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: AlertmanagerProxyPort,
+			},
+		},
+		Env: env,
+		VolumeMounts: append(
+			mc.cfg.TrustedCertBundle.VolumeMounts(mc.SupportedOSType()),
+			mc.cfg.ServerTLSSecret.VolumeMount(mc.SupportedOSType()),
+		),
+		ReadinessProbe:  probe,
+		LivenessProbe:   probe,
+		SecurityContext: securitycontext.NewNonRootContext(),
+	}
+}
+
+// alertmanagerExternalService exposes the Alertmanager authenticating proxy.
+func (mc *monitorComponent) alertmanagerExternalService() *corev1.Service {
+	serviceType := corev1.ServiceTypeClusterIP
+	if t := mc.cfg.Monitor.AlertmanagerExternalAccess.ServiceType; t != "" {
+		serviceType = t
+	}
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AlertmanagerExternalServiceName,
+			Namespace: common.TigeraPrometheusNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: serviceType,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "web",
+					Port:       AlertmanagerPort,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(AlertmanagerProxyPort),
+				},
+			},
+			Selector: map[string]string{
+				"alertmanager": CalicoNodeAlertmanager,
+			},
+		},
+	}
+}
+
+// AlertmanagerReplicas returns the number of Alertmanager replicas for the given monitor and installation.
+func AlertmanagerReplicas(monitor operatorv1.MonitorSpec, installation *operatorv1.InstallationSpec) int32 {
+	if replicas := alertmanagerReplicas(monitor, installation); replicas != nil {
+		return *replicas
+	}
+	// The prometheus operator runs a single replica when none is specified.
+	return 1
+}
+
+// alertmanagerReplicas returns the replica count to render for Alertmanager. The Monitor replica count takes precedence
+// over the control plane replica count of the installation.
+func alertmanagerReplicas(monitor operatorv1.MonitorSpec, installation *operatorv1.InstallationSpec) *int32 {
+	if monitor.AlertmanagerReplicas != nil {
+		return monitor.AlertmanagerReplicas
+	}
+	return installation.ControlPlaneReplicas
+}
+
+// PrometheusReplicas returns the number of Prometheus replicas for the given monitor.
+func PrometheusReplicas(monitor operatorv1.MonitorSpec) int32 {
+	if monitor.PrometheusReplicas != nil {
+		return *monitor.PrometheusReplicas
+	}
+	// The prometheus operator runs a single replica when none is specified.
+	return 1
+}
+
+func (mc *monitorComponent) alertmanagerPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	if !DeploysAlertmanager(mc.cfg.Monitor) || mc.cfg.Monitor.AlertManager == nil || mc.cfg.Monitor.AlertManager.AlertManagerSpec == nil {
+		return nil
+	}
+	cfg := mc.cfg.Monitor.AlertManager.AlertManagerSpec.PodDisruptionBudget
+	if cfg == nil || AlertmanagerReplicas(mc.cfg.Monitor, mc.cfg.Installation) <= 1 {
+		return nil
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{
+		"app.kubernetes.io/name": "alertmanager",
+		"alertmanager":           CalicoNodeAlertmanager,
+	}}
+	return poddisruptionbudget.New(CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, selector, cfg)
+}
+
+func (mc *monitorComponent) prometheusPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	if mc.cfg.Monitor.Prometheus == nil || mc.cfg.Monitor.Prometheus.PrometheusSpec == nil {
+		return nil
+	}
+	cfg := mc.cfg.Monitor.Prometheus.PrometheusSpec.PodDisruptionBudget
+	if cfg == nil || PrometheusReplicas(mc.cfg.Monitor) <= 1 {
+		return nil
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{
+		"app.kubernetes.io/name": "prometheus",
+		"prometheus":             CalicoNodePrometheus,
+	}}
+	return poddisruptionbudget.New(CalicoNodePrometheus, common.TigeraPrometheusNamespace, selector, cfg)
+}
+
+func podDisruptionBudgetToDelete(name string) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta:   metav1.TypeMeta{Kind: "PodDisruptionBudget", APIVersion: "policy/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: common.TigeraPrometheusNamespace},
+	}
+}
+
 func (mc *monitorComponent) alertmanagerService() *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
@@ -575,6 +937,7 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 				ListenLocal:            true,
 				NodeSelector:           mc.cfg.Installation.ControlPlaneNodeSelector,
 				PodMonitorSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"team": "network-operators"}},
+				Replicas:               mc.cfg.Monitor.PrometheusReplicas,
 				Resources:              corev1.ResourceRequirements{Requests: corev1.ResourceList{"memory": resource.MustParse("400Mi")}},
 				SecurityContext:        securitycontext.NewNonRootPodContext(),
 				ServiceAccountName:     PrometheusServiceAccountName,
@@ -602,6 +965,88 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 		},
 	}
 
+	if PrometheusReplicas(mc.cfg.Monitor) > 1 {
+		// Spread the replicas across nodes and zones so that losing one of them does not take down all of Prometheus.
+		prometheus.Spec.Affinity = podaffinity.NewPodAntiAffinity(TigeraPrometheusObjectName, common.TigeraPrometheusNamespace)
+	}
+
+	if UsesExternalAlertmanager(mc.cfg.Monitor) {
+		prometheus.Spec.Alerting = nil
+		prometheus.Spec.AdditionalAlertManagerConfigs = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: PrometheusAlertmanagersSecretName},
+			Key:                  prometheusAlertmanagersSecretKey,
+		}
+	} else if !AlertmanagerEnabled(mc.cfg.Monitor) {
+		prometheus.Spec.Alerting = nil
+	}
+
+	if len(mc.cfg.Monitor.Federation) > 0 {
+		prometheus.Spec.AdditionalScrapeConfigs = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: PrometheusFederationSecretName},
+			Key:                  prometheusFederationSecretKey,
+		}
+	}
+
+	if tsdb := mc.cfg.Monitor.TSDB; tsdb != nil {
+		if tsdb.MinBlockDuration != "" {
+			prometheus.Spec.AdditionalArgs = append(prometheus.Spec.AdditionalArgs, monitoringv1.Argument{Name: "storage.tsdb.min-block-duration", Value: string(tsdb.MinBlockDuration)})
+		}
+		if tsdb.MaxBlockDuration != "" {
+			prometheus.Spec.AdditionalArgs = append(prometheus.Spec.AdditionalArgs, monitoringv1.Argument{Name: "storage.tsdb.max-block-duration", Value: string(tsdb.MaxBlockDuration)})
+		}
+		prometheus.Spec.TSDB.OutOfOrderTimeWindow = tsdb.OutOfOrderTimeWindow
+	}
+
+	if mc.cfg.Monitor.Retention != "" {
+		prometheus.Spec.Retention = mc.cfg.Monitor.Retention
+	}
+
+	if interval := mc.cfg.Monitor.ScrapeInterval; interval != "" {
+		prometheus.Spec.ScrapeInterval = interval
+	}
+	if interval := mc.cfg.Monitor.EvaluationInterval; interval != "" {
+		prometheus.Spec.EvaluationInterval = interval
+	}
+
+	if timeout := mc.cfg.Monitor.QueryTimeout; timeout != "" {
+		prometheus.Spec.Query = &monitoringv1.QuerySpec{Timeout: &timeout}
+	}
+
+	if maxConns := mc.cfg.Monitor.PrometheusMaxConnections; maxConns != nil {
+		prometheus.Spec.AdditionalArgs = append(prometheus.Spec.AdditionalArgs, monitoringv1.Argument{Name: "web.max-connections", Value: fmt.Sprint(*maxConns)})
+	}
+
+	if size := mc.cfg.Monitor.StorageSize; size != nil {
+		prometheus.Spec.Storage = &monitoringv1.StorageSpec{
+			VolumeClaimTemplate: monitoringv1.EmbeddedPersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources:   corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: *size}},
+				},
+			},
+		}
+		if name := mc.cfg.Monitor.StorageClassName; name != "" {
+			prometheus.Spec.Storage.VolumeClaimTemplate.Spec.StorageClassName = &name
+		}
+	}
+
+	for _, rw := range mc.cfg.Monitor.RemoteWrite {
+		prometheus.Spec.RemoteWrite = append(prometheus.Spec.RemoteWrite, mc.remoteWriteSpec(rw))
+	}
+	for _, rr := range mc.cfg.Monitor.RemoteRead {
+		prometheus.Spec.RemoteRead = append(prometheus.Spec.RemoteRead, mc.remoteReadSpec(rr))
+	}
+
+	for _, feature := range mc.cfg.Monitor.PrometheusFeatures {
+		prometheus.Spec.EnableFeatures = append(prometheus.Spec.EnableFeatures, string(feature))
+	}
+
+	// The Prometheus operator serves the probes of the Prometheus container under the route prefix. The probes of the
+	// authn-proxy are served by the proxy itself, and are not affected.
+	if prefix := PrometheusRoutePrefix(mc.cfg.Monitor); prefix != "" {
+		prometheus.Spec.RoutePrefix = prefix
+	}
+
 	if overrides := mc.cfg.Monitor.Prometheus; overrides != nil {
 		rcomponents.ApplyPrometheusOverrides(prometheus, overrides)
 	}
@@ -609,6 +1054,121 @@ func (mc *monitorComponent) prometheus() *monitoringv1.Prometheus {
 	return prometheus
 }
 
+// remoteWriteSpec returns the Prometheus remote write configuration for rw, authenticating with the copies of its
+// Secrets in the tigera-prometheus namespace.
+func (mc *monitorComponent) remoteWriteSpec(rw operatorv1.PrometheusRemoteWrite) monitoringv1.RemoteWriteSpec {
+	spec := monitoringv1.RemoteWriteSpec{URL: rw.URL}
+	spec.BasicAuth, spec.TLSConfig = mc.remoteStorageAuth(rw.BasicAuthSecret, rw.TLSSecret)
+	return spec
+}
+
+// remoteReadSpec returns the Prometheus remote read configuration for rr, authenticating with the copies of its
+// Secrets in the tigera-prometheus namespace.
+func (mc *monitorComponent) remoteReadSpec(rr operatorv1.PrometheusRemoteRead) monitoringv1.RemoteReadSpec {
+	spec := monitoringv1.RemoteReadSpec{URL: rr.URL, ReadRecent: rr.ReadRecent}
+	spec.BasicAuth, spec.TLSConfig = mc.remoteStorageAuth(rr.BasicAuthSecret, rr.TLSSecret)
+	return spec
+}
+
+// remoteStorageAuth returns the basic auth and TLS configuration that read the credentials of a remote storage
+// endpoint from the referenced Secrets. The CA certificate is only configured if the TLS Secret holds one.
+func (mc *monitorComponent) remoteStorageAuth(basicAuthRef, tlsRef *corev1.LocalObjectReference) (*monitoringv1.BasicAuth, *monitoringv1.TLSConfig) {
+	var basicAuth *monitoringv1.BasicAuth
+	if basicAuthRef != nil {
+		basicAuth = &monitoringv1.BasicAuth{
+			Username: corev1.SecretKeySelector{LocalObjectReference: *basicAuthRef, Key: corev1.BasicAuthUsernameKey},
+			Password: corev1.SecretKeySelector{LocalObjectReference: *basicAuthRef, Key: corev1.BasicAuthPasswordKey},
+		}
+	}
+	var tlsConfig *monitoringv1.TLSConfig
+	if tlsRef != nil {
+		safeTLSConfig := monitoringv1.SafeTLSConfig{
+			Cert:      monitoringv1.SecretOrConfigMap{Secret: &corev1.SecretKeySelector{LocalObjectReference: *tlsRef, Key: corev1.TLSCertKey}},
+			KeySecret: &corev1.SecretKeySelector{LocalObjectReference: *tlsRef, Key: corev1.TLSPrivateKeyKey},
+		}
+		for _, s := range mc.cfg.RemoteStorageSecrets {
+			if _, ok := s.Data[RemoteStorageCAKey]; ok && s.Name == tlsRef.Name {
+				safeTLSConfig.CA = monitoringv1.SecretOrConfigMap{Secret: &corev1.SecretKeySelector{LocalObjectReference: *tlsRef, Key: RemoteStorageCAKey}}
+			}
+		}
+		tlsConfig = &monitoringv1.TLSConfig{SafeTLSConfig: safeTLSConfig}
+	}
+	return basicAuth, tlsConfig
+}
+
+// prometheusFederationSecret renders a scrape job for each federation source, reading the selected series from the
+// /federate path of the source.
+func (mc *monitorComponent) prometheusFederationSecret() *corev1.Secret {
+	var scrapeConfigs []map[string]interface{}
+	for _, source := range mc.cfg.Monitor.Federation {
+		// The endpoint has been validated by the controller.
+		u, _ := url.Parse(source.Endpoint)
+		scrapeConfigs = append(scrapeConfigs, map[string]interface{}{
+			"job_name":     fmt.Sprintf("federate-%s", source.Name),
+			"honor_labels": true,
+			"metrics_path": strings.TrimSuffix(u.Path, "/") + "/federate",
+			"params":       map[string][]string{"match[]": source.Match},
+			"scheme":       u.Scheme,
+			"static_configs": []map[string]interface{}{
+				{"targets": []string{net.JoinHostPort(u.Hostname(), strconv.Itoa(FederationEndpointPort(u)))}},
+			},
+		})
+	}
+	// Marshalling a slice of maps of basic types cannot fail.
+	b, _ := yaml.Marshal(scrapeConfigs)
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PrometheusFederationSecretName,
+			Namespace: common.TigeraPrometheusNamespace,
+		},
+		Data: map[string][]byte{prometheusFederationSecretKey: b},
+	}
+}
+
+// prometheusAlertmanagersSecret renders an Alertmanager config for each external Alertmanager, in the format of the
+// alerting.alertmanagers section of the Prometheus configuration.
+func (mc *monitorComponent) prometheusAlertmanagersSecret() *corev1.Secret {
+	var alertmanagerConfigs []map[string]interface{}
+	for _, endpoint := range mc.cfg.Monitor.ExternalAlertmanager {
+		// The endpoint has been validated by the controller.
+		u, _ := url.Parse(endpoint)
+		config := map[string]interface{}{
+			"scheme": u.Scheme,
+			"static_configs": []map[string]interface{}{
+				{"targets": []string{net.JoinHostPort(u.Hostname(), strconv.Itoa(FederationEndpointPort(u)))}},
+			},
+		}
+		if prefix := strings.TrimSuffix(u.Path, "/"); prefix != "" {
+			config["path_prefix"] = prefix
+		}
+		alertmanagerConfigs = append(alertmanagerConfigs, config)
+	}
+	// Marshalling a slice of maps of basic types cannot fail.
+	b, _ := yaml.Marshal(alertmanagerConfigs)
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PrometheusAlertmanagersSecretName,
+			Namespace: common.TigeraPrometheusNamespace,
+		},
+		Data: map[string][]byte{prometheusAlertmanagersSecretKey: b},
+	}
+}
+
+// FederationEndpointPort returns the port of a federation or external Alertmanager endpoint, defaulting to the port of its scheme.
+func FederationEndpointPort(u *url.URL) int {
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		return port
+	}
+	if u.Scheme == "http" {
+		return 80
+	}
+	return 443
+}
+
 func (mc *monitorComponent) prometheusServiceAccount() *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"},
@@ -835,6 +1395,59 @@ func (mc *monitorComponent) serviceMonitorCalicoNode() *monitoringv1.ServiceMoni
 	}
 }
 
+// withEndpointConfig applies the metric relabelings and scrape configuration of the Monitor to each endpoint of the
+// given ServiceMonitor.
+func (mc *monitorComponent) withEndpointConfig(sm *monitoringv1.ServiceMonitor) *monitoringv1.ServiceMonitor {
+	interval, timeout := ScrapeIntervalAndTimeout(mc.cfg.Monitor.ScrapeConfig, sm.Name)
+	for i := range sm.Spec.Endpoints {
+		sm.Spec.Endpoints[i].MetricRelabelConfigs = append(sm.Spec.Endpoints[i].MetricRelabelConfigs, mc.cfg.Monitor.MetricRelabelConfigs...)
+		sm.Spec.Endpoints[i].Interval = interval
+		sm.Spec.Endpoints[i].ScrapeTimeout = timeout
+	}
+	return sm
+}
+
+// ScrapeIntervalAndTimeout returns the scrape interval and timeout of the operator managed ServiceMonitor with the
+// given name. Settings for the ServiceMonitor take precedence over the global settings, which in turn take
+// precedence over the defaults.
+func ScrapeIntervalAndTimeout(cfg *operatorv1.ScrapeConfig, name string) (monitoringv1.Duration, monitoringv1.Duration) {
+	interval, timeout := monitoringv1.Duration(defaultScrapeInterval), monitoringv1.Duration(defaultScrapeTimeout)
+	if cfg == nil {
+		return interval, timeout
+	}
+	if cfg.Interval != "" {
+		interval = cfg.Interval
+	}
+	if cfg.ScrapeTimeout != "" {
+		timeout = cfg.ScrapeTimeout
+	}
+	for _, sm := range cfg.ServiceMonitors {
+		if sm.Name != name {
+			continue
+		}
+		if sm.Interval != "" {
+			interval = sm.Interval
+		}
+		if sm.ScrapeTimeout != "" {
+			timeout = sm.ScrapeTimeout
+		}
+	}
+	return interval, timeout
+}
+
+// ManagedServiceMonitorNames returns the names of the ServiceMonitors that the operator creates in the
+// tigera-prometheus namespace.
+func ManagedServiceMonitorNames() []string {
+	return []string{
+		CalicoNodeMonitor,
+		ElasticsearchMetrics,
+		render.FluentdMetricsService,
+		render.QueryserverServiceName,
+		KubeControllerMetrics,
+		render.TyphaMetricsName,
+	}
+}
+
 func (mc *monitorComponent) tlsConfig(serverName string) *monitoringv1.TLSConfig {
 	return &monitoringv1.TLSConfig{
 		KeyFile:  mc.cfg.ClientTLSSecret.VolumeMountKeyFilePath(),
@@ -1003,6 +1616,11 @@ func allowTigeraAlertManagerPolicy(cfg *Config) *v3.NetworkPolicy {
 		Protocol: &networkpolicy.TCPProtocol,
 	})
 
+	ingressPorts := []uint16{AlertmanagerPort}
+	if cfg.Monitor.AlertmanagerExternalAccess != nil {
+		ingressPorts = append(ingressPorts, AlertmanagerProxyPort)
+	}
+
 	return &v3.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "projectcalico.org/v3"},
 		ObjectMeta: metav1.ObjectMeta{
@@ -1019,7 +1637,7 @@ func allowTigeraAlertManagerPolicy(cfg *Config) *v3.NetworkPolicy {
 					Action:   v3.Allow,
 					Protocol: &networkpolicy.TCPProtocol,
 					Destination: v3.EntityRule{
-						Ports: networkpolicy.Ports(AlertmanagerPort),
+						Ports: networkpolicy.Ports(ingressPorts...),
 					},
 				},
 			},
@@ -1144,6 +1762,38 @@ func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
 		})
 	}
 
+	for _, source := range cfg.Monitor.Federation {
+		u, err := url.Parse(source.Endpoint)
+		if err != nil {
+			continue
+		}
+		// Egress access to the federation source, which may be outside of the cluster.
+		destination := v3.EntityRule{Ports: networkpolicy.Ports(uint16(FederationEndpointPort(u)))}
+		if ip := net.ParseIP(u.Hostname()); ip == nil {
+			destination.Domains = []string{u.Hostname()}
+		} else if ip.To4() != nil {
+			destination.Nets = []string{ip.String() + "/32"}
+		} else {
+			destination.Nets = []string{ip.String() + "/128"}
+		}
+		egressRules = append(egressRules, v3.Rule{
+			Action:      v3.Allow,
+			Protocol:    &networkpolicy.TCPProtocol,
+			Destination: destination,
+		})
+	}
+
+	for _, port := range additionalServiceMonitorPorts(cfg.Monitor.AdditionalServiceMonitors) {
+		egressRules = append(egressRules, v3.Rule{
+			Action:   v3.Allow,
+			Protocol: &networkpolicy.TCPProtocol,
+			Destination: v3.EntityRule{
+				// Egress access to the targets of the additional ServiceMonitors.
+				Ports: networkpolicy.Ports(port),
+			},
+		})
+	}
+
 	typhaMetricsPort := cfg.Installation.TyphaMetricsPort
 	if typhaMetricsPort != nil {
 		egressRules = append(egressRules, v3.Rule{
@@ -1182,6 +1832,22 @@ func allowTigeraPrometheusPolicy(cfg *Config) *v3.NetworkPolicy {
 	}
 }
 
+// additionalServiceMonitorPorts returns the distinct target ports of the additional ServiceMonitors, in the order in
+// which they are first configured.
+func additionalServiceMonitorPorts(asms []operatorv1.AdditionalServiceMonitor) []uint16 {
+	var ports []uint16
+	seen := map[uint16]bool{}
+	for _, asm := range asms {
+		for _, ep := range asm.Endpoints {
+			if port := uint16(ep.Port); !seen[port] {
+				seen[port] = true
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
+
 // Creates a network policy to allow traffic to access through tigera-prometheus-api
 func allowTigeraPrometheusAPIPolicy(cfg *Config) *v3.NetworkPolicy {
 	egressRules := []v3.Rule{}
@@ -1366,7 +2032,7 @@ func (mc *monitorComponent) externalServiceMonitor() (client.Object, bool) {
 	for i, ep := range mc.cfg.Monitor.ExternalPrometheus.ServiceMonitor.Endpoints {
 		endpoints[i] = monitoringv1.Endpoint{
 			Port:          "web",
-			Path:          "/federate",
+			Path:          PrometheusRoutePrefix(mc.cfg.Monitor) + "/federate",
 			Scheme:        "https",
 			Params:        ep.Params,
 			Interval:      ep.Interval,
@@ -1421,7 +2087,42 @@ func (mc *monitorComponent) externalServiceMonitor() (client.Object, bool) {
 	}, needsRBAC
 }
 
-func (mc *monitorComponent) typhaServiceMonitor() client.Object {
+// additionalServiceMonitor returns the ServiceMonitor for a user defined entry of Monitor.AdditionalServiceMonitors.
+// It is labeled so that Prometheus selects it, and so that it can be told apart from the operator's own.
+func (mc *monitorComponent) additionalServiceMonitor(asm operatorv1.AdditionalServiceMonitor) *monitoringv1.ServiceMonitor {
+	endpoints := make([]monitoringv1.Endpoint, len(asm.Endpoints))
+	for i, ep := range asm.Endpoints {
+		targetPort := intstr.FromInt(int(ep.Port))
+		endpoints[i] = monitoringv1.Endpoint{
+			TargetPort:           &targetPort,
+			Path:                 ep.Path,
+			Scheme:               "http",
+			Interval:             ep.Interval,
+			ScrapeTimeout:        ep.ScrapeTimeout,
+			HonorLabels:          ep.HonorLabels,
+			MetricRelabelConfigs: ep.MetricRelabelConfigs,
+			RelabelConfigs:       ep.RelabelConfigs,
+		}
+	}
+	return &monitoringv1.ServiceMonitor{
+		TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: MonitoringAPIVersion},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      asm.Name,
+			Namespace: common.TigeraPrometheusNamespace,
+			Labels: map[string]string{
+				"team":                        "network-operators",
+				AdditionalServiceMonitorLabel: "true",
+			},
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector:          *asm.Selector.DeepCopy(),
+			NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: asm.Namespaces},
+			Endpoints:         endpoints,
+		},
+	}
+}
+
+func (mc *monitorComponent) typhaServiceMonitor() *monitoringv1.ServiceMonitor {
 	return &monitoringv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: MonitoringAPIVersion},
 		ObjectMeta: metav1.ObjectMeta{
@@ -16,14 +16,17 @@ package monitor_test
 
 import (
 	"fmt"
+	"reflect"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"gopkg.in/yaml.v2"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +46,8 @@ import (
 	"github.com/tigera/operator/pkg/ptr"
 	"github.com/tigera/operator/pkg/render"
 	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+	"github.com/tigera/operator/pkg/render/common/networkpolicy"
+	"github.com/tigera/operator/pkg/render/common/podaffinity"
 	rtest "github.com/tigera/operator/pkg/render/common/test"
 	"github.com/tigera/operator/pkg/render/monitor"
 	"github.com/tigera/operator/pkg/render/testutils"
@@ -119,7 +124,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 
 		// Check the namespace.
 		namespace := rtest.GetResource(toCreate, "tigera-prometheus", "", "", "v1", "Namespace").(*corev1.Namespace)
@@ -175,7 +180,7 @@ var _ = Describe("monitor rendering tests", func() {
 		component := monitor.Monitor(cfg)
 		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
 		toCreate, toDelete := component.Objects()
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 
 		// Prometheus
 		prometheusObj, ok := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
@@ -194,6 +199,33 @@ var _ = Describe("monitor rendering tests", func() {
 
 	})
 
+	It("Should keep the default Prometheus and Alertmanager resources when they are not overridden", func() {
+		defaultPrometheusResources := corev1.ResourceRequirements{Requests: corev1.ResourceList{"memory": k8sresource.MustParse("400Mi")}}
+		authnProxyResources := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{"cpu": k8sresource.MustParse("100m")},
+		}
+
+		for _, overrides := range []*operatorv1.Prometheus{
+			nil,
+			{PrometheusSpec: &operatorv1.PrometheusSpec{}},
+			{PrometheusSpec: &operatorv1.PrometheusSpec{CommonPrometheusFields: &operatorv1.CommonPrometheusFields{
+				Containers: []operatorv1.PrometheusContainer{{Name: "authn-proxy", Resources: &authnProxyResources}},
+			}}},
+		} {
+			cfg.Monitor.Prometheus = overrides
+			cfg.Monitor.AlertManager = &operatorv1.AlertManager{AlertManagerSpec: &operatorv1.AlertManagerSpec{}}
+			component := monitor.Monitor(cfg)
+			Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+			toCreate, _ := component.Objects()
+
+			prometheusObj := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+			Expect(prometheusObj.Spec.CommonPrometheusFields.Resources).To(Equal(defaultPrometheusResources))
+
+			alertmanagerObj := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+			Expect(alertmanagerObj.Spec.Resources).To(Equal(corev1.ResourceRequirements{}))
+		}
+	})
+
 	It("Should render Prometheus resource Specs correctly", func() {
 		component := monitor.Monitor(cfg)
 		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
@@ -647,7 +679,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 
 		// Prometheus
 		prometheusObj, ok := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
@@ -811,6 +843,69 @@ var _ = Describe("monitor rendering tests", func() {
 
 			Expect(len(zeroedPolicy.Spec.Egress)).To(Equal(len(baselinePolicy.Spec.Egress) - 1))
 		})
+
+		It("should remove the alertmanager mesh policy when it is disabled", func() {
+			disabled := operatorv1.AlertmanagerMeshPolicyDisabled
+			cfg.Monitor.AlertmanagerMeshPolicy = &disabled
+			toCreate, toDelete := monitor.MonitorPolicy(cfg).Objects()
+
+			meshPolicy := types.NamespacedName{Name: monitor.MeshAlertManagerPolicyName, Namespace: common.TigeraPrometheusNamespace}
+			Expect(testutils.GetAllowTigeraPolicyFromResources(meshPolicy, toCreate)).To(BeNil())
+			Expect(testutils.GetAllowTigeraPolicyFromResources(meshPolicy, toDelete)).NotTo(BeNil())
+			Expect(testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: monitor.AlertManagerPolicyName, Namespace: common.TigeraPrometheusNamespace}, toCreate)).NotTo(BeNil())
+		})
+
+		It("prometheus policy should allow egress to the federation sources", func() {
+			cfg.Monitor.Federation = []operatorv1.PrometheusFederationSource{
+				{Name: "spoke", Endpoint: "https://prometheus.spoke.example.com:9443", Match: []string{"up"}},
+				{Name: "edge", Endpoint: "http://10.0.0.5:9090", Match: []string{"up"}},
+				{Name: "lab", Endpoint: "https://[fd00::5]", Match: []string{"up"}},
+			}
+			component := monitor.MonitorPolicy(cfg)
+			resourcesToCreate, _ := component.Objects()
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: "allow-tigera.prometheus", Namespace: "tigera-prometheus"}, resourcesToCreate)
+
+			Expect(policy.Spec.Egress).To(ContainElements(
+				v3.Rule{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Domains: []string{"prometheus.spoke.example.com"}, Ports: networkpolicy.Ports(9443)},
+				},
+				v3.Rule{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Nets: []string{"10.0.0.5/32"}, Ports: networkpolicy.Ports(9090)},
+				},
+				v3.Rule{
+					Action:      v3.Allow,
+					Protocol:    &networkpolicy.TCPProtocol,
+					Destination: v3.EntityRule{Nets: []string{"fd00::5/128"}, Ports: networkpolicy.Ports(443)},
+				},
+			))
+		})
+
+		It("prometheus policy should allow egress to the additional service monitor targets", func() {
+			cfg.Monitor.AdditionalServiceMonitors = []operatorv1.AdditionalServiceMonitor{
+				{Name: "sidecar", Namespaces: []string{"apps"}, Endpoints: []operatorv1.AdditionalServiceMonitorEndpoint{{Port: 9102}, {Port: 9102}}},
+			}
+			component := monitor.MonitorPolicy(cfg)
+			resourcesToCreate, _ := component.Objects()
+			policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: "allow-tigera.prometheus", Namespace: "tigera-prometheus"}, resourcesToCreate)
+
+			rule := v3.Rule{
+				Action:      v3.Allow,
+				Protocol:    &networkpolicy.TCPProtocol,
+				Destination: v3.EntityRule{Ports: networkpolicy.Ports(9102)},
+			}
+			Expect(policy.Spec.Egress).To(ContainElement(rule))
+			count := 0
+			for _, r := range policy.Spec.Egress {
+				if reflect.DeepEqual(r, rule) {
+					count++
+				}
+			}
+			Expect(count).To(Equal(1))
+		})
 	})
 
 	It("Should render external prometheus resources with service monitor", func() {
@@ -844,7 +939,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 	})
 	It("Should render external prometheus resources with service monitor and custom token", func() {
 		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
@@ -873,7 +968,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 	})
 	It("Should render external prometheus resources without service monitor", func() {
 		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
@@ -892,7 +987,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
-		Expect(toDelete).To(HaveLen(3))
+		Expect(toDelete).To(HaveLen(8))
 	})
 	It("Should render typha service monitor if typha metrics are enabled", func() {
 		cfg.Installation.TyphaMetricsPort = ptr.Int32ToPtr(9093)
@@ -909,7 +1004,7 @@ var _ = Describe("monitor rendering tests", func() {
 			rtest.ExpectResourceTypeAndObjectMetadata(obj, expectedRes.name, expectedRes.ns, expectedRes.group, expectedRes.version, expectedRes.kind)
 		}
 		Expect(toCreate).To(HaveLen(len(expectedResources)))
-		Expect(toDelete).To(HaveLen(2))
+		Expect(toDelete).To(HaveLen(7))
 		sm := rtest.GetResource(toCreate, "calico-typha-metrics", "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor").(*monitoringv1.ServiceMonitor)
 		Expect(sm).To(Equal(&monitoringv1.ServiceMonitor{
 			TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: "monitoring.coreos.com/v1"},
@@ -939,6 +1034,692 @@ var _ = Describe("monitor rendering tests", func() {
 			},
 		}))
 	})
+
+	It("Should apply the scrape configuration to the operator managed service monitors", func() {
+		cfg.Installation.TyphaMetricsPort = ptr.Int32ToPtr(9093)
+		cfg.Monitor.ScrapeConfig = &operatorv1.ScrapeConfig{
+			Interval:      "30s",
+			ScrapeTimeout: "20s",
+			ServiceMonitors: []operatorv1.ServiceMonitorScrapeConfig{
+				{Name: monitor.ElasticsearchMetrics, ScrapeTimeout: "25s"},
+				{Name: monitor.CalicoNodeMonitor, Interval: "1m", ScrapeTimeout: "45s"},
+			},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		expected := map[string][2]monitoringv1.Duration{
+			monitor.CalicoNodeMonitor:     {"1m", "45s"},
+			monitor.ElasticsearchMetrics:  {"30s", "25s"},
+			render.FluentdMetricsService:  {"30s", "20s"},
+			render.QueryserverServiceName: {"30s", "20s"},
+			monitor.KubeControllerMetrics: {"30s", "20s"},
+			render.TyphaMetricsName:       {"30s", "20s"},
+		}
+		for name, durations := range expected {
+			sm := rtest.GetResource(toCreate, name, "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor").(*monitoringv1.ServiceMonitor)
+			Expect(sm.Spec.Endpoints).NotTo(BeEmpty())
+			for _, ep := range sm.Spec.Endpoints {
+				Expect(ep.Interval).To(Equal(durations[0]), "service monitor %s", name)
+				Expect(ep.ScrapeTimeout).To(Equal(durations[1]), "service monitor %s", name)
+			}
+		}
+	})
+
+	It("Should render a federation scrape config for each federation source", func() {
+		cfg.Monitor.Federation = []operatorv1.PrometheusFederationSource{
+			{Name: "spoke-1", Endpoint: "https://prometheus.spoke-1.example.com:9090", Match: []string{`{job="calico-node-metrics"}`}},
+			{Name: "spoke-2", Endpoint: "http://10.0.0.1/prometheus/", Match: []string{`{__name__=~"felix_.*"}`, "up"}},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+		Expect(rtest.GetResource(toDelete, monitor.PrometheusFederationSecretName, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.AdditionalScrapeConfigs).To(Equal(&corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: monitor.PrometheusFederationSecretName},
+			Key:                  "federation.yaml",
+		}))
+
+		secret := rtest.GetResource(toCreate, monitor.PrometheusFederationSecretName, "tigera-prometheus", "", "v1", "Secret").(*corev1.Secret)
+		type scrapeConfig struct {
+			JobName       string              `yaml:"job_name"`
+			HonorLabels   bool                `yaml:"honor_labels"`
+			MetricsPath   string              `yaml:"metrics_path"`
+			Params        map[string][]string `yaml:"params"`
+			Scheme        string              `yaml:"scheme"`
+			StaticConfigs []struct {
+				Targets []string `yaml:"targets"`
+			} `yaml:"static_configs"`
+		}
+		var scrapeConfigs []scrapeConfig
+		Expect(yaml.Unmarshal(secret.Data["federation.yaml"], &scrapeConfigs)).To(Succeed())
+		Expect(scrapeConfigs).To(HaveLen(2))
+
+		Expect(scrapeConfigs[0].JobName).To(Equal("federate-spoke-1"))
+		Expect(scrapeConfigs[0].HonorLabels).To(BeTrue())
+		Expect(scrapeConfigs[0].MetricsPath).To(Equal("/federate"))
+		Expect(scrapeConfigs[0].Params).To(Equal(map[string][]string{"match[]": {`{job="calico-node-metrics"}`}}))
+		Expect(scrapeConfigs[0].Scheme).To(Equal("https"))
+		Expect(scrapeConfigs[0].StaticConfigs).To(HaveLen(1))
+		Expect(scrapeConfigs[0].StaticConfigs[0].Targets).To(Equal([]string{"prometheus.spoke-1.example.com:9090"}))
+
+		Expect(scrapeConfigs[1].JobName).To(Equal("federate-spoke-2"))
+		Expect(scrapeConfigs[1].MetricsPath).To(Equal("/prometheus/federate"))
+		Expect(scrapeConfigs[1].Params).To(Equal(map[string][]string{"match[]": {`{__name__=~"felix_.*"}`, "up"}}))
+		Expect(scrapeConfigs[1].Scheme).To(Equal("http"))
+		Expect(scrapeConfigs[1].StaticConfigs[0].Targets).To(Equal([]string{"10.0.0.1:80"}))
+	})
+
+	It("Should not render a federation scrape config by default", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.AdditionalScrapeConfigs).To(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.PrometheusFederationSecretName, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.PrometheusFederationSecretName, "tigera-prometheus", "", "v1", "Secret")).NotTo(BeNil())
+	})
+
+	It("Should point Prometheus at external Alertmanagers instead of rendering Alertmanager", func() {
+		cfg.Monitor.ExternalAlertmanager = []string{"https://alertmanager.example.com:9443", "http://10.0.0.2/alertmanager/"}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind)).To(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "", "v1", "Service")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "", "v1", "Service")).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.AlertmanagerStatefulSetName, "tigera-prometheus", "apps", "v1", "StatefulSet")).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.PrometheusAlertmanagersSecretName, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Alerting).To(BeNil())
+		Expect(prometheus.Spec.AdditionalAlertManagerConfigs).To(Equal(&corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: monitor.PrometheusAlertmanagersSecretName},
+			Key:                  "alertmanagers.yaml",
+		}))
+
+		secret := rtest.GetResource(toCreate, monitor.PrometheusAlertmanagersSecretName, "tigera-prometheus", "", "v1", "Secret").(*corev1.Secret)
+		type alertmanagerConfig struct {
+			Scheme        string `yaml:"scheme"`
+			PathPrefix    string `yaml:"path_prefix"`
+			StaticConfigs []struct {
+				Targets []string `yaml:"targets"`
+			} `yaml:"static_configs"`
+		}
+		var alertmanagerConfigs []alertmanagerConfig
+		Expect(yaml.Unmarshal(secret.Data["alertmanagers.yaml"], &alertmanagerConfigs)).To(Succeed())
+		Expect(alertmanagerConfigs).To(HaveLen(2))
+
+		Expect(alertmanagerConfigs[0].Scheme).To(Equal("https"))
+		Expect(alertmanagerConfigs[0].PathPrefix).To(BeEmpty())
+		Expect(alertmanagerConfigs[0].StaticConfigs).To(HaveLen(1))
+		Expect(alertmanagerConfigs[0].StaticConfigs[0].Targets).To(Equal([]string{"alertmanager.example.com:9443"}))
+
+		Expect(alertmanagerConfigs[1].Scheme).To(Equal("http"))
+		Expect(alertmanagerConfigs[1].PathPrefix).To(Equal("/alertmanager"))
+		Expect(alertmanagerConfigs[1].StaticConfigs[0].Targets).To(Equal([]string{"10.0.0.2:80"}))
+	})
+
+	It("Should render Prometheus without Alertmanager when Alertmanager is disabled", func() {
+		cfg.Monitor.AlertmanagerMode = ptr.ToPtr(operatorv1.AlertmanagerModeDisabled)
+		cfg.AlertmanagerConfigSecret = nil
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind)).To(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "", "v1", "Service")).To(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.AlertmanagerConfigSecret, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.PrometheusAlertmanagersSecretName, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "", "v1", "Service")).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.AlertmanagerStatefulSetName, "tigera-prometheus", "apps", "v1", "StatefulSet")).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.AlertmanagerConfigSecret, "tigera-prometheus", "", "v1", "Secret")).NotTo(BeNil())
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Alerting).To(BeNil())
+		Expect(prometheus.Spec.AdditionalAlertManagerConfigs).To(BeNil())
+	})
+
+	It("Should not render an Alertmanager PodDisruptionBudget when external Alertmanagers are used", func() {
+		cfg.Monitor.ExternalAlertmanager = []string{"https://alertmanager.example.com"}
+		cfg.Monitor.AlertmanagerReplicas = ptr.Int32ToPtr(3)
+		cfg.Monitor.AlertManager = &operatorv1.AlertManager{AlertManagerSpec: &operatorv1.AlertManagerSpec{
+			PodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &intstr.IntOrString{IntVal: 1}},
+		}}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "policy", "v1", "PodDisruptionBudget")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "policy", "v1", "PodDisruptionBudget")).NotTo(BeNil())
+	})
+
+	It("Should render the bundled Alertmanager by default", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind)).NotTo(BeNil())
+		Expect(rtest.GetResource(toCreate, monitor.PrometheusAlertmanagersSecretName, "tigera-prometheus", "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.PrometheusAlertmanagersSecretName, "tigera-prometheus", "", "v1", "Secret")).NotTo(BeNil())
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, "tigera-prometheus", "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Alerting).NotTo(BeNil())
+		Expect(prometheus.Spec.AdditionalAlertManagerConfigs).To(BeNil())
+	})
+
+	It("Should render the TSDB block duration flags only when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.AdditionalArgs).To(BeEmpty())
+
+		cfg.Monitor.TSDB = &operatorv1.PrometheusTSDB{MinBlockDuration: "1h", MaxBlockDuration: "6h"}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.AdditionalArgs).To(ConsistOf(
+			monitoringv1.Argument{Name: "storage.tsdb.min-block-duration", Value: "1h"},
+			monitoringv1.Argument{Name: "storage.tsdb.max-block-duration", Value: "6h"},
+		))
+	})
+
+	It("Should render the TSDB out-of-order time window only when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.TSDB.OutOfOrderTimeWindow).To(BeEmpty())
+
+		cfg.Monitor.TSDB = &operatorv1.PrometheusTSDB{OutOfOrderTimeWindow: "30m"}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.TSDB.OutOfOrderTimeWindow).To(Equal(monitoringv1.Duration("30m")))
+		Expect(prometheus.Spec.AdditionalArgs).To(BeEmpty())
+	})
+
+	It("Should render the configured Prometheus retention and storage size", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Retention).To(Equal(monitoringv1.Duration("24h")))
+		Expect(prometheus.Spec.Storage).To(BeNil())
+
+		size := k8sresource.MustParse("50Gi")
+		cfg.Monitor.Retention = "30d"
+		cfg.Monitor.StorageSize = &size
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Retention).To(Equal(monitoringv1.Duration("30d")))
+		Expect(prometheus.Spec.Storage).NotTo(BeNil())
+		claim := prometheus.Spec.Storage.VolumeClaimTemplate.Spec
+		Expect(claim.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+		Expect(claim.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceStorage, size))
+		Expect(claim.StorageClassName).To(BeNil())
+
+		cfg.Monitor.StorageClassName = "fast-ssd"
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Storage.VolumeClaimTemplate.Spec.StorageClassName).To(Equal(ptr.ToPtr("fast-ssd")))
+	})
+
+	It("Should render the query timeout only when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Query).To(BeNil())
+
+		cfg.Monitor.QueryTimeout = "5m"
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Query).NotTo(BeNil())
+		Expect(*prometheus.Spec.Query.Timeout).To(Equal(monitoringv1.Duration("5m")))
+	})
+
+	It("Should render the scrape and evaluation intervals only when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.ScrapeInterval).To(BeEmpty())
+		Expect(prometheus.Spec.EvaluationInterval).To(BeEmpty())
+
+		cfg.Monitor.ScrapeInterval = "10s"
+		cfg.Monitor.EvaluationInterval = "15s"
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.ScrapeInterval).To(Equal(monitoringv1.Duration("10s")))
+		Expect(prometheus.Spec.EvaluationInterval).To(Equal(monitoringv1.Duration("15s")))
+	})
+
+	It("Should render the max web connections only when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		for _, arg := range prometheus.Spec.AdditionalArgs {
+			Expect(arg.Name).NotTo(Equal("web.max-connections"))
+		}
+
+		maxConns := int32(2048)
+		cfg.Monitor.PrometheusMaxConnections = &maxConns
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.AdditionalArgs).To(ContainElement(monitoringv1.Argument{Name: "web.max-connections", Value: "2048"}))
+	})
+
+	It("Should render the configured remote write endpoints", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RemoteWrite).To(BeEmpty())
+
+		cfg.Monitor.RemoteWrite = []operatorv1.PrometheusRemoteWrite{
+			{URL: "https://thanos.example.com/api/v1/receive", BasicAuthSecret: &corev1.LocalObjectReference{Name: "thanos-auth"}},
+			{URL: "https://cortex.example.com/api/v1/push", TLSSecret: &corev1.LocalObjectReference{Name: "cortex-tls"}},
+			{URL: "https://mimir.example.com/api/v1/push", TLSSecret: &corev1.LocalObjectReference{Name: "mimir-tls"}},
+		}
+		cfg.RemoteStorageSecrets = []*corev1.Secret{
+			{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "thanos-auth", Namespace: common.OperatorNamespace()}, Data: map[string][]byte{"username": []byte("u"), "password": []byte("p")}},
+			{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "cortex-tls", Namespace: common.OperatorNamespace()}, Data: map[string][]byte{"tls.crt": []byte("c"), "tls.key": []byte("k"), "ca.crt": []byte("ca")}},
+			{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "mimir-tls", Namespace: common.OperatorNamespace()}, Data: map[string][]byte{"tls.crt": []byte("c"), "tls.key": []byte("k")}},
+		}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		for _, name := range []string{"thanos-auth", "cortex-tls", "mimir-tls"} {
+			secret := rtest.GetResource(toCreate, name, common.TigeraPrometheusNamespace, "", "v1", "Secret").(*corev1.Secret)
+			Expect(secret.Labels).To(HaveKeyWithValue(monitor.RemoteStorageSecretLabel, "true"))
+		}
+
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RemoteWrite).To(HaveLen(3))
+
+		thanos := prometheus.Spec.RemoteWrite[0]
+		Expect(thanos.URL).To(Equal("https://thanos.example.com/api/v1/receive"))
+		Expect(thanos.BasicAuth).To(Equal(&monitoringv1.BasicAuth{
+			Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-auth"}, Key: "username"},
+			Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-auth"}, Key: "password"},
+		}))
+		Expect(thanos.TLSConfig).To(BeNil())
+
+		cortex := prometheus.Spec.RemoteWrite[1]
+		Expect(cortex.BasicAuth).To(BeNil())
+		Expect(cortex.TLSConfig.Cert.Secret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "tls.crt"}))
+		Expect(cortex.TLSConfig.KeySecret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "tls.key"}))
+		Expect(cortex.TLSConfig.CA.Secret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "ca.crt"}))
+
+		By("not configuring a CA when the TLS secret does not hold one")
+		Expect(prometheus.Spec.RemoteWrite[2].TLSConfig.CA.Secret).To(BeNil())
+	})
+
+	It("Should delete the copies of remote storage secrets that are no longer referenced", func() {
+		cfg.StaleRemoteStorageSecrets = []string{"old-thanos-auth"}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+		Expect(rtest.GetResource(toCreate, "old-thanos-auth", common.TigeraPrometheusNamespace, "", "v1", "Secret")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, "old-thanos-auth", common.TigeraPrometheusNamespace, "", "v1", "Secret")).NotTo(BeNil())
+	})
+
+	It("Should render the configured remote read endpoints", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RemoteRead).To(BeEmpty())
+
+		cfg.Monitor.RemoteRead = []operatorv1.PrometheusRemoteRead{
+			{URL: "https://thanos.example.com/api/v1/read", ReadRecent: true, BasicAuthSecret: &corev1.LocalObjectReference{Name: "thanos-auth"}},
+			{URL: "https://cortex.example.com/api/v1/read", TLSSecret: &corev1.LocalObjectReference{Name: "cortex-tls"}},
+		}
+		cfg.RemoteStorageSecrets = []*corev1.Secret{
+			{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "thanos-auth", Namespace: common.OperatorNamespace()}, Data: map[string][]byte{"username": []byte("u"), "password": []byte("p")}},
+			{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "cortex-tls", Namespace: common.OperatorNamespace()}, Data: map[string][]byte{"tls.crt": []byte("c"), "tls.key": []byte("k"), "ca.crt": []byte("ca")}},
+		}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		for _, name := range []string{"thanos-auth", "cortex-tls"} {
+			Expect(rtest.GetResource(toCreate, name, common.TigeraPrometheusNamespace, "", "v1", "Secret")).NotTo(BeNil())
+		}
+
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RemoteRead).To(HaveLen(2))
+
+		thanos := prometheus.Spec.RemoteRead[0]
+		Expect(thanos.URL).To(Equal("https://thanos.example.com/api/v1/read"))
+		Expect(thanos.ReadRecent).To(BeTrue())
+		Expect(thanos.BasicAuth).To(Equal(&monitoringv1.BasicAuth{
+			Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-auth"}, Key: "username"},
+			Password: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "thanos-auth"}, Key: "password"},
+		}))
+		Expect(thanos.TLSConfig).To(BeNil())
+
+		cortex := prometheus.Spec.RemoteRead[1]
+		Expect(cortex.URL).To(Equal("https://cortex.example.com/api/v1/read"))
+		Expect(cortex.ReadRecent).To(BeFalse())
+		Expect(cortex.BasicAuth).To(BeNil())
+		Expect(cortex.TLSConfig.Cert.Secret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "tls.crt"}))
+		Expect(cortex.TLSConfig.KeySecret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "tls.key"}))
+		Expect(cortex.TLSConfig.CA.Secret).To(Equal(&corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cortex-tls"}, Key: "ca.crt"}))
+	})
+
+	It("Should enable the configured Prometheus features", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.EnableFeatures).To(BeEmpty())
+
+		cfg.Monitor.PrometheusFeatures = []operatorv1.PrometheusFeature{operatorv1.PrometheusFeatureExemplarStorage, operatorv1.PrometheusFeatureNativeHistograms}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.EnableFeatures).To(Equal([]string{"exemplar-storage", "native-histograms"}))
+	})
+
+	It("Should serve Prometheus under the route prefix when configured", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RoutePrefix).To(BeEmpty())
+
+		cfg.Monitor.RoutePrefix = "/prometheus/"
+		cfg.Monitor.ExternalPrometheus = &operatorv1.ExternalPrometheus{
+			ServiceMonitor: &operatorv1.ServiceMonitor{
+				Endpoints: []operatorv1.Endpoint{{}},
+			},
+			Namespace: "external-prometheus",
+		}
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.RoutePrefix).To(Equal("/prometheus"))
+
+		// The authn-proxy serves its own health endpoint, and forwards requests to Prometheus unchanged.
+		Expect(prometheus.Spec.Containers[0].ReadinessProbe.HTTPGet.Path).To(Equal("/health"))
+		Expect(prometheus.Spec.Containers[0].LivenessProbe.HTTPGet.Path).To(Equal("/health"))
+
+		sm := rtest.GetResource(toCreate, monitor.TigeraExternalPrometheus, "external-prometheus", "monitoring.coreos.com", "v1", monitoringv1.ServiceMonitorsKind).(*monitoringv1.ServiceMonitor)
+		Expect(sm.Spec.Endpoints).To(HaveLen(1))
+		Expect(sm.Spec.Endpoints[0].Path).To(Equal("/prometheus/federate"))
+	})
+
+	It("Should render the auth-proxied Alertmanager exposure when external access is configured", func() {
+		authentication := &operatorv1.Authentication{
+			Spec: operatorv1.AuthenticationSpec{
+				ManagerDomain: "https://127.0.0.1",
+				OIDC:          &operatorv1.AuthenticationOIDC{IssuerURL: "https://accounts.google.com", UsernameClaim: "email"},
+			},
+		}
+		cfg.KeyValidatorConfig = render.NewDexKeyValidatorConfig(authentication, nil, dns.DefaultClusterDomain)
+		cfg.ServerTLSSecret = prometheusKeyPair
+		cfg.Monitor.AlertmanagerExternalAccess = &operatorv1.AlertmanagerExternalAccess{ServiceType: corev1.ServiceTypeLoadBalancer}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		svc := rtest.GetResource(toCreate, monitor.AlertmanagerExternalServiceName, common.TigeraPrometheusNamespace, "", "v1", "Service").(*corev1.Service)
+		Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+		Expect(svc.Spec.Ports).To(Equal([]corev1.ServicePort{
+			{Name: "web", Port: 9093, Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromInt(9096)},
+		}))
+		Expect(svc.Spec.Selector).To(Equal(map[string]string{"alertmanager": monitor.CalicoNodeAlertmanager}))
+		Expect(rtest.GetResource(toDelete, monitor.AlertmanagerExternalServiceName, common.TigeraPrometheusNamespace, "", "v1", "Service")).To(BeNil())
+
+		am := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(am.Spec.Containers).To(HaveLen(1))
+		proxy := am.Spec.Containers[0]
+		Expect(proxy.Name).To(Equal("authn-proxy"))
+		Expect(proxy.Ports).To(Equal([]corev1.ContainerPort{{ContainerPort: 9096}}))
+		Expect(proxy.Env).To(ContainElements(
+			corev1.EnvVar{Name: "PROMETHEUS_ENDPOINT_URL", Value: "http://localhost:9093"},
+			corev1.EnvVar{Name: "LISTEN_ADDR", Value: ":9096"},
+			corev1.EnvVar{Name: "TLS_KEY", Value: "/calico-node-prometheus-tls/tls.key"},
+			corev1.EnvVar{Name: "TLS_CERT", Value: "/calico-node-prometheus-tls/tls.crt"},
+		))
+		Expect(proxy.Env).To(ContainElements(cfg.KeyValidatorConfig.RequiredEnv("")))
+		Expect(proxy.VolumeMounts).To(ContainElement(prometheusKeyPair.VolumeMount(rmeta.OSTypeLinux)))
+		Expect(am.Spec.Volumes).To(ContainElement(prometheusKeyPair.Volume()))
+
+		policies, _ := monitor.MonitorPolicy(cfg).Objects()
+		policy := testutils.GetAllowTigeraPolicyFromResources(types.NamespacedName{Name: monitor.AlertManagerPolicyName, Namespace: common.TigeraPrometheusNamespace}, policies)
+		Expect(policy.Spec.Ingress[0].Destination.Ports).To(Equal(networkpolicy.Ports(9093, 9096)))
+	})
+
+	It("Should not render the Alertmanager exposure by default", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		Expect(rtest.GetResource(toCreate, monitor.AlertmanagerExternalServiceName, common.TigeraPrometheusNamespace, "", "v1", "Service")).To(BeNil())
+		Expect(rtest.GetResource(toDelete, monitor.AlertmanagerExternalServiceName, common.TigeraPrometheusNamespace, "", "v1", "Service")).NotTo(BeNil())
+		am := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(am.Spec.Containers).To(BeEmpty())
+	})
+
+	It("Should render the configured Alertmanager replicas", func() {
+		cfg.Installation.ControlPlaneReplicas = ptr.Int32ToPtr(2)
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		am := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(*am.Spec.Replicas).To(Equal(int32(2)))
+
+		cfg.Monitor.AlertmanagerReplicas = ptr.Int32ToPtr(3)
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		am = rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(*am.Spec.Replicas).To(Equal(int32(3)))
+		Expect(monitor.AlertmanagerReplicas(cfg.Monitor, cfg.Installation)).To(Equal(int32(3)))
+	})
+
+	It("Should render the configured Alertmanager log level", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+		am := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(am.Spec.LogLevel).To(BeEmpty())
+
+		level := operatorv1.LogLevelWarn
+		cfg.Monitor.AlertmanagerLogLevel = &level
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+		am = rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.AlertmanagersKind).(*monitoringv1.Alertmanager)
+		Expect(am.Spec.LogLevel).To(Equal("warn"))
+	})
+
+	It("Should render an Alertmanager PodDisruptionBudget with the configured minAvailable", func() {
+		minAvailable := intstr.FromInt(2)
+		cfg.Installation.ControlPlaneReplicas = ptr.Int32ToPtr(3)
+		cfg.Monitor.AlertManager = &operatorv1.AlertManager{
+			AlertManagerSpec: &operatorv1.AlertManagerSpec{
+				PodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MinAvailable: &minAvailable},
+			},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		pdb := rtest.GetResource(toCreate, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+		Expect(pdb.Spec.MinAvailable).To(Equal(&minAvailable))
+		Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+		Expect(pdb.Spec.Selector.MatchLabels).To(Equal(map[string]string{
+			"app.kubernetes.io/name": "alertmanager",
+			"alertmanager":           monitor.CalicoNodeAlertmanager,
+		}))
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodeAlertmanager, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget")).To(BeNil())
+	})
+
+	It("Should not render PodDisruptionBudgets for components running a single replica", func() {
+		maxUnavailable := intstr.FromInt(1)
+		cfg.Installation.ControlPlaneReplicas = ptr.Int32ToPtr(1)
+		cfg.Monitor.AlertManager = &operatorv1.AlertManager{
+			AlertManagerSpec: &operatorv1.AlertManagerSpec{
+				PodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable},
+			},
+		}
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{
+			PrometheusSpec: &operatorv1.PrometheusSpec{
+				PodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable},
+			},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		for _, name := range []string{monitor.CalicoNodeAlertmanager, monitor.CalicoNodePrometheus} {
+			Expect(rtest.GetResource(toCreate, name, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget")).To(BeNil())
+			Expect(rtest.GetResource(toDelete, name, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget")).NotTo(BeNil())
+		}
+	})
+
+	It("Should spread multiple Prometheus replicas across nodes with pod anti-affinity", func() {
+		cfg.Monitor.PrometheusReplicas = ptr.Int32ToPtr(2)
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Replicas).To(Equal(ptr.Int32ToPtr(2)))
+		Expect(prometheus.Spec.Affinity).To(Equal(podaffinity.NewPodAntiAffinity(monitor.TigeraPrometheusObjectName, common.TigeraPrometheusNamespace)))
+		Expect(prometheus.Spec.PodMetadata.Labels).To(HaveKeyWithValue(podaffinity.K8sAppLabelName, monitor.TigeraPrometheusObjectName))
+	})
+
+	It("Should not set Prometheus pod anti-affinity for a single replica", func() {
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		prometheus := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Replicas).To(BeNil())
+		Expect(prometheus.Spec.Affinity).To(BeNil())
+
+		cfg.Monitor.PrometheusReplicas = ptr.Int32ToPtr(1)
+		component = monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ = component.Objects()
+
+		prometheus = rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "monitoring.coreos.com", "v1", monitoringv1.PrometheusesKind).(*monitoringv1.Prometheus)
+		Expect(prometheus.Spec.Replicas).To(Equal(ptr.Int32ToPtr(1)))
+		Expect(prometheus.Spec.Affinity).To(BeNil())
+	})
+
+	It("Should render a Prometheus PodDisruptionBudget when Prometheus runs multiple replicas", func() {
+		maxUnavailable := intstr.FromInt(1)
+		cfg.Monitor.PrometheusReplicas = ptr.Int32ToPtr(2)
+		cfg.Monitor.Prometheus = &operatorv1.Prometheus{
+			PrometheusSpec: &operatorv1.PrometheusSpec{
+				PodDisruptionBudget: &operatorv1.PodDisruptionBudgetConfig{MaxUnavailable: &maxUnavailable},
+			},
+		}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		pdb := rtest.GetResource(toCreate, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget").(*policyv1.PodDisruptionBudget)
+		Expect(pdb.Spec.MaxUnavailable).To(Equal(&maxUnavailable))
+		Expect(rtest.GetResource(toDelete, monitor.CalicoNodePrometheus, common.TigeraPrometheusNamespace, "policy", "v1", "PodDisruptionBudget")).To(BeNil())
+	})
+
+	It("Should render the additional service monitors and delete the stale ones", func() {
+		relabelings := []*monitoringv1.RelabelConfig{{Action: "drop", SourceLabels: []monitoringv1.LabelName{"__name__"}, Regex: "go_.*"}}
+		cfg.Monitor.AdditionalServiceMonitors = []operatorv1.AdditionalServiceMonitor{
+			{
+				Name:       "sidecar",
+				Namespaces: []string{"apps"},
+				Selector:   metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Endpoints: []operatorv1.AdditionalServiceMonitorEndpoint{
+					{Port: 9102, Path: "/stats", Interval: "30s", ScrapeTimeout: "10s", MetricRelabelConfigs: relabelings},
+				},
+			},
+		}
+		cfg.StaleAdditionalServiceMonitors = []string{"old-sidecar"}
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, toDelete := component.Objects()
+
+		targetPort := intstr.FromInt(9102)
+		sm := rtest.GetResource(toCreate, "sidecar", "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor").(*monitoringv1.ServiceMonitor)
+		Expect(sm).To(Equal(&monitoringv1.ServiceMonitor{
+			TypeMeta: metav1.TypeMeta{Kind: monitoringv1.ServiceMonitorsKind, APIVersion: "monitoring.coreos.com/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sidecar",
+				Namespace: "tigera-prometheus",
+				Labels:    map[string]string{"team": "network-operators", monitor.AdditionalServiceMonitorLabel: "true"},
+			},
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Endpoints: []monitoringv1.Endpoint{
+					{
+						TargetPort:           &targetPort,
+						Path:                 "/stats",
+						Scheme:               "http",
+						Interval:             "30s",
+						ScrapeTimeout:        "10s",
+						MetricRelabelConfigs: relabelings,
+					},
+				},
+				NamespaceSelector: monitoringv1.NamespaceSelector{MatchNames: []string{"apps"}},
+				Selector:          metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+		}))
+
+		Expect(rtest.GetResource(toDelete, "old-sidecar", "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor")).NotTo(BeNil())
+		Expect(rtest.GetResource(toDelete, "sidecar", "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor")).To(BeNil())
+	})
+
+	It("Should apply metric relabelings to the operator managed service monitors", func() {
+		relabelings := []*monitoringv1.RelabelConfig{
+			{
+				Action:       "drop",
+				SourceLabels: []monitoringv1.LabelName{"__name__"},
+				Regex:        "felix_bpf_.*",
+			},
+		}
+		cfg.Monitor.MetricRelabelConfigs = relabelings
+		cfg.Installation.TyphaMetricsPort = ptr.Int32ToPtr(9093)
+		component := monitor.Monitor(cfg)
+		Expect(component.ResolveImages(nil)).NotTo(HaveOccurred())
+		toCreate, _ := component.Objects()
+
+		for _, name := range []string{monitor.CalicoNodeMonitor, monitor.ElasticsearchMetrics, render.FluentdMetricsService, render.QueryserverServiceName, monitor.KubeControllerMetrics, render.TyphaMetricsName} {
+			sm := rtest.GetResource(toCreate, name, "tigera-prometheus", "monitoring.coreos.com", "v1", "ServiceMonitor").(*monitoringv1.ServiceMonitor)
+			Expect(sm.Spec.Endpoints).NotTo(BeEmpty())
+			for _, ep := range sm.Spec.Endpoints {
+				Expect(ep.MetricRelabelConfigs).To(Equal(relabelings), "service monitor %s", name)
+			}
+		}
+	})
 })
 
 type resource struct {
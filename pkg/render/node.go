@@ -146,9 +146,6 @@ type nodeComponent struct {
 }
 
 func (c *nodeComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var errMsgs []string
 	appendIfErr := func(imageName string, err error) string {
 		if err != nil {
@@ -159,20 +156,20 @@ func (c *nodeComponent) ResolveImages(is *operatorv1.ImageSet) error {
 
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.cniImage = appendIfErr(components.GetReference(components.ComponentTigeraCNIFIPS, reg, path, prefix, is))
+			c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraCNIFIPS, c.cfg.Installation, is))
 		} else {
-			c.cniImage = appendIfErr(components.GetReference(components.ComponentTigeraCNI, reg, path, prefix, is))
+			c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraCNI, c.cfg.Installation, is))
 		}
-		c.nodeImage = appendIfErr(components.GetReference(components.ComponentTigeraNode, reg, path, prefix, is))
-		c.flexvolImage = appendIfErr(components.GetReference(components.ComponentTigeraFlexVolume, reg, path, prefix, is))
+		c.nodeImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraNode, c.cfg.Installation, is))
+		c.flexvolImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraFlexVolume, c.cfg.Installation, is))
 	} else {
-		c.flexvolImage = appendIfErr(components.GetReference(components.ComponentCalicoFlexVolume, reg, path, prefix, is))
+		c.flexvolImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoFlexVolume, c.cfg.Installation, is))
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.cniImage = appendIfErr(components.GetReference(components.ComponentCalicoCNIFIPS, reg, path, prefix, is))
-			c.nodeImage = appendIfErr(components.GetReference(components.ComponentCalicoNodeFIPS, reg, path, prefix, is))
+			c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoCNIFIPS, c.cfg.Installation, is))
+			c.nodeImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoNodeFIPS, c.cfg.Installation, is))
 		} else {
-			c.cniImage = appendIfErr(components.GetReference(components.ComponentCalicoCNI, reg, path, prefix, is))
-			c.nodeImage = appendIfErr(components.GetReference(components.ComponentCalicoNode, reg, path, prefix, is))
+			c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoCNI, c.cfg.Installation, is))
+			c.nodeImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoNode, c.cfg.Installation, is))
 		}
 	}
 
@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/k8sapi"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+const OperatorNetworkPolicyName = "tigera-operator"
+
+// OperatorNetworkPolicy renders a Kubernetes NetworkPolicy that restricts the egress of the operator pod.
+// A native policy is used rather than a Calico one since it must be enforceable before the Calico API server exists.
+func OperatorNetworkPolicy(cfg *OperatorNetworkPolicyConfiguration) Component {
+	return &operatorNetworkPolicyComponent{cfg: cfg}
+}
+
+// OperatorNetworkPolicyConfiguration contains all the config information needed to render the component.
+type OperatorNetworkPolicyConfiguration struct {
+	Installation *operatorv1.InstallationSpec
+	K8sServiceEp k8sapi.ServiceEndpoint
+
+	// The addresses of the kubernetes service's endpoints. The operator's egress to the API server is restricted
+	// to these addresses and to the host of K8sServiceEp, if it is an IP.
+	APIServerAddresses []string
+
+	// Whether the policy should be rendered. When false, the policy is removed.
+	Enabled bool
+}
+
+type operatorNetworkPolicyComponent struct {
+	cfg *OperatorNetworkPolicyConfiguration
+}
+
+func (c *operatorNetworkPolicyComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *operatorNetworkPolicyComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *operatorNetworkPolicyComponent) Objects() ([]client.Object, []client.Object) {
+	if !c.cfg.Enabled {
+		return nil, []client.Object{&netv1.NetworkPolicy{
+			TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: OperatorNetworkPolicyName, Namespace: common.OperatorNamespace()},
+		}}
+	}
+	return []client.Object{c.networkPolicy()}, nil
+}
+
+func (c *operatorNetworkPolicyComponent) Ready() bool {
+	return true
+}
+
+func (c *operatorNetworkPolicyComponent) networkPolicy() *netv1.NetworkPolicy {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+
+	dnsPorts := []int{53}
+	if c.cfg.Installation.KubernetesProvider == operatorv1.ProviderOpenShift {
+		dnsPorts = append(dnsPorts, 5353)
+	}
+	var dns []netv1.NetworkPolicyPort
+	for _, port := range dnsPorts {
+		p := intstr.FromInt(port)
+		dns = append(dns, netv1.NetworkPolicyPort{Protocol: &udp, Port: &p}, netv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+	}
+
+	// Policy is evaluated after the kubernetes service has been resolved to the API server backends, so allow both the
+	// common backend ports and the port of the configured endpoint.
+	apiServerPorts := []int{443, 6443}
+	if port, err := strconv.Atoi(c.cfg.K8sServiceEp.Port); err == nil && port != 443 && port != 6443 {
+		apiServerPorts = append(apiServerPorts, port)
+	}
+	var apiServer []netv1.NetworkPolicyPort
+	for _, port := range apiServerPorts {
+		p := intstr.FromInt(port)
+		apiServer = append(apiServer, netv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+	}
+
+	return &netv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorNetworkPolicyName, Namespace: common.OperatorNamespace()},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"name": "tigera-operator"}},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeEgress},
+			Egress: []netv1.NetworkPolicyEgressRule{
+				{Ports: dns},
+				{Ports: apiServer, To: c.apiServerPeers()},
+				{
+					To: []netv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "name", Operator: metav1.LabelSelectorOpIn, Values: c.managedNamespaces()},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// apiServerPeers returns a peer for each address the operator reaches the API server at.
+func (c *operatorNetworkPolicyComponent) apiServerPeers() []netv1.NetworkPolicyPeer {
+	addresses := c.cfg.APIServerAddresses
+	if net.ParseIP(c.cfg.K8sServiceEp.Host) != nil {
+		addresses = append([]string{c.cfg.K8sServiceEp.Host}, addresses...)
+	}
+
+	var peers []netv1.NetworkPolicyPeer
+	seen := map[string]bool{}
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			continue
+		}
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+		if seen[cidr] {
+			continue
+		}
+		seen[cidr] = true
+		peers = append(peers, netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: cidr}})
+	}
+	return peers
+}
+
+// managedNamespaces returns the namespaces of the components the operator connects to directly.
+func (c *operatorNetworkPolicyComponent) managedNamespaces() []string {
+	namespaces := []string{common.CalicoNamespace, rmeta.APIServerNamespace(c.cfg.Installation.Variant)}
+	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
+		namespaces = append(namespaces, ElasticsearchNamespace, KibanaNamespace)
+	}
+	return namespaces
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	netv1 "k8s.io/api/networking/v1"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	"github.com/tigera/operator/pkg/controller/k8sapi"
+	"github.com/tigera/operator/pkg/render"
+	rtest "github.com/tigera/operator/pkg/render/common/test"
+)
+
+var _ = Describe("Operator NetworkPolicy rendering tests", func() {
+	var cfg *render.OperatorNetworkPolicyConfiguration
+
+	BeforeEach(func() {
+		cfg = &render.OperatorNetworkPolicyConfiguration{
+			Installation:       &operatorv1.InstallationSpec{Variant: operatorv1.Calico, KubernetesProvider: operatorv1.ProviderNone},
+			K8sServiceEp:       k8sapi.ServiceEndpoint{Host: "1.2.3.4", Port: "8443"},
+			APIServerAddresses: []string{"10.0.0.1", "10.0.0.2"},
+			Enabled:            true,
+		}
+	})
+
+	ports := func(rule netv1.NetworkPolicyEgressRule) []string {
+		var ps []string
+		for _, p := range rule.Ports {
+			ps = append(ps, string(*p.Protocol)+"/"+p.Port.String())
+		}
+		return ps
+	}
+
+	It("should render an egress policy for the operator pod when enabled", func() {
+		toCreate, toDelete := render.OperatorNetworkPolicy(cfg).Objects()
+		Expect(toDelete).To(BeEmpty())
+		Expect(toCreate).To(HaveLen(1))
+		rtest.ExpectResourceTypeAndObjectMetadata(toCreate[0], render.OperatorNetworkPolicyName, common.OperatorNamespace(), "networking.k8s.io", "v1", "NetworkPolicy")
+
+		policy := toCreate[0].(*netv1.NetworkPolicy)
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"name": "tigera-operator"}))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(netv1.PolicyTypeEgress))
+		Expect(policy.Spec.Ingress).To(BeEmpty())
+		Expect(policy.Spec.Egress).To(HaveLen(3))
+
+		Expect(ports(policy.Spec.Egress[0])).To(ConsistOf("UDP/53", "TCP/53"))
+		Expect(ports(policy.Spec.Egress[1])).To(ConsistOf("TCP/443", "TCP/6443", "TCP/8443"))
+		Expect(policy.Spec.Egress[1].To).To(ConsistOf(
+			netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "1.2.3.4/32"}},
+			netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.1/32"}},
+			netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.2/32"}},
+		))
+
+		Expect(policy.Spec.Egress[2].Ports).To(BeEmpty())
+		Expect(policy.Spec.Egress[2].To).To(HaveLen(1))
+		selector := policy.Spec.Egress[2].To[0].NamespaceSelector
+		Expect(selector).NotTo(BeNil())
+		Expect(selector.MatchExpressions).To(HaveLen(1))
+		Expect(selector.MatchExpressions[0].Key).To(Equal("name"))
+		Expect(selector.MatchExpressions[0].Values).To(ConsistOf("calico-system", "calico-apiserver"))
+	})
+
+	It("should allow the enterprise namespaces and OpenShift DNS", func() {
+		cfg.Installation.Variant = operatorv1.TigeraSecureEnterprise
+		cfg.Installation.KubernetesProvider = operatorv1.ProviderOpenShift
+		cfg.K8sServiceEp = k8sapi.ServiceEndpoint{}
+
+		toCreate, _ := render.OperatorNetworkPolicy(cfg).Objects()
+		Expect(toCreate).To(HaveLen(1))
+		policy := toCreate[0].(*netv1.NetworkPolicy)
+
+		Expect(ports(policy.Spec.Egress[0])).To(ConsistOf("UDP/53", "TCP/53", "UDP/5353", "TCP/5353"))
+		Expect(ports(policy.Spec.Egress[1])).To(ConsistOf("TCP/443", "TCP/6443"))
+		Expect(policy.Spec.Egress[1].To).To(HaveLen(2))
+		Expect(policy.Spec.Egress[2].To[0].NamespaceSelector.MatchExpressions[0].Values).To(ConsistOf(
+			"calico-system", "tigera-system", "tigera-elasticsearch", "tigera-kibana"))
+	})
+
+	It("should restrict the API server egress to the endpoint addresses when the host is not an IP", func() {
+		cfg.K8sServiceEp = k8sapi.ServiceEndpoint{Host: "api.example.com", Port: "6443"}
+		cfg.APIServerAddresses = []string{"fd00::1", "10.0.0.1", "10.0.0.1"}
+
+		toCreate, _ := render.OperatorNetworkPolicy(cfg).Objects()
+		Expect(toCreate).To(HaveLen(1))
+		policy := toCreate[0].(*netv1.NetworkPolicy)
+		Expect(policy.Spec.Egress[1].To).To(ConsistOf(
+			netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "fd00::1/128"}},
+			netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.1/32"}},
+		))
+	})
+
+	It("should delete the policy when disabled", func() {
+		cfg.Enabled = false
+		toCreate, toDelete := render.OperatorNetworkPolicy(cfg).Objects()
+		Expect(toCreate).To(BeEmpty())
+		Expect(toDelete).To(HaveLen(1))
+		rtest.ExpectResourceTypeAndObjectMetadata(toDelete[0], render.OperatorNetworkPolicyName, common.OperatorNamespace(), "networking.k8s.io", "v1", "NetworkPolicy")
+	})
+})
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1 "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/common"
+	rmeta "github.com/tigera/operator/pkg/render/common/meta"
+)
+
+const (
+	// OperatorWebhookServiceName is the Service that fronts the operator's webhook server. The webhook configurations
+	// reference it, and the webhook server certificate is issued for its DNS names.
+	OperatorWebhookServiceName = "tigera-operator-webhook"
+
+	// OperatorWebhookTLSSecretName is the secret holding the webhook server certificate. It is expected to be mounted
+	// into the operator pod at the webhook server's certificate directory.
+	OperatorWebhookTLSSecretName = "tigera-operator-webhook-tls"
+
+	// OperatorWebhookConfigurationName is the name of the ValidatingWebhookConfiguration and
+	// MutatingWebhookConfiguration whose CABundle the operator keeps up to date.
+	OperatorWebhookConfigurationName = "tigera-operator"
+
+	OperatorWebhookPort = 9443
+)
+
+// OperatorWebhook renders the Service for the operator's webhook server.
+func OperatorWebhook(cfg *OperatorWebhookConfiguration) Component {
+	return &operatorWebhookComponent{cfg: cfg}
+}
+
+// OperatorWebhookConfiguration contains all the config information needed to render the component.
+type OperatorWebhookConfiguration struct {
+	// Whether the webhook Service should be rendered. When false, the Service is removed.
+	Enabled bool
+}
+
+type operatorWebhookComponent struct {
+	cfg *OperatorWebhookConfiguration
+}
+
+func (c *operatorWebhookComponent) ResolveImages(is *operatorv1.ImageSet) error {
+	return nil
+}
+
+func (c *operatorWebhookComponent) SupportedOSType() rmeta.OSType {
+	return rmeta.OSTypeAny
+}
+
+func (c *operatorWebhookComponent) Objects() ([]client.Object, []client.Object) {
+	if !c.cfg.Enabled {
+		return nil, []client.Object{c.service()}
+	}
+	return []client.Object{c.service()}, nil
+}
+
+func (c *operatorWebhookComponent) Ready() bool {
+	return true
+}
+
+func (c *operatorWebhookComponent) service() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorWebhookServiceName, Namespace: common.OperatorNamespace()},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"name": "tigera-operator"},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "webhook-server",
+					Port:       443,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(OperatorWebhookPort),
+				},
+			},
+		},
+	}
+}
@@ -89,12 +89,9 @@ func PacketCaptureAPIPolicy(cfg *PacketCaptureApiConfiguration) Component {
 }
 
 func (pc *packetCaptureApiComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := pc.cfg.Installation.Registry
-	path := pc.cfg.Installation.ImagePath
-	prefix := pc.cfg.Installation.ImagePrefix
 
 	var err error
-	pc.image, err = components.GetReference(components.ComponentPacketCapture, reg, path, prefix, is)
+	pc.image, err = components.GetInstallationReference(components.ComponentPacketCapture, pc.cfg.Installation, is)
 	if err != nil {
 		return err
 	}
@@ -95,12 +95,9 @@ func PolicyRecommendation(cfg *PolicyRecommendationConfiguration) Component {
 }
 
 func (pr *policyRecommendationComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := pr.cfg.Installation.Registry
-	path := pr.cfg.Installation.ImagePath
-	prefix := pr.cfg.Installation.ImagePrefix
 
 	var err error
-	pr.image, err = components.GetReference(components.ComponentPolicyRecommendation, reg, path, prefix, is)
+	pr.image, err = components.GetInstallationReference(components.ComponentPolicyRecommendation, pr.cfg.Installation, is)
 	if err != nil {
 		return err
 	}
@@ -89,17 +89,14 @@ type typhaComponent struct {
 }
 
 func (c *typhaComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var err error
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.typhaImage, err = components.GetReference(components.ComponentTigeraTypha, reg, path, prefix, is)
+		c.typhaImage, err = components.GetInstallationReference(components.ComponentTigeraTypha, c.cfg.Installation, is)
 	} else {
 		if operatorv1.IsFIPSModeEnabled(c.cfg.Installation.FIPSMode) {
-			c.typhaImage, err = components.GetReference(components.ComponentCalicoTyphaFIPS, reg, path, prefix, is)
+			c.typhaImage, err = components.GetInstallationReference(components.ComponentCalicoTyphaFIPS, c.cfg.Installation, is)
 		} else {
-			c.typhaImage, err = components.GetReference(components.ComponentCalicoTypha, reg, path, prefix, is)
+			c.typhaImage, err = components.GetInstallationReference(components.ComponentCalicoTypha, c.cfg.Installation, is)
 		}
 	}
 	if err != nil {
@@ -66,9 +66,6 @@ type windowsComponent struct {
 }
 
 func (c *windowsComponent) ResolveImages(is *operatorv1.ImageSet) error {
-	reg := c.cfg.Installation.Registry
-	path := c.cfg.Installation.ImagePath
-	prefix := c.cfg.Installation.ImagePrefix
 	var errMsgs []string
 	appendIfErr := func(imageName string, err error) string {
 		if err != nil {
@@ -78,11 +75,11 @@ func (c *windowsComponent) ResolveImages(is *operatorv1.ImageSet) error {
 	}
 
 	if c.cfg.Installation.Variant == operatorv1.TigeraSecureEnterprise {
-		c.cniImage = appendIfErr(components.GetReference(components.ComponentTigeraCNIWindows, reg, path, prefix, is))
-		c.nodeImage = appendIfErr(components.GetReference(components.ComponentTigeraNodeWindows, reg, path, prefix, is))
+		c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraCNIWindows, c.cfg.Installation, is))
+		c.nodeImage = appendIfErr(components.GetInstallationReference(components.ComponentTigeraNodeWindows, c.cfg.Installation, is))
 	} else {
-		c.cniImage = appendIfErr(components.GetReference(components.ComponentCalicoCNIWindows, reg, path, prefix, is))
-		c.nodeImage = appendIfErr(components.GetReference(components.ComponentCalicoNodeWindows, reg, path, prefix, is))
+		c.cniImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoCNIWindows, c.cfg.Installation, is))
+		c.nodeImage = appendIfErr(components.GetInstallationReference(components.ComponentCalicoNodeWindows, c.cfg.Installation, is))
 	}
 
 	if len(errMsgs) != 0 {
@@ -339,7 +336,7 @@ func (c *windowsComponent) createCalicoPluginConfig() map[string]interface{} {
 	dns := map[string]interface{}{
 		"Nameservers": c.cfg.K8sDNSServers,
 		"Search": []string{
-			"svc.cluster.local",
+			fmt.Sprintf("svc.%s", c.cfg.ClusterDomain),
 		},
 	}
 	calicoPluginConfig["DNS"] = dns
@@ -15,6 +15,7 @@
 package render_test
 
 import (
+	"encoding/json"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -48,7 +49,7 @@ var _ = Describe("Windows rendering tests", func() {
 	var k8sServiceEp k8sapi.ServiceEndpoint
 	one := intstr.FromInt(1)
 	defaultNumExpectedResources := 2
-	const defaultClusterDomain = "svc.cluster.local"
+	const defaultClusterDomain = "cluster.local"
 	var defaultMode int32 = 420
 	var cfg render.WindowsConfiguration
 	var cli client.Client
@@ -2137,6 +2138,24 @@ var _ = Describe("Windows rendering tests", func() {
 		Expect(ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable).To(Equal(&two))
 	})
 
+	It("should render the cni DNS search path for a non-default cluster domain", func() {
+		cfg.ClusterDomain = "example.internal"
+		component := render.Windows(&cfg)
+		Expect(component.ResolveImages(nil)).To(BeNil())
+		resources, _ := component.Objects()
+
+		cniCm := rtest.GetResource(resources, "cni-config-windows", "calico-system", "", "v1", "ConfigMap").(*corev1.ConfigMap)
+		var cniConfig struct {
+			Plugins []struct {
+				DNS struct {
+					Search []string
+				}
+			} `json:"plugins"`
+		}
+		Expect(json.Unmarshal([]byte(cniCm.Data["config"]), &cniConfig)).To(Succeed())
+		Expect(cniConfig.Plugins[0].DNS.Search).To(Equal([]string{"svc.example.internal"}))
+	})
+
 	It("should render cni config with host-local", func() {
 		defaultInstance.CNI.IPAM.Type = operatorv1.IPAMPluginHostLocal
 		component := render.Windows(&cfg)
@@ -93,21 +93,9 @@ func CreateCSRInitContainer(
 // ResolveCsrInitImage resolves the image needed for the CSR init image taking into account the specified ImageSet
 func ResolveCSRInitImage(inst *operatorv1.InstallationSpec, is *operatorv1.ImageSet) (string, error) {
 	if inst.Variant == operatorv1.TigeraSecureEnterprise {
-		return components.GetReference(
-			components.ComponentTigeraCSRInitContainer,
-			inst.Registry,
-			inst.ImagePath,
-			inst.ImagePrefix,
-			is,
-		)
+		return components.GetInstallationReference(components.ComponentTigeraCSRInitContainer, inst, is)
 	}
-	return components.GetReference(
-		components.ComponentCalicoCSRInitContainer,
-		inst.Registry,
-		inst.ImagePath,
-		inst.ImagePrefix,
-		is,
-	)
+	return components.GetInstallationReference(components.ComponentCalicoCSRInitContainer, inst, is)
 }
 
 // CSRClusterRole returns a role with the necessary permissions to create certificate signing requests.
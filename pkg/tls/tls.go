@@ -27,6 +27,9 @@ import (
 
 const DefaultCertificateDuration = 825 * 24 * time.Hour
 
+// DefaultCertificateRenewalWindow is how long before expiry operator issued certificates are renewed.
+const DefaultCertificateRenewalWindow = 30 * 24 * time.Hour
+
 func SetClientAuth(x *x509.Certificate) error {
 	if x.ExtKeyUsage == nil {
 		x.ExtKeyUsage = []x509.ExtKeyUsage{}
@@ -36,6 +36,7 @@ import (
 
 	v3 "github.com/tigera/api/pkg/apis/projectcalico/v3"
 	operator "github.com/tigera/operator/api/v1"
+	"github.com/tigera/operator/pkg/components"
 	"github.com/tigera/operator/pkg/controller/status"
 
 	corev1 "k8s.io/api/core/v1"
@@ -147,6 +148,27 @@ func MakeTestCA(signer string) *crypto.CA {
 	}
 }
 
+// CompleteImageSet returns the ImageSet for the variant with an entry for every image of the variant. The given
+// images override the entries of the same name, all other images get a placeholder digest.
+func CompleteImageSet(variant operator.ProductVariant, images ...operator.Image) *operator.ImageSet {
+	digests := map[string]string{}
+	for _, img := range images {
+		digests[img.Image] = img.Digest
+	}
+
+	is := &operator.ImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: components.ImageSetName(variant)},
+	}
+	for _, img := range components.ImageSetImages(variant) {
+		digest, ok := digests[img]
+		if !ok {
+			digest = "sha256:" + img
+		}
+		is.Spec.Images = append(is.Spec.Images, operator.Image{Image: img, Digest: digest})
+	}
+	return is
+}
+
 // Mock a cache.ListWatcher for nodes to use in the test as there is no other suitable
 // mock available in the fake packages.
 // Ref: https://github.com/kubernetes/client-go/issues/352#issuecomment-614740790